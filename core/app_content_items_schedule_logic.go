@@ -0,0 +1,139 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/interfaces"
+	"content/core/model"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+// contentItemsScheduleInterval is the longest wait between the checks for the content items which are published or expire,
+// the writes which schedule an earlier time wake the timer up
+const contentItemsScheduleInterval = 10 * time.Minute
+
+// contentItemsScheduleLogic gives the content items which are published or expire at their scheduled times to the stream, as nothing is written then
+type contentItemsScheduleLogic struct {
+	interfaces.DefaultStorageListener
+
+	logger logs.Logger
+
+	storage interfaces.Storage
+	stream  *contentItemsStream
+
+	//the items which are published or expire until this time have been given to the stream
+	checked time.Time
+
+	//the next time when an item is published or expires
+	next        *time.Time
+	nextLock    *sync.Mutex
+	rescheduled chan bool
+
+	//schedule timer
+	scheduleTimer *time.Timer
+	timerDone     chan bool
+}
+
+func (c *contentItemsScheduleLogic) start() {
+	c.logger.Info("Content items schedule timer")
+
+	c.checked = time.Now().UTC()
+	c.storage.RegisterStorageListener(c)
+	go c.process()
+}
+
+func (c *contentItemsScheduleLogic) process() {
+	for {
+		c.processSchedule()
+
+		wait := contentItemsScheduleInterval
+		c.nextLock.Lock()
+		if c.next != nil && time.Until(*c.next) < wait {
+			wait = time.Until(*c.next)
+		}
+		c.nextLock.Unlock()
+
+		c.scheduleTimer = time.NewTimer(wait)
+		select {
+		case <-c.scheduleTimer.C:
+			c.scheduleTimer = nil
+		case <-c.rescheduled:
+			c.scheduleTimer.Stop()
+			c.scheduleTimer = nil
+		case <-c.timerDone:
+			// timer aborted
+			c.logger.Info("Content items schedule process -> timer aborted")
+			c.scheduleTimer = nil
+			return
+		}
+	}
+}
+
+// processSchedule gives the items which have been published or have expired since the last check to the stream and finds the next time
+func (c *contentItemsScheduleLogic) processSchedule() {
+	now := time.Now().UTC()
+	events, err := c.storage.FindContentItemsVisibilityChanges(c.checked, now)
+	if err != nil {
+		c.logger.Errorf("error on finding the published and expired content items - %s", err)
+	} else {
+		for _, event := range events {
+			//the clients resume with it as with the changes
+			event.ID = uuid.NewString()
+			c.stream.OnContentItemChanged(event)
+		}
+		c.checked = now
+	}
+
+	next, err := c.storage.FindNextContentItemsVisibilityChange(c.checked)
+	if err != nil {
+		c.logger.Errorf("error on finding the next time when a content item is published or expires - %s", err)
+	}
+	c.nextLock.Lock()
+	c.next = next
+	c.nextLock.Unlock()
+}
+
+// OnContentItemChanged wakes the timer up when the changed item is published or expires before the next time
+func (c *contentItemsScheduleLogic) OnContentItemChanged(event model.ContentItemEvent) {
+	item := event.Content
+	if item == nil || event.Type == model.ContentItemEventDeleted {
+		return
+	}
+
+	now := time.Now().UTC()
+	c.nextLock.Lock()
+	defer c.nextLock.Unlock()
+	for _, at := range []*time.Time{item.PublishAt, item.ExpireAt, item.ExpiresAt} {
+		if at == nil || !at.After(now) || (c.next != nil && !at.Before(*c.next)) {
+			continue
+		}
+		c.next = at
+		select {
+		case c.rescheduled <- true:
+		default:
+			//it is already woken up
+		}
+	}
+}
+
+// newContentItemsScheduleLogic creates new contentItemsScheduleLogic
+func newContentItemsScheduleLogic(logger logs.Logger, storage interfaces.Storage, stream *contentItemsStream) *contentItemsScheduleLogic {
+	return &contentItemsScheduleLogic{logger: logger, storage: storage, stream: stream, nextLock: &sync.Mutex{},
+		rescheduled: make(chan bool, 1), timerDone: make(chan bool)}
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/interfaces"
+	"content/core/model"
+	"sync"
+	"time"
+)
+
+const (
+	//contentItemsStreamHistory is the count of the latest events which are kept for the clients which resume.
+	//They are kept in memory, so the clients which resume with another instance get a reset event.
+	contentItemsStreamHistory = 1000
+	//contentItemsStreamBuffer is the count of the events which could wait for a client, the slower ones are dropped
+	contentItemsStreamBuffer = 256
+)
+
+// contentItemsSubscriber is a client which gets the changes of the content items
+type contentItemsSubscriber struct {
+	orgID      string
+	appID      *string                   // nil for the items for all the apps within the organization
+	categories map[string]bool           // all the categories when empty
+	hidden     map[string]bool           // the categories which the subscriber cannot read
+	viewer     *model.ContentItemsViewer // the audience of the items is checked against it
+	locales    []string                  // the locale chain, the items are given as they are stored when it is nil
+	format     string                    // the format of the markdown fields, used together with the locales
+
+	events chan model.ContentItemEvent
+}
+
+// contentItemsStream pushes the changes of the content items to the subscribed clients
+type contentItemsStream struct {
+	interfaces.DefaultStorageListener
+
+	lock *sync.Mutex
+
+	history     []model.ContentItemEvent
+	subscribers map[*contentItemsSubscriber]bool
+}
+
+// OnContentItemChanged is called by the storage for every change of a content item
+func (c *contentItemsStream) OnContentItemChanged(event model.ContentItemEvent) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.history = append(c.history, event)
+	if len(c.history) > contentItemsStreamHistory {
+		c.history = c.history[len(c.history)-contentItemsStreamHistory:]
+	}
+
+	now := time.Now().UTC()
+	for subscriber := range c.subscribers {
+		if !subscriber.send(event, now) {
+			//it cannot keep up, it resumes with the last event it has got
+			delete(c.subscribers, subscriber)
+			close(subscriber.events)
+		}
+	}
+}
+
+// subscribe adds a subscriber. The events after lastEventID are given first, or a reset event when they are not kept anymore.
+func (c *contentItemsStream) subscribe(subscriber *contentItemsSubscriber, lastEventID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(lastEventID) > 0 {
+		found := false
+		now := time.Now().UTC()
+		for i, event := range c.history {
+			if event.ID != lastEventID {
+				continue
+			}
+			found = true
+			for _, missed := range c.history[i+1:] {
+				if !subscriber.send(missed, now) {
+					//too many to replay
+					found = false
+					subscriber.events = make(chan model.ContentItemEvent, contentItemsStreamBuffer)
+					break
+				}
+			}
+			break
+		}
+		if !found {
+			reset := model.ContentItemEvent{Type: model.ContentItemEventReset}
+			if len(c.history) > 0 {
+				reset.ID = c.history[len(c.history)-1].ID
+			}
+			subscriber.events <- reset
+		}
+	}
+
+	c.subscribers[subscriber] = true
+}
+
+// unsubscribe removes a subscriber, nothing is done if it has already been dropped
+func (c *contentItemsStream) unsubscribe(subscriber *contentItemsSubscriber) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.subscribers[subscriber] {
+		delete(c.subscribers, subscriber)
+		close(subscriber.events)
+	}
+}
+
+// send gives the event to the subscriber if it is interested in it. It gives false if the subscriber cannot get it now.
+func (s *contentItemsSubscriber) send(event model.ContentItemEvent, now time.Time) bool {
+	item := event.Content
+	if item == nil || item.OrgID != s.orgID {
+		return true
+	}
+	if (item.AppID == nil) != (s.appID == nil) || (item.AppID != nil && *item.AppID != *s.appID) {
+		return true
+	}
+	if len(s.categories) > 0 && !s.categories[item.Category] {
+		return true
+	}
+	if s.hidden[item.Category] {
+		return true
+	}
+
+	if event.Type != model.ContentItemEventDeleted && contentItemPublished(*item, now) && contentItemAudienceMatches(item.Audience, s.viewer) {
+		//every subscriber gets its own copy in its locales
+		response := make(model.ContentItemResponse, len(event.Item))
+		for key, value := range event.Item {
+			response[key] = value
+		}
+		//it is given to the clients, so it is shaped as the client reads give it
+		hideContentItemsAudienceNumbers([]model.ContentItemResponse{response})
+		if s.locales != nil {
+			localizeContentItem(response, s.locales, s.format)
+		}
+		event.Item = response
+	} else {
+		//the clients see only the published items for their audience
+		event.Type = model.ContentItemEventDeleted
+		event.Item = nil
+	}
+
+	select {
+	case s.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// contentItemPublished says if the item is visible to the clients at the moment
+func contentItemPublished(item model.ContentItem, now time.Time) bool {
+	if len(item.Status) > 0 && item.Status != model.ContentItemStatusPublished {
+		return false
+	}
+	if item.PublishAt != nil && item.PublishAt.After(now) {
+		return false
+	}
+	if item.ExpireAt != nil && !item.ExpireAt.After(now) {
+		return false
+	}
+	if item.ExpiresAt != nil && !item.ExpiresAt.After(now) {
+		return false
+	}
+	return true
+}
+
+// newContentItemsStream creates new contentItemsStream
+func newContentItemsStream() *contentItemsStream {
+	return &contentItemsStream{lock: &sync.Mutex{}, subscribers: map[*contentItemsSubscriber]bool{}}
+}
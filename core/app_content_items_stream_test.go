@@ -0,0 +1,175 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestContentItemsSubscriberSend(t *testing.T) {
+	now := time.Now().UTC()
+	app, otherApp := "app", "other"
+	later, past := now.Add(time.Hour), now.Add(-time.Hour)
+	anonymous := true
+	item := func(change func(item *model.ContentItem)) *model.ContentItem {
+		item := &model.ContentItem{ID: "1", Category: "news", OrgID: "org", AppID: &app, Status: model.ContentItemStatusPublished}
+		if change != nil {
+			change(item)
+		}
+		return item
+	}
+	tests := []struct {
+		name      string
+		eventType string
+		content   *model.ContentItem
+		hidden    map[string]bool
+		wantType  string // empty when the subscriber does not get the event
+	}{
+		{name: "updated", eventType: model.ContentItemEventUpdated, content: item(nil), wantType: model.ContentItemEventUpdated},
+		{name: "deleted", eventType: model.ContentItemEventDeleted, content: item(nil), wantType: model.ContentItemEventDeleted},
+		{name: "unknown item", eventType: model.ContentItemEventDeleted},
+		{name: "other organization", eventType: model.ContentItemEventUpdated, content: item(func(item *model.ContentItem) { item.OrgID = "other" })},
+		{name: "other app", eventType: model.ContentItemEventUpdated, content: item(func(item *model.ContentItem) { item.AppID = &otherApp })},
+		{name: "all the apps", eventType: model.ContentItemEventUpdated, content: item(func(item *model.ContentItem) { item.AppID = nil })},
+		{name: "other category", eventType: model.ContentItemEventUpdated, content: item(func(item *model.ContentItem) { item.Category = "events" })},
+		{name: "hidden category", eventType: model.ContentItemEventUpdated, content: item(nil), hidden: map[string]bool{"news": true}},
+		{name: "draft", eventType: model.ContentItemEventUpdated, content: item(func(item *model.ContentItem) { item.Status = model.ContentItemStatusDraft }),
+			wantType: model.ContentItemEventDeleted},
+		{name: "not published yet", eventType: model.ContentItemEventCreated, content: item(func(item *model.ContentItem) { item.PublishAt = &later }),
+			wantType: model.ContentItemEventDeleted},
+		{name: "expired", eventType: model.ContentItemEventUpdated, content: item(func(item *model.ContentItem) { item.ExpiresAt = &past }),
+			wantType: model.ContentItemEventDeleted},
+		{name: "other audience", eventType: model.ContentItemEventUpdated,
+			content:  item(func(item *model.ContentItem) { item.Audience = &model.ContentItemAudience{Anonymous: &anonymous} }),
+			wantType: model.ContentItemEventDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscriber := &contentItemsSubscriber{orgID: "org", appID: &app, categories: map[string]bool{"news": true}, hidden: tt.hidden,
+				viewer: &model.ContentItemsViewer{}, events: make(chan model.ContentItemEvent, 1)}
+			event := model.ContentItemEvent{ID: "e1", Type: tt.eventType, ItemID: "1", Category: "news", Content: tt.content,
+				Item: model.ContentItemResponse{"id": "1", "audience": map[string]interface{}{"min_app_version_number": 1}}}
+			if !subscriber.send(event, now) {
+				t.Fatal("send() = false")
+			}
+
+			select {
+			case got := <-subscriber.events:
+				if got.Type != tt.wantType {
+					t.Errorf("send() type = %s, want %s", got.Type, tt.wantType)
+				}
+				if tt.wantType == model.ContentItemEventDeleted && got.Item != nil {
+					t.Errorf("send() item = %v for a deleted item", got.Item)
+				}
+				if tt.wantType != model.ContentItemEventDeleted && !reflect.DeepEqual(got.Item, model.ContentItemResponse{"id": "1", "audience": map[string]interface{}{}}) {
+					t.Errorf("send() item = %v", got.Item)
+				}
+			default:
+				if len(tt.wantType) > 0 {
+					t.Errorf("send() gave nothing, want %s", tt.wantType)
+				}
+			}
+		})
+	}
+}
+
+func TestContentItemsSubscriberSendFull(t *testing.T) {
+	subscriber := &contentItemsSubscriber{orgID: "org", events: make(chan model.ContentItemEvent, 1)}
+	event := model.ContentItemEvent{Type: model.ContentItemEventDeleted, Content: &model.ContentItem{OrgID: "org"}}
+	if !subscriber.send(event, time.Now()) {
+		t.Fatal("send() = false with free buffer")
+	}
+	if subscriber.send(event, time.Now()) {
+		t.Error("send() = true with full buffer")
+	}
+}
+
+func TestContentItemsStreamSubscribe(t *testing.T) {
+	tests := []struct {
+		name        string
+		history     int
+		lastEventID string
+		buffer      int
+		want        []string // the ids of the given events, the reset events are given as reset:<id>
+	}{
+		{name: "new subscriber", history: 3, buffer: 10},
+		{name: "resume", history: 3, lastEventID: "e1", buffer: 10, want: []string{"e2", "e3"}},
+		{name: "resume with the last event", history: 3, lastEventID: "e3", buffer: 10},
+		{name: "unknown event", history: 3, lastEventID: "other", buffer: 10, want: []string{"reset:e3"}},
+		{name: "unknown event without history", lastEventID: "other", buffer: 10, want: []string{"reset:"}},
+		{name: "too many to replay", history: 5, lastEventID: "e1", buffer: 2, want: []string{"reset:e5"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := newContentItemsStream()
+			for i := 1; i <= tt.history; i++ {
+				stream.OnContentItemChanged(model.ContentItemEvent{ID: fmt.Sprintf("e%d", i), Type: model.ContentItemEventDeleted,
+					Content: &model.ContentItem{OrgID: "org"}})
+			}
+			subscriber := &contentItemsSubscriber{orgID: "org", events: make(chan model.ContentItemEvent, tt.buffer)}
+			stream.subscribe(subscriber, tt.lastEventID)
+
+			got := []string{}
+			for len(subscriber.events) > 0 {
+				event := <-subscriber.events
+				if event.Type == model.ContentItemEventReset {
+					got = append(got, "reset:"+event.ID)
+				} else {
+					got = append(got, event.ID)
+				}
+			}
+			if len(tt.want) == 0 {
+				tt.want = []string{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("subscribe() gave %v, want %v", got, tt.want)
+			}
+			if !stream.subscribers[subscriber] {
+				t.Error("subscribe() did not add the subscriber")
+			}
+		})
+	}
+}
+
+func TestContentItemsStreamOnContentItemChanged(t *testing.T) {
+	stream := newContentItemsStream()
+	fast := &contentItemsSubscriber{orgID: "org", events: make(chan model.ContentItemEvent, contentItemsStreamHistory+1)}
+	slow := &contentItemsSubscriber{orgID: "org", events: make(chan model.ContentItemEvent, 1)}
+	stream.subscribe(fast, "")
+	stream.subscribe(slow, "")
+
+	for i := 1; i <= contentItemsStreamHistory+1; i++ {
+		stream.OnContentItemChanged(model.ContentItemEvent{ID: fmt.Sprintf("e%d", i), Type: model.ContentItemEventDeleted,
+			Content: &model.ContentItem{OrgID: "org"}})
+	}
+
+	if len(stream.history) != contentItemsStreamHistory || stream.history[0].ID != "e2" {
+		t.Errorf("history has %d events from %s, want %d from e2", len(stream.history), stream.history[0].ID, contentItemsStreamHistory)
+	}
+	if len(fast.events) != contentItemsStreamHistory+1 || !stream.subscribers[fast] {
+		t.Errorf("the subscriber got %d events, want %d", len(fast.events), contentItemsStreamHistory+1)
+	}
+	if stream.subscribers[slow] {
+		t.Error("the subscriber which cannot keep up is not dropped")
+	}
+	<-slow.events
+	if _, open := <-slow.events; open {
+		t.Error("the events of the dropped subscriber are not closed")
+	}
+}
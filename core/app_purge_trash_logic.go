@@ -0,0 +1,99 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/interfaces"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+// trashPurgeInterval is how often the trash is checked for items which have to be removed for good
+const trashPurgeInterval = time.Hour
+
+type purgeTrashLogic struct {
+	logger logs.Logger
+
+	storage interfaces.Storage
+
+	//how long the deleted items are kept in the trash
+	retention time.Duration
+
+	//purge timer
+	purgeTimer *time.Timer
+	timerDone  chan bool
+}
+
+func (p *purgeTrashLogic) start() {
+	p.logger.Infof("Purge trash timer - retention %s", p.retention)
+
+	go p.process()
+}
+
+func (p *purgeTrashLogic) process() {
+	for {
+		p.processPurge()
+
+		p.logger.Infof("Purging trash process -> next call after %s", trashPurgeInterval)
+		p.purgeTimer = time.NewTimer(trashPurgeInterval)
+		select {
+		case <-p.purgeTimer.C:
+			p.purgeTimer = nil
+		case <-p.timerDone:
+			// timer aborted
+			p.logger.Info("Purging trash process -> timer aborted")
+			p.purgeTimer = nil
+			return
+		}
+	}
+}
+
+func (p *purgeTrashLogic) processPurge() {
+	before := time.Now().UTC().Add(-p.retention)
+
+	count, err := p.storage.PurgeDeletedContentItems(before)
+	if err != nil {
+		p.logger.Errorf("error on purging deleted content items - %s", err)
+	} else if count > 0 {
+		p.logger.Infof("purged %d deleted content items", count)
+	}
+
+	count, err = p.storage.PurgeOrphanedContentItemRevisions()
+	if err != nil {
+		p.logger.Errorf("error on purging the revisions of removed content items - %s", err)
+	} else if count > 0 {
+		p.logger.Infof("purged %d revisions of removed content items", count)
+	}
+
+	count, err = p.storage.PurgeDeletedDataContentItems(before)
+	if err != nil {
+		p.logger.Errorf("error on purging deleted data content items - %s", err)
+	} else if count > 0 {
+		p.logger.Infof("purged %d deleted data content items", count)
+	}
+
+	count, err = p.storage.PurgeDeletedCategories(before)
+	if err != nil {
+		p.logger.Errorf("error on purging deleted categories - %s", err)
+	} else if count > 0 {
+		p.logger.Infof("purged %d deleted categories", count)
+	}
+}
+
+// purgeLogic creates new purgeTrashLogic
+func purgeLogic(logger logs.Logger, storage interfaces.Storage, retention time.Duration) *purgeTrashLogic {
+	return &purgeTrashLogic{logger: logger, storage: storage, retention: retention, timerDone: make(chan bool)}
+}
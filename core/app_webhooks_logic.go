@@ -0,0 +1,162 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/interfaces"
+	"content/core/model"
+	"content/driven/webhooks"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+const (
+	// webhooksInterval is how often the due webhook deliveries are looked for
+	webhooksInterval = 10 * time.Second
+	// webhookDeliveryLease is how long a claimed delivery is not given to anyone else while it is being sent
+	webhookDeliveryLease = 2 * time.Minute
+	// webhookMaxAttempts is how many times a delivery is sent before it is marked as failed
+	webhookMaxAttempts = 8
+	// webhookRetryDelay is the delay before the first retry, it doubles for every next one
+	webhookRetryDelay = 30 * time.Second
+	// webhookMaxRetryDelay is the longest delay between the retries
+	webhookMaxRetryDelay = 6 * time.Hour
+)
+
+type webhooksLogic struct {
+	logger logs.Logger
+
+	storage interfaces.Storage
+	adapter *webhooks.Adapter
+
+	//deliveries timer
+	deliveriesTimer *time.Timer
+	timerDone       chan bool
+}
+
+func (w *webhooksLogic) start() {
+	w.logger.Info("Webhook deliveries timer")
+
+	go w.process()
+}
+
+func (w *webhooksLogic) process() {
+	for {
+		w.processDeliveries()
+
+		w.deliveriesTimer = time.NewTimer(webhooksInterval)
+		select {
+		case <-w.deliveriesTimer.C:
+			w.deliveriesTimer = nil
+		case <-w.timerDone:
+			// timer aborted
+			w.logger.Info("Webhook deliveries process -> timer aborted")
+			w.deliveriesTimer = nil
+			return
+		}
+	}
+}
+
+func (w *webhooksLogic) processDeliveries() {
+	for {
+		delivery, err := w.storage.ClaimWebhookDelivery(time.Now().UTC(), webhookDeliveryLease)
+		if err != nil {
+			w.logger.Errorf("error on claiming webhook delivery - %s", err)
+			return
+		}
+		if delivery == nil {
+			//nothing more to send
+			return
+		}
+
+		w.deliver(*delivery)
+	}
+}
+
+func (w *webhooksLogic) deliver(delivery model.WebhookDelivery) {
+	webhook, err := w.storage.FindWebhook(delivery.AppID, delivery.OrgID, delivery.WebhookID)
+	if err != nil {
+		//it will be claimed again when the lease expires
+		w.logger.Errorf("error on finding webhook %s for delivery %s - %s", delivery.WebhookID, delivery.ID, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	attempt := model.WebhookDeliveryAttempt{Date: now}
+	if webhook == nil || !webhook.Active {
+		attempt.Error = "the webhook is not active"
+		delivery.Attempts = append(delivery.Attempts, attempt)
+		delivery.Status = model.WebhookDeliveryStatusFailed
+		delivery.NextAttempt = nil
+	} else {
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		headers := map[string]string{
+			"X-Webhook-ID":        webhook.ID,
+			"X-Webhook-Delivery":  delivery.ID,
+			"X-Webhook-Event":     delivery.Event,
+			"X-Webhook-Timestamp": timestamp,
+			"X-Webhook-Signature": "sha256=" + signWebhookPayload(webhook.Secret, timestamp, delivery.Payload),
+		}
+
+		statusCode, responseBody, err := w.adapter.Send(webhook.URL, headers, []byte(delivery.Payload))
+		attempt.DurationMS = time.Since(now).Milliseconds()
+		attempt.StatusCode = statusCode
+		attempt.ResponseBody = responseBody
+		if err != nil {
+			attempt.Error = err.Error()
+		} else if statusCode < 200 || statusCode > 299 {
+			attempt.Error = fmt.Sprintf("unexpected response status %d", statusCode)
+		}
+		delivery.Attempts = append(delivery.Attempts, attempt)
+
+		if len(attempt.Error) == 0 {
+			delivery.Status = model.WebhookDeliveryStatusSucceeded
+			delivery.NextAttempt = nil
+		} else if len(delivery.Attempts) >= webhookMaxAttempts {
+			delivery.Status = model.WebhookDeliveryStatusFailed
+			delivery.NextAttempt = nil
+		} else {
+			nextAttempt := time.Now().UTC().Add(webhookRetryBackoff(len(delivery.Attempts)))
+			delivery.NextAttempt = &nextAttempt
+		}
+	}
+
+	dateUpdated := time.Now().UTC()
+	delivery.DateUpdated = &dateUpdated
+	err = w.storage.SaveWebhookDelivery(delivery)
+	if err != nil {
+		w.logger.Errorf("error on saving webhook delivery %s - %s", delivery.ID, err)
+	}
+}
+
+// webhookRetryBackoff gives the delay before the next attempt after the failed ones
+func webhookRetryBackoff(attempts int) time.Duration {
+	delay := webhookRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookMaxRetryDelay {
+			return webhookMaxRetryDelay
+		}
+	}
+	return delay
+}
+
+// newWebhooksLogic creates new webhooksLogic
+func newWebhooksLogic(logger logs.Logger, storage interfaces.Storage, adapter *webhooks.Adapter) *webhooksLogic {
+	return &webhooksLogic{logger: logger, storage: storage, adapter: adapter, timerDone: make(chan bool)}
+}
@@ -19,8 +19,10 @@ import (
 	"content/driven/awsstorage"
 	cacheadapter "content/driven/cache"
 	"content/driven/twitter"
+	"content/driven/webhooks"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/rokwire/logging-library-go/v2/logs"
 )
@@ -43,10 +45,24 @@ type Application struct {
 	multiTenancyAppID string
 	multiTenancyOrgID string
 
+	//the locales to use when the content does not have any of the requested ones
+	localeFallback []string
+
 	logger *logs.Logger
 
 	//delete data logic
 	deleteDataLogic deleteDataLogic
+
+	//removes for good the items which have been in the trash longer than the retention period
+	purgeTrashLogic *purgeTrashLogic
+
+	//pushes the content items changes to the subscribed clients
+	contentItemsStream *contentItemsStream
+	//gives the content items which are published or expire at their scheduled times to the stream
+	contentItemsScheduleLogic *contentItemsScheduleLogic
+
+	//sends the content changes to the webhooks
+	webhooksLogic *webhooksLogic
 }
 
 // Start starts the core part of the application
@@ -59,6 +75,12 @@ func (app *Application) Start() {
 	}
 
 	app.deleteDataLogic.start()
+	app.purgeTrashLogic.start()
+	app.webhooksLogic.start()
+
+	app.storage.RegisterStorageListener(&contentCacheListener{cacheAdapter: app.cacheAdapter})
+	app.storage.RegisterStorageListener(app.contentItemsStream)
+	app.contentItemsScheduleLogic.start()
 }
 
 // as the service starts supporting multi-tenancy we need to add the needed multi-tenancy fields for the existing data,
@@ -111,14 +133,20 @@ func (app *Application) storeMultiTenancyData() error {
 
 // NewApplication creates new Application
 func NewApplication(version string, build string, storage interfaces.Storage, awsAdapter *awsstorage.Adapter,
-	twitterAdapter *twitter.Adapter, cacheadapter *cacheadapter.CacheAdapter, mtAppID string, mtOrgID string,
-	serviceID string, coreBB interfaces.Core, logger *logs.Logger) *Application {
+	twitterAdapter *twitter.Adapter, cacheadapter *cacheadapter.CacheAdapter, webhooksAdapter *webhooks.Adapter, mtAppID string, mtOrgID string,
+	serviceID string, coreBB interfaces.Core, localeFallback []string, trashRetention time.Duration, logger *logs.Logger) *Application {
 	cacheLock := &sync.Mutex{}
 	deleteDataLogic := deleteLogic(*logger, coreBB, serviceID, storage, awsAdapter)
+	purgeTrashLogic := purgeLogic(*logger, storage, trashRetention)
+	webhooksLogic := newWebhooksLogic(*logger, storage, webhooksAdapter)
+	contentItemsStream := newContentItemsStream()
+	contentItemsScheduleLogic := newContentItemsScheduleLogic(*logger, storage, contentItemsStream)
 
 	application := Application{version: version, build: build, cacheLock: cacheLock, storage: storage,
 		awsAdapter: awsAdapter, twitterAdapter: twitterAdapter, cacheAdapter: cacheadapter,
-		multiTenancyAppID: mtAppID, multiTenancyOrgID: mtOrgID, deleteDataLogic: deleteDataLogic, logger: logger}
+		multiTenancyAppID: mtAppID, multiTenancyOrgID: mtOrgID, localeFallback: localeFallback, deleteDataLogic: deleteDataLogic,
+		purgeTrashLogic: purgeTrashLogic, contentItemsStream: contentItemsStream, contentItemsScheduleLogic: contentItemsScheduleLogic,
+		webhooksLogic: webhooksLogic, logger: logger}
 
 	// add the drivers ports/interfaces
 	application.Services = &servicesImpl{app: &application}
@@ -0,0 +1,105 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"content/utils"
+	"errors"
+
+	"github.com/rokwire/core-auth-library-go/v3/authutils"
+)
+
+// prepareContentItemAudience checks the audience of a content item and keeps its app versions as numbers too
+func prepareContentItemAudience(audience *model.ContentItemAudience) error {
+	if audience == nil {
+		return nil
+	}
+
+	audience.MinAppVersionNumber = nil
+	audience.MaxAppVersionNumber = nil
+	if audience.MinAppVersion != nil {
+		number, err := utils.VersionNumber(*audience.MinAppVersion)
+		if err != nil {
+			return err
+		}
+		audience.MinAppVersionNumber = &number
+	}
+	if audience.MaxAppVersion != nil {
+		number, err := utils.VersionNumber(*audience.MaxAppVersion)
+		if err != nil {
+			return err
+		}
+		audience.MaxAppVersionNumber = &number
+	}
+	if audience.MinAppVersion != nil && audience.MaxAppVersion != nil && utils.IsVersionLess(*audience.MaxAppVersion, *audience.MinAppVersion) {
+		return errors.New("max_app_version must not be less than min_app_version")
+	}
+	return nil
+}
+
+// contentItemAudienceMatches checks the audience of a content item against the viewer in the same way as the storage does when it looks for the items
+func contentItemAudienceMatches(audience *model.ContentItemAudience, viewer *model.ContentItemsViewer) bool {
+	if audience == nil || viewer == nil {
+		return true
+	}
+
+	if audience.Anonymous != nil && *audience.Anonymous != viewer.Anonymous {
+		return false
+	}
+	if !containsAnyString(audience.Permissions, viewer.Permissions) {
+		return false
+	}
+	if audience.MinAppVersion == nil && audience.MaxAppVersion == nil {
+		return true
+	}
+	if viewer.AppVersion == nil {
+		return false
+	}
+	if audience.MinAppVersion != nil && utils.IsVersionLess(*viewer.AppVersion, *audience.MinAppVersion) {
+		return false
+	}
+	if audience.MaxAppVersion != nil && utils.IsVersionLess(*audience.MaxAppVersion, *viewer.AppVersion) {
+		return false
+	}
+	return true
+}
+
+// containsAnyString checks if at least one of the required values is given. Nothing is required when the list is empty.
+func containsAnyString(required []string, given []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	for _, value := range required {
+		if authutils.ContainsString(given, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// hideContentItemsAudienceNumbers removes the app versions as numbers from the items, they are kept only to look for the items
+func hideContentItemsAudienceNumbers(items []model.ContentItemResponse) {
+	for _, item := range items {
+		if item["audience"] == nil {
+			continue
+		}
+		if audience, ok := normalizeJSONValue(item["audience"]).(map[string]interface{}); ok {
+			delete(audience, "min_app_version_number")
+			delete(audience, "max_app_version_number")
+			item["audience"] = audience
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"testing"
+)
+
+func TestContentItemAudienceMatches(t *testing.T) {
+	yes, no := true, false
+	v1, v2, v3 := "1.0", "2.0.0", "3.1"
+	tests := []struct {
+		name     string
+		audience *model.ContentItemAudience
+		viewer   *model.ContentItemsViewer
+		want     bool
+	}{
+		{name: "no audience", viewer: &model.ContentItemsViewer{Anonymous: true}, want: true},
+		{name: "no viewer", audience: &model.ContentItemAudience{Anonymous: &yes}, want: true},
+		{name: "anonymous", audience: &model.ContentItemAudience{Anonymous: &yes}, viewer: &model.ContentItemsViewer{Anonymous: true}, want: true},
+		{name: "anonymous only", audience: &model.ContentItemAudience{Anonymous: &yes}, viewer: &model.ContentItemsViewer{}},
+		{name: "signed in only", audience: &model.ContentItemAudience{Anonymous: &no}, viewer: &model.ContentItemsViewer{Anonymous: true}},
+		{name: "any permission", audience: &model.ContentItemAudience{Permissions: []string{"a", "b"}},
+			viewer: &model.ContentItemsViewer{Permissions: []string{"c", "b"}}, want: true},
+		{name: "missing permission", audience: &model.ContentItemAudience{Permissions: []string{"a"}},
+			viewer: &model.ContentItemsViewer{Permissions: []string{"b"}}},
+		{name: "no app version", audience: &model.ContentItemAudience{MinAppVersion: &v1}, viewer: &model.ContentItemsViewer{}},
+		{name: "min app version", audience: &model.ContentItemAudience{MinAppVersion: &v2},
+			viewer: &model.ContentItemsViewer{AppVersion: &v2}, want: true},
+		{name: "lower app version", audience: &model.ContentItemAudience{MinAppVersion: &v2},
+			viewer: &model.ContentItemsViewer{AppVersion: &v1}},
+		{name: "max app version", audience: &model.ContentItemAudience{MaxAppVersion: &v2},
+			viewer: &model.ContentItemsViewer{AppVersion: &v2}, want: true},
+		{name: "higher app version", audience: &model.ContentItemAudience{MaxAppVersion: &v2},
+			viewer: &model.ContentItemsViewer{AppVersion: &v3}},
+		{name: "app versions range", audience: &model.ContentItemAudience{MinAppVersion: &v1, MaxAppVersion: &v3},
+			viewer: &model.ContentItemsViewer{AppVersion: &v2}, want: true},
+		{name: "all the rules", audience: &model.ContentItemAudience{Anonymous: &no, Permissions: []string{"a"}, MinAppVersion: &v1},
+			viewer: &model.ContentItemsViewer{Permissions: []string{"a"}, AppVersion: &v3}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contentItemAudienceMatches(tt.audience, tt.viewer); got != tt.want {
+				t.Errorf("contentItemAudienceMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/interfaces"
+	"content/core/model"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
+)
+
+// auditIgnoredFields are changed on every write, so they are not part of the audit log changes
+var auditIgnoredFields = []string{"version", "date_updated"}
+
+// recordChange keeps a change made by the user of the claims - it stores an audit log entry, queues the deliveries for the webhooks and invalidates the cached client reads which the change affects.
+// before is nil for the created resources and after is nil for the deleted ones.
+func (s *servicesImpl) recordChange(storage interfaces.Storage, claims *tokenauth.Claims, appID *string, action string,
+	resourceType string, resourceID string, before interface{}, after interface{}) error {
+	s.keepContentChange(storage, contentChange{appID: appID, orgID: claims.OrgID, resourceType: resourceType, before: before, after: after})
+
+	var permissions []string
+	if len(claims.Permissions) > 0 {
+		permissions = strings.Split(claims.Permissions, ",")
+	}
+
+	now := time.Now().UTC()
+	entry := model.AuditLogEntry{ID: uuid.NewString(), Action: action, ResourceType: resourceType, ResourceID: resourceID,
+		Actor: model.AuditActor{Subject: claims.Subject, Name: claims.Name, Permissions: permissions},
+		OrgID: claims.OrgID, AppID: appID,
+		Changes: diffNormalizedData(auditSnapshot(before), auditSnapshot(after), ""), DateCreated: now}
+	err := storage.CreateAuditLogEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	data := after
+	if data == nil {
+		data = before
+	}
+	return s.queueWebhookDeliveries(storage, claims, appID, action, resourceType, resourceID, jsonSnapshot(data), now)
+}
+
+// logChange keeps a change which is not done in a transaction, so a failure is only logged
+func (s *servicesImpl) logChange(claims *tokenauth.Claims, appID *string, action string, resourceType string, resourceID string, before interface{}, after interface{}) {
+	err := s.recordChange(s.app.storage, claims, appID, action, resourceType, resourceID, before, after)
+	if err != nil {
+		log.Printf("error on recording the change for %s %s %s - %s", action, resourceType, resourceID, err)
+	}
+}
+
+// jsonSnapshot gives a copy of the resource as generic JSON, so that it is not affected by later changes of the resource
+func jsonSnapshot(resource interface{}) interface{} {
+	if resource == nil {
+		return nil
+	}
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil
+	}
+	var snapshot interface{}
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return nil
+	}
+	return snapshot
+}
+
+// auditSnapshot gives a copy of the resource without the fields which are not part of the audit log changes
+func auditSnapshot(resource interface{}) interface{} {
+	snapshot := jsonSnapshot(resource)
+	if fields, ok := snapshot.(map[string]interface{}); ok {
+		for _, field := range auditIgnoredFields {
+			delete(fields, field)
+		}
+		return fields
+	}
+	if snapshot == nil {
+		return map[string]interface{}{}
+	}
+	return snapshot
+}
+
+func (s *servicesImpl) GetAuditLogEntries(allApps bool, appID string, orgID string, filter model.AuditLogFilter, offset *int64, limit *int64) ([]model.AuditLogEntry, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+	return s.app.storage.FindAuditLogEntries(appIDParam, orgID, filter, offset, limit)
+}
@@ -0,0 +1,341 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/interfaces"
+	"content/core/model"
+	cacheadapter "content/driven/cache"
+	"encoding/json"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// contentChange is a change of a content item, a data content item or a category which is recorded within a transaction
+type contentChange struct {
+	appID        *string
+	orgID        string
+	resourceType string
+	before       interface{}
+	after        interface{}
+}
+
+// changesStorage is the storage within a transaction which keeps the recorded changes, so that the cached reads which they affect
+// are invalidated once the transaction is committed
+type changesStorage struct {
+	interfaces.Storage
+	changes []contentChange
+}
+
+// performTransaction performs the transaction and invalidates the cached client reads of the content which has been changed in it
+func (s *servicesImpl) performTransaction(transaction func(storage interfaces.Storage) error) error {
+	var storage *changesStorage
+	err := s.app.storage.PerformTransaction(func(transactionStorage interfaces.Storage) error {
+		//the transaction could be retried, so only the changes of the last attempt are kept
+		storage = &changesStorage{Storage: transactionStorage}
+		return transaction(storage)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, change := range storage.changes {
+		s.invalidateContentCache(change)
+	}
+	return nil
+}
+
+// keepContentChange invalidates the cached reads which the change affects. When it is done within performTransaction, it waits until the transaction is committed,
+// otherwise the reads which are done before the commit could cache the content as it was.
+func (s *servicesImpl) keepContentChange(storage interfaces.Storage, change contentChange) {
+	if changes, ok := storage.(*changesStorage); ok {
+		changes.changes = append(changes.changes, change)
+		return
+	}
+	s.invalidateContentCache(change)
+}
+
+// invalidateContentCache invalidates the cached reads of the ids and the categories which the resource had before and after the change
+func (s *servicesImpl) invalidateContentCache(change contentChange) {
+	switch change.resourceType {
+	case model.AuditResourceContentItem:
+		var tags []string
+		for _, resource := range []interface{}{change.before, change.after} {
+			if fields, ok := jsonSnapshot(resource).(map[string]interface{}); ok {
+				tags = append(tags, contentIDCacheTag(fields["id"]), contentCategoryCacheTag(fields["category"]))
+			}
+		}
+		s.app.cacheAdapter.InvalidateContent(cacheadapter.ContentItemsNamespace, change.orgID, change.appID, uniqueStrings(tags))
+	case model.AuditResourceDataContentItem:
+		var tags []string
+		for _, resource := range []interface{}{change.before, change.after} {
+			if fields, ok := jsonSnapshot(resource).(map[string]interface{}); ok {
+				tags = append(tags, dataContentKeyCacheTag(fields["key"]), contentCategoryCacheTag(fields["category"]))
+			}
+		}
+		s.app.cacheAdapter.InvalidateContent(cacheadapter.DataContentItemsNamespace, change.orgID, change.appID, uniqueStrings(tags))
+	case model.AuditResourceCategory:
+		//the read access of the category applies to the reads of the data content items by their keys too
+		s.app.cacheAdapter.InvalidateContentScope(cacheadapter.DataContentItemsNamespace, change.orgID, change.appID)
+	}
+}
+
+// contentCacheListener invalidates the cached client reads of the content which has been changed by any instance of the service.
+// The instance which makes the change invalidates its reads right away, the others once they get the change from the storage.
+type contentCacheListener struct {
+	interfaces.DefaultStorageListener
+
+	cacheAdapter *cacheadapter.CacheAdapter
+}
+
+// OnContentItemChanged invalidates the cached reads of the ids and the categories which the item had before and after the change
+func (c *contentCacheListener) OnContentItemChanged(event model.ContentItemEvent) {
+	item := event.Content
+	if item == nil {
+		//only the id is known, so it could be in any read
+		c.cacheAdapter.InvalidateAllContent(cacheadapter.ContentItemsNamespace)
+		return
+	}
+	if event.Type == model.ContentItemEventUpdated && event.Previous == nil {
+		//the category which the item had is not known
+		c.cacheAdapter.InvalidateContentScope(cacheadapter.ContentItemsNamespace, item.OrgID, item.AppID)
+		return
+	}
+
+	tags := []string{contentIDCacheTag(item.ID), contentCategoryCacheTag(item.Category)}
+	if event.Previous != nil {
+		tags = append(tags, contentCategoryCacheTag(event.Previous.Category))
+	}
+	c.cacheAdapter.InvalidateContent(cacheadapter.ContentItemsNamespace, item.OrgID, item.AppID, uniqueStrings(tags))
+}
+
+// OnDataContentItemChanged invalidates the cached reads of the keys and the categories which the item had before and after the change
+func (c *contentCacheListener) OnDataContentItemChanged(event model.DataContentItemEvent) {
+	var tags []string
+	var item *model.DataContentItem
+	for _, current := range []*model.DataContentItem{event.Previous, event.Current} {
+		if current != nil {
+			tags = append(tags, dataContentKeyCacheTag(current.Key), contentCategoryCacheTag(current.Category))
+			item = current
+		}
+	}
+	if item == nil {
+		//the item is not known, so it could be in any read
+		c.cacheAdapter.InvalidateAllContent(cacheadapter.DataContentItemsNamespace)
+		return
+	}
+	c.cacheAdapter.InvalidateContent(cacheadapter.DataContentItemsNamespace, item.OrgID, item.AppID, uniqueStrings(tags))
+}
+
+// OnContentSettingsChanged invalidates the cached reads which the settings apply to
+func (c *contentCacheListener) OnContentSettingsChanged(change model.ContentSettingsChange) {
+	namespace := cacheadapter.ContentItemsNamespace
+	if change.Settings == model.ContentSettingsCategories {
+		//the read access of the category applies to the reads of the data content items by their keys too
+		namespace = cacheadapter.DataContentItemsNamespace
+	}
+	if change.OrgID == nil {
+		c.cacheAdapter.InvalidateAllContent(namespace)
+		return
+	}
+	c.cacheAdapter.InvalidateContentScope(namespace, *change.OrgID, change.AppID)
+}
+
+// invalidateContentItemsScope invalidates the cached reads of the content items of the app after the schemas or the read access of the categories are changed.
+// The reads of all the apps in the organization are invalidated when appID is nil.
+func (s *servicesImpl) invalidateContentItemsScope(appID *string, orgID string) {
+	s.app.cacheAdapter.InvalidateContentScope(cacheadapter.ContentItemsNamespace, orgID, appID)
+}
+
+// cachedContent gives a copy of the cached read, so that the caller could change it. It gives nil when the read is not cached.
+func (s *servicesImpl) cachedContent(cacheKey string) interface{} {
+	return copyCachedContent(s.app.cacheAdapter.GetContent(cacheKey))
+}
+
+// cacheContentItems caches a client read of the content items until the nearest scheduled change of the items which it could depend on -
+// an item is published, expires, is removed or is unpinned then. The read depends on all the items when it expands the references.
+func (s *servicesImpl) cacheContentItems(cacheKey string, appID *string, orgID string, ids []string, categoryList []string, expand int, content interface{}) {
+	if expand > 0 {
+		ids, categoryList = nil, nil
+	}
+	until, err := s.app.storage.FindContentItemsNextTransition(appID, orgID, ids, categoryList, time.Now().UTC())
+	if err != nil {
+		//it is just not cached
+		log.Printf("error on finding the next change of the content items to cache - %s", err)
+		return
+	}
+	s.app.cacheAdapter.SetContent(cacheKey, copyCachedContent(content), until)
+}
+
+// cacheDataContentItems caches a client read of the data content items until any of them is removed
+func (s *servicesImpl) cacheDataContentItems(cacheKey string, items []*model.DataContentItem, content interface{}) {
+	var until *time.Time
+	for _, item := range items {
+		if item.ExpiresAt != nil && (until == nil || item.ExpiresAt.Before(*until)) {
+			until = item.ExpiresAt
+		}
+	}
+	s.app.cacheAdapter.SetContent(cacheKey, copyCachedContent(content), until)
+}
+
+// copyCachedContent gives a deep copy of a cached read, the cache is not changed when the copy is changed
+func copyCachedContent(content interface{}) interface{} {
+	switch v := content.(type) {
+	case []model.ContentItemResponse:
+		result := make([]model.ContentItemResponse, len(v))
+		for i, item := range v {
+			result[i] = copyContentValue(item).(model.ContentItemResponse)
+		}
+		return result
+	case *model.ContentItemResponse:
+		if v == nil {
+			return v
+		}
+		item := copyContentValue(*v).(model.ContentItemResponse)
+		return &item
+	case *model.ContentItemsPage:
+		if v == nil {
+			return v
+		}
+		page := *v
+		page.Items = copyCachedContent(v.Items).([]model.ContentItemResponse)
+		if v.NextCursor != nil {
+			nextCursor := *v.NextCursor
+			page.NextCursor = &nextCursor
+		}
+		return &page
+	case *model.DataContentItem:
+		if v == nil {
+			return v
+		}
+		item := *v
+		item.Data = copyContentValue(v.Data)
+		if v.Locales != nil {
+			item.Locales = copyContentValue(v.Locales).(map[string]interface{})
+		}
+		return &item
+	case []*model.DataContentItem:
+		result := make([]*model.DataContentItem, len(v))
+		for i, item := range v {
+			result[i] = copyCachedContent(item).(*model.DataContentItem)
+		}
+		return result
+	}
+	return content
+}
+
+// copyContentValue gives a deep copy of a value decoded from json or bson
+func copyContentValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		result := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			result[key] = copyContentValue(element)
+		}
+		return result
+	case primitive.M:
+		if v == nil {
+			return v
+		}
+		result := make(primitive.M, len(v))
+		for key, element := range v {
+			result[key] = copyContentValue(element)
+		}
+		return result
+	case primitive.D:
+		if v == nil {
+			return v
+		}
+		result := make(primitive.D, len(v))
+		for i, element := range v {
+			result[i] = primitive.E{Key: element.Key, Value: copyContentValue(element.Value)}
+		}
+		return result
+	case primitive.A:
+		if v == nil {
+			return v
+		}
+		result := make(primitive.A, len(v))
+		for i, element := range v {
+			result[i] = copyContentValue(element)
+		}
+		return result
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		result := make([]interface{}, len(v))
+		for i, element := range v {
+			result[i] = copyContentValue(element)
+		}
+		return result
+	case []model.ContentItemResponse:
+		return copyCachedContent(v)
+	}
+	return value
+}
+
+// contentItemsCacheKey gives the cache key of a client read of the content items. The query has all the params which the result depends on.
+// The read depends on the requested items, otherwise on the requested categories. It depends on all the items when it expands the references.
+func (s *servicesImpl) contentItemsCacheKey(appID *string, orgID string, ids []string, categoryList []string, expand int, query ...interface{}) string {
+	var tags []string
+	switch {
+	case expand > 0:
+		tags = []string{cacheadapter.ContentAllTag}
+	case len(ids) > 0:
+		for _, id := range ids {
+			tags = append(tags, contentIDCacheTag(id))
+		}
+	case len(categoryList) > 0:
+		for _, category := range categoryList {
+			tags = append(tags, contentCategoryCacheTag(category))
+		}
+	default:
+		tags = []string{cacheadapter.ContentAllTag}
+	}
+	return s.contentCacheKey(cacheadapter.ContentItemsNamespace, appID, orgID, tags, query)
+}
+
+// dataContentItemsCacheKey gives the cache key of a client read of the data content items, the read depends on the item with the key or on the items in the category
+func (s *servicesImpl) dataContentItemsCacheKey(appID *string, orgID string, tag string, query ...interface{}) string {
+	return s.contentCacheKey(cacheadapter.DataContentItemsNamespace, appID, orgID, []string{tag}, query)
+}
+
+func (s *servicesImpl) contentCacheKey(namespace string, appID *string, orgID string, tags []string, query []interface{}) string {
+	params, _ := json.Marshal(query)
+	return s.app.cacheAdapter.ContentKey(namespace, orgID, appID, tags, string(params))
+}
+
+func contentIDCacheTag(id interface{}) string {
+	return "id:" + stringValue(id)
+}
+
+func contentCategoryCacheTag(category interface{}) string {
+	return "category:" + stringValue(category)
+}
+
+func dataContentKeyCacheTag(key interface{}) string {
+	return "key:" + stringValue(key)
+}
+
+func stringValue(value interface{}) string {
+	text, _ := value.(string)
+	return text
+}
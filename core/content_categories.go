@@ -0,0 +1,291 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"content/utils"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
+)
+
+// checkContentItemCategory makes sure that the category is registered when the organization requires it.
+// The categories registered for all the apps in the organization are valid for every app.
+func (s *servicesImpl) checkContentItemCategory(appID *string, orgID string, category string) error {
+	settings, err := s.app.storage.FindOrgSettings(orgID)
+	if err != nil {
+		return err
+	}
+	if settings == nil || !settings.RequireRegisteredCategories {
+		return nil
+	}
+
+	registered, err := s.app.storage.FindContentItemCategory(appID, orgID, category)
+	if err != nil {
+		return err
+	}
+	if registered == nil && appID != nil {
+		registered, err = s.app.storage.FindContentItemCategory(nil, orgID, category)
+		if err != nil {
+			return err
+		}
+	}
+	if registered == nil {
+		return fmt.Errorf("category %s is not registered", category)
+	}
+	return nil
+}
+
+// hiddenContentItemsCategories gives the categories which the viewer cannot read. Nothing is hidden from the admins - when the viewer is nil.
+// The categories registered for the app override the ones registered for all the apps in the organization.
+func (s *servicesImpl) hiddenContentItemsCategories(viewer *model.ContentItemsViewer, appID *string, orgID string) (map[string]bool, error) {
+	if viewer == nil {
+		return nil, nil
+	}
+
+	categories, err := s.app.storage.FindContentItemCategories(nil, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if appID != nil {
+		appCategories, err := s.app.storage.FindContentItemCategories(appID, orgID)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, appCategories...)
+	}
+	readAccess := map[string]*model.ReadAccess{}
+	for _, category := range categories {
+		readAccess[category.Name] = category.ReadAccess
+	}
+
+	hidden := map[string]bool{}
+	for name, access := range readAccess {
+		if !access.Allows(viewer.Anonymous, viewer.Permissions) {
+			hidden[name] = true
+		}
+	}
+	return hidden, nil
+}
+
+// readableContentItemsCategories removes the hidden categories from the requested ones. All the readable categories are given when nothing is requested but something is hidden.
+// It gives false when none of the categories could be read.
+func (s *servicesImpl) readableContentItemsCategories(appID *string, orgID string, categoryList []string, hidden map[string]bool) ([]string, bool, error) {
+	if len(hidden) == 0 {
+		return categoryList, true, nil
+	}
+	if len(categoryList) == 0 {
+		var err error
+		categoryList, err = s.app.storage.GetContentItemsCategories(appID, orgID)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	var readable []string
+	for _, category := range categoryList {
+		if !hidden[category] {
+			readable = append(readable, category)
+		}
+	}
+	return readable, len(readable) > 0, nil
+}
+
+// canReadDataContentItems checks if the user could read the data content items in the category
+func (s *servicesImpl) canReadDataContentItems(claims *tokenauth.Claims, category string) (bool, error) {
+	item, err := s.app.storage.FindCategory(&claims.AppID, claims.OrgID, category)
+	if err != nil {
+		return false, err
+	}
+	if item == nil {
+		return true, nil
+	}
+	return item.ReadAccess.Allows(claims.Anonymous, utils.GetListValue(claims.Permissions)), nil
+}
+
+// Content Item Categories
+
+func (s *servicesImpl) GetContentItemCategories(allApps bool, appID string, orgID string) ([]model.ContentItemCategory, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	categories, err := s.app.storage.FindContentItemCategories(appIDParam, orgID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.app.storage.GetContentItemsCategoriesStats(appIDParam, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]int, len(categories))
+	for i := range categories {
+		categories[i].Registered = true
+		indexes[categories[i].Name] = i
+	}
+	for _, categoryStats := range stats {
+		index, ok := indexes[categoryStats.Category]
+		if !ok {
+			//the items are in a category which is not registered
+			categories = append(categories, model.ContentItemCategory{Name: categoryStats.Category, OrgID: orgID, AppID: appIDParam})
+			index = len(categories) - 1
+		}
+		categories[index].ItemsCount = categoryStats.ItemsCount
+		categories[index].LastModified = categoryStats.LastModified
+	}
+
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+	return categories, nil
+}
+
+func (s *servicesImpl) GetContentItemCategory(allApps bool, appID string, orgID string, name string) (*model.ContentItemCategory, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	category, err := s.app.storage.FindContentItemCategory(appIDParam, orgID, name)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, fmt.Errorf("category %s is not registered", name)
+	}
+	category.Registered = true
+
+	stats, err := s.app.storage.GetContentItemsCategoriesStats(appIDParam, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, categoryStats := range stats {
+		if categoryStats.Category == name {
+			category.ItemsCount = categoryStats.ItemsCount
+			category.LastModified = categoryStats.LastModified
+			break
+		}
+	}
+	return category, nil
+}
+
+func (s *servicesImpl) CreateContentItemCategory(allApps bool, appID string, orgID string, item model.ContentItemCategory) (*model.ContentItemCategory, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	item.Name = strings.TrimSpace(item.Name)
+	if len(item.Name) == 0 {
+		return nil, errors.New("missing name")
+	}
+
+	item.ID = uuid.NewString()
+	item.OrgID = orgID
+	item.AppID = appIDParam
+	item.DateCreated = time.Now().UTC()
+	item.DateUpdated = nil
+	err := s.app.storage.CreateContentItemCategory(item)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateContentItemsScope(appIDParam, orgID)
+	item.Registered = true
+	return &item, nil
+}
+
+func (s *servicesImpl) UpdateContentItemCategory(allApps bool, appID string, orgID string, item model.ContentItemCategory) (*model.ContentItemCategory, error) {
+	current, err := s.GetContentItemCategory(allApps, appID, orgID, item.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	current.DisplayName = item.DisplayName
+	current.Description = item.Description
+	current.Icon = item.Icon
+	current.ReadAccess = item.ReadAccess
+	current.DateUpdated = &now
+	err = s.app.storage.UpdateContentItemCategory(*current)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateContentItemsScope(current.AppID, orgID)
+	return current, nil
+}
+
+func (s *servicesImpl) DeleteContentItemCategory(allApps bool, appID string, orgID string, name string) error {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+	err := s.app.storage.DeleteContentItemCategory(appIDParam, orgID, name)
+	if err != nil {
+		return err
+	}
+	s.invalidateContentItemsScope(appIDParam, orgID)
+	return nil
+}
+
+// Org Settings
+
+func (s *servicesImpl) GetOrgSettings(orgID string) (*model.OrgSettings, error) {
+	settings, err := s.app.storage.FindOrgSettings(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		//the defaults until the organization sets anything
+		settings = &model.OrgSettings{OrgID: orgID}
+	}
+	return settings, nil
+}
+
+func (s *servicesImpl) UpdateOrgSettings(orgID string, item model.OrgSettings) (*model.OrgSettings, error) {
+	current, err := s.app.storage.FindOrgSettings(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if current == nil {
+		item.ID = uuid.NewString()
+		item.DateCreated = now
+		item.DateUpdated = nil
+	} else {
+		item.ID = current.ID
+		item.DateCreated = current.DateCreated
+		item.DateUpdated = &now
+	}
+	item.OrgID = orgID
+
+	err = s.app.storage.SaveOrgSettings(item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
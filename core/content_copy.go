@@ -0,0 +1,260 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/interfaces"
+	"content/core/model"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
+)
+
+func (s *servicesImpl) CopyContentItems(claims *tokenauth.Claims, allApps bool, targetAppID *string, ids []string, categoryList []string, onConflict string, dryRun bool) (*model.ContentItemsCopyReport, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	if targetAppID != nil && len(*targetAppID) == 0 {
+		return nil, errors.New("missing target app id")
+	}
+	if sameAppScope(appIDParam, targetAppID) {
+		return nil, errors.New("the source and the target are the same")
+	}
+	if len(ids) == 0 && len(categoryList) == 0 {
+		return nil, errors.New("no items or categories to copy")
+	}
+	if len(onConflict) == 0 {
+		onConflict = model.ContentItemsCopyOnConflictSkip
+	}
+	if onConflict != model.ContentItemsCopyOnConflictSkip && onConflict != model.ContentItemsCopyOnConflictReplace && onConflict != model.ContentItemsCopyOnConflictFail {
+		return nil, fmt.Errorf("invalid on conflict value %s - possible values: %s, %s, %s", onConflict,
+			model.ContentItemsCopyOnConflictSkip, model.ContentItemsCopyOnConflictReplace, model.ContentItemsCopyOnConflictFail)
+	}
+
+	report := model.ContentItemsCopyReport{SourceAppID: appIDParam, TargetAppID: targetAppID, OnConflict: onConflict, DryRun: dryRun,
+		Items: []model.ContentItemsCopyItem{}, Conflicts: []model.ContentItemsCopyConflict{}, Errors: []model.ContentItemsCopyError{}}
+	transaction := func(storage interfaces.Storage) error {
+		items, err := findContentItemsToCopy(storage, appIDParam, claims.OrgID, ids, categoryList, &report)
+		if err != nil {
+			return err
+		}
+		report.Total = len(items)
+
+		//the copies reference the copies of the items, so the copies which the target already has are needed for the referenced items too
+		paths, err := contentItemsReferencePaths(storage, appIDParam, claims.OrgID)
+		if err != nil {
+			return err
+		}
+		sourceIDs := []string{}
+		for i := range items {
+			item := &items[i]
+			useRawContentItemData(item)
+			item.Data = normalizeJSONValue(item.Data)
+			locales, _ := normalizeJSONValue(item.Locales).(map[string]interface{})
+			item.Locales = locales
+
+			sourceIDs = append(sourceIDs, item.ID)
+			for _, path := range paths[item.Category] {
+				sourceIDs = append(sourceIDs, referenceIDs(item.Data, path)...)
+				for _, data := range item.Locales {
+					sourceIDs = append(sourceIDs, referenceIDs(data, path)...)
+				}
+			}
+		}
+		copies, err := storage.FindContentItemsCopies(targetAppID, claims.OrgID, uniqueStrings(sourceIDs))
+		if err != nil {
+			return err
+		}
+		existing := map[string]model.ContentItem{}
+		targetIDs := map[string]string{}
+		for _, current := range copies {
+			if _, ok := existing[*current.CopiedFrom]; !ok {
+				existing[*current.CopiedFrom] = current
+				targetIDs[*current.CopiedFrom] = current.ID
+			}
+		}
+
+		//decide what happens with every item first, so that all the references could be resolved
+		failed := false
+		copied := []model.ContentItem{}
+		for _, item := range items {
+			current, ok := existing[item.ID]
+			if !ok {
+				targetIDs[item.ID] = uuid.NewString()
+				copied = append(copied, item)
+				continue
+			}
+
+			report.Conflicts = append(report.Conflicts, model.ContentItemsCopyConflict{SourceID: item.ID, TargetID: current.ID,
+				Type: model.ContentItemsCopyConflictExists, Message: "the target already has a copy of the item"})
+			switch onConflict {
+			case model.ContentItemsCopyOnConflictSkip:
+				report.Items = append(report.Items, model.ContentItemsCopyItem{SourceID: item.ID, TargetID: current.ID, Action: model.ContentItemsCopyOnConflictSkip})
+				report.Skipped++
+			case model.ContentItemsCopyOnConflictFail:
+				failed = true
+			default:
+				copied = append(copied, item)
+			}
+		}
+
+		now := time.Now().UTC()
+		targets := make([]model.ContentItem, 0, len(copied))
+		for _, item := range copied {
+			target := copyContentItem(item, targetIDs, paths[item.Category], &report)
+			target.OrgID = claims.OrgID
+			target.AppID = targetAppID
+			err = s.prepareContentItem(targetAppID, claims.OrgID, &target)
+			if err != nil {
+				report.Errors = append(report.Errors, model.ContentItemsCopyError{SourceID: item.ID, Message: err.Error()})
+				continue
+			}
+
+			action := model.ContentItemsBatchActionCreate
+			if current, ok := existing[item.ID]; ok {
+				action = model.ContentItemsCopyOnConflictReplace
+				target.DateCreated = current.DateCreated
+				target.DateUpdated = &now
+				target.Version = current.Version + 1
+				report.Replaced++
+			} else {
+				target.DateCreated = now
+				target.Version = 1
+				report.Created++
+			}
+			report.Items = append(report.Items, model.ContentItemsCopyItem{SourceID: item.ID, TargetID: target.ID, Action: action})
+			targets = append(targets, target)
+		}
+		if dryRun || failed || len(report.Errors) > 0 {
+			return nil
+		}
+
+		//store them
+		for _, target := range targets {
+			var before interface{}
+			if current, ok := existing[*target.CopiedFrom]; ok {
+				err = s.createContentItemRevision(storage, current, claims.Subject, model.RevisionActionCopy)
+				if err != nil {
+					return err
+				}
+				before = current
+			}
+			err = storage.SaveContentItem(target)
+			if err != nil {
+				return fmt.Errorf("error on storing the copy of content item with id: %s - %s", *target.CopiedFrom, err)
+			}
+			err = s.recordChange(storage, claims, targetAppID, model.AuditActionCopy, model.AuditResourceContentItem, target.ID, before, target)
+			if err != nil {
+				return err
+			}
+		}
+		report.Copied = true
+		return nil
+	}
+
+	err := s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// findContentItemsToCopy gives the items with the ids and the items in the categories. The ids which are not found are reported as errors.
+func findContentItemsToCopy(storage interfaces.Storage, appID *string, orgID string, ids []string, categoryList []string, report *model.ContentItemsCopyReport) ([]model.ContentItem, error) {
+	var items []model.ContentItem
+	found := map[string]bool{}
+	if len(ids) > 0 {
+		selected, err := storage.FindContentItems(appID, orgID, ids, nil, nil, nil, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range selected {
+			found[item.ID] = true
+			items = append(items, item)
+		}
+		for _, id := range uniqueStrings(ids) {
+			if !found[id] {
+				report.Errors = append(report.Errors, model.ContentItemsCopyError{SourceID: id, Message: "content item not found"})
+			}
+		}
+	}
+	if len(categoryList) > 0 {
+		selected, err := storage.FindContentItems(appID, orgID, nil, categoryList, nil, nil, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range selected {
+			if !found[item.ID] {
+				found[item.ID] = true
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
+
+// copyContentItem gives a copy of the item with the target id. The references on the paths are replaced by the ids of the copies of the referenced items.
+// The references to the items which are not copied are kept as they are and reported as conflicts.
+func copyContentItem(item model.ContentItem, targetIDs map[string]string, paths []string, report *model.ContentItemsCopyReport) model.ContentItem {
+	unresolved := map[string]bool{}
+	rewrite := func(data interface{}) interface{} {
+		for _, path := range paths {
+			data = referenceValues(data, strings.Split(path, "."), func(value interface{}) interface{} {
+				id, ok := value.(string)
+				if !ok || len(id) == 0 {
+					return value
+				}
+				if targetID, ok := targetIDs[id]; ok {
+					return targetID
+				}
+				if !unresolved[id] {
+					unresolved[id] = true
+					report.Conflicts = append(report.Conflicts, model.ContentItemsCopyConflict{SourceID: item.ID, Type: model.ContentItemsCopyConflictUnresolvedReference,
+						Message: fmt.Sprintf("the referenced item %s is not copied, so the reference is kept as it is", id)})
+				}
+				return value
+			})
+		}
+		return data
+	}
+
+	sourceID := item.ID
+	target := model.ContentItem{ID: targetIDs[item.ID], Category: item.Category, Data: rewrite(item.Data),
+		Status: item.Status, PublishAt: item.PublishAt, ExpireAt: item.ExpireAt, ExpiresAt: item.ExpiresAt, Audience: item.Audience,
+		Position: item.Position, Pinned: item.Pinned, PinnedUntil: item.PinnedUntil, CopiedFrom: &sourceID}
+	if item.Locales != nil {
+		target.Locales = make(map[string]interface{}, len(item.Locales))
+		for locale, data := range item.Locales {
+			target.Locales[locale] = rewrite(data)
+		}
+	}
+	return target
+}
+
+// sameAppScope checks if the app ids are for the same app or both are for all the apps in the organization
+func sameAppScope(appID *string, otherAppID *string) bool {
+	if appID == nil || otherAppID == nil {
+		return appID == nil && otherAppID == nil
+	}
+	return *appID == *otherAppID
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+const (
+	dataChangeAdded   string = "added"
+	dataChangeRemoved string = "removed"
+	dataChangeChanged string = "changed"
+)
+
+// diffData gives the changes needed to go from one version of the data to another.
+// Objects are compared key by key and arrays index by index, everything else as a whole.
+func diffData(from interface{}, to interface{}, path string) []model.DataChange {
+	return diffNormalizedData(normalizeJSONValue(from), normalizeJSONValue(to), path)
+}
+
+func diffNormalizedData(from interface{}, to interface{}, path string) []model.DataChange {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if fromIsMap && toIsMap {
+		keys := make([]string, 0, len(fromMap)+len(toMap))
+		for key := range fromMap {
+			keys = append(keys, key)
+		}
+		for key := range toMap {
+			if _, ok := fromMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		changes := []model.DataChange{}
+		for _, key := range keys {
+			keyPath := key
+			if len(path) > 0 {
+				keyPath = path + "." + key
+			}
+			fromValue, inFrom := fromMap[key]
+			toValue, inTo := toMap[key]
+			switch {
+			case !inFrom:
+				changes = append(changes, model.DataChange{Path: keyPath, Op: dataChangeAdded, To: toValue})
+			case !inTo:
+				changes = append(changes, model.DataChange{Path: keyPath, Op: dataChangeRemoved, From: fromValue})
+			default:
+				changes = append(changes, diffNormalizedData(fromValue, toValue, keyPath)...)
+			}
+		}
+		return changes
+	}
+
+	fromList, fromIsList := from.([]interface{})
+	toList, toIsList := to.([]interface{})
+	if fromIsList && toIsList {
+		changes := []model.DataChange{}
+		for i := 0; i < len(fromList) || i < len(toList); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(fromList):
+				changes = append(changes, model.DataChange{Path: itemPath, Op: dataChangeAdded, To: toList[i]})
+			case i >= len(toList):
+				changes = append(changes, model.DataChange{Path: itemPath, Op: dataChangeRemoved, From: fromList[i]})
+			default:
+				changes = append(changes, diffNormalizedData(fromList[i], toList[i], itemPath)...)
+			}
+		}
+		return changes
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return []model.DataChange{}
+	}
+	return []model.DataChange{{Path: path, Op: dataChangeChanged, From: from, To: to}}
+}
@@ -0,0 +1,387 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	jsonPatchOpAdd     string = "add"
+	jsonPatchOpRemove  string = "remove"
+	jsonPatchOpReplace string = "replace"
+	jsonPatchOpMove    string = "move"
+	jsonPatchOpCopy    string = "copy"
+	jsonPatchOpTest    string = "test"
+)
+
+// applyDataPatch applies a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) on normalized data
+func applyDataPatch(data interface{}, patchType string, patch json.RawMessage) (interface{}, error) {
+	switch patchType {
+	case model.DataPatchTypeMerge:
+		var mergePatch interface{}
+		err := json.Unmarshal(patch, &mergePatch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid merge patch - %s", err)
+		}
+		return applyMergePatch(data, mergePatch), nil
+	case model.DataPatchTypeJSON:
+		var operations []model.JSONPatchOperation
+		err := json.Unmarshal(patch, &operations)
+		if err != nil {
+			return nil, fmt.Errorf("invalid json patch - %s", err)
+		}
+		return applyJSONPatch(data, operations)
+	default:
+		return nil, fmt.Errorf("invalid patch type %s - possible values: %s, %s", patchType, model.DataPatchTypeMerge, model.DataPatchTypeJSON)
+	}
+}
+
+// applyMergePatch applies a JSON merge patch as described in RFC 7396
+func applyMergePatch(target interface{}, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = map[string]interface{}{}
+	}
+	result := make(map[string]interface{}, len(targetMap))
+	for key, value := range targetMap {
+		result[key] = value
+	}
+	for key, value := range patchMap {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = applyMergePatch(result[key], value)
+	}
+	return result
+}
+
+// applyJSONPatch applies the operations of a JSON patch as described in RFC 6902. Either all of them are applied or none.
+func applyJSONPatch(document interface{}, operations []model.JSONPatchOperation) (interface{}, error) {
+	var err error
+	for i, operation := range operations {
+		document, err = applyJSONPatchOperation(document, operation)
+		if err != nil {
+			return nil, fmt.Errorf("json patch operation %d (%s %s) - %s", i, operation.Op, operation.Path, err)
+		}
+	}
+	return document, nil
+}
+
+func applyJSONPatchOperation(document interface{}, operation model.JSONPatchOperation) (interface{}, error) {
+	path, err := parseJSONPointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case jsonPatchOpAdd, jsonPatchOpReplace, jsonPatchOpTest:
+		if operation.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		var value interface{}
+		err = json.Unmarshal(operation.Value, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		switch operation.Op {
+		case jsonPatchOpAdd:
+			return addJSONValue(document, path, value)
+		case jsonPatchOpReplace:
+			if len(path) == 0 {
+				return value, nil
+			}
+			document, _, err = removeJSONValue(document, path)
+			if err != nil {
+				return nil, err
+			}
+			return addJSONValue(document, path, value)
+		default:
+			current, err := getJSONValue(document, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, errors.New("test failed")
+			}
+			return document, nil
+		}
+	case jsonPatchOpRemove:
+		document, _, err = removeJSONValue(document, path)
+		return document, err
+	case jsonPatchOpMove, jsonPatchOpCopy:
+		from, err := parseJSONPointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if operation.Op == jsonPatchOpMove {
+			if len(from) < len(path) && reflect.DeepEqual(from, path[:len(from)]) {
+				return nil, errors.New("cannot move a value into itself")
+			}
+			document, value, err = removeJSONValue(document, from)
+		} else {
+			value, err = getJSONValue(document, from)
+			value = copyJSONValue(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return addJSONValue(document, path, value)
+	default:
+		return nil, fmt.Errorf("invalid op %s", operation.Op)
+	}
+}
+
+// parseJSONPointer gives the reference tokens of a JSON pointer (RFC 6901)
+func parseJSONPointer(pointer string) ([]string, error) {
+	if len(pointer) == 0 {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid path %s", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// jsonArrayIndex gives the index which a token refers to within an array. The "-" token refers to the end of the array when it is allowed.
+func jsonArrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index %s", token)
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if index > max {
+		return 0, fmt.Errorf("array index %d out of bounds", index)
+	}
+	return index, nil
+}
+
+func getJSONValue(document interface{}, path []string) (interface{}, error) {
+	current := document
+	for _, token := range path {
+		switch container := current.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("%s is not found", token)
+			}
+			current = value
+		case []interface{}:
+			index, err := jsonArrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			current = container[index]
+		default:
+			return nil, fmt.Errorf("%s is not found", token)
+		}
+	}
+	return current, nil
+}
+
+// addJSONValue gives the document with the value added at the path. The containers on the path are copied, the document is not changed.
+func addJSONValue(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token := path[0]
+	switch container := document.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(container)+1)
+		for key, item := range container {
+			result[key] = item
+		}
+		if len(path) == 1 {
+			result[token] = value
+			return result, nil
+		}
+		child, ok := container[token]
+		if !ok {
+			return nil, fmt.Errorf("%s is not found", token)
+		}
+		updated, err := addJSONValue(child, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		result[token] = updated
+		return result, nil
+	case []interface{}:
+		if len(path) == 1 {
+			index, err := jsonArrayIndex(token, len(container), true)
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0, len(container)+1)
+			result = append(result, container[:index]...)
+			result = append(result, value)
+			return append(result, container[index:]...), nil
+		}
+		index, err := jsonArrayIndex(token, len(container), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := addJSONValue(container[index], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		result := append([]interface{}{}, container...)
+		result[index] = updated
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%s is not found", token)
+	}
+}
+
+// removeJSONValue gives the document without the value at the path and the removed value. The document is not changed.
+func removeJSONValue(document interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+
+	token := path[0]
+	switch container := document.(type) {
+	case map[string]interface{}:
+		child, ok := container[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s is not found", token)
+		}
+		result := make(map[string]interface{}, len(container))
+		for key, item := range container {
+			result[key] = item
+		}
+		if len(path) == 1 {
+			delete(result, token)
+			return result, child, nil
+		}
+		updated, removed, err := removeJSONValue(child, path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		result[token] = updated
+		return result, removed, nil
+	case []interface{}:
+		index, err := jsonArrayIndex(token, len(container), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(path) == 1 {
+			result := make([]interface{}, 0, len(container)-1)
+			result = append(result, container[:index]...)
+			return append(result, container[index+1:]...), container[index], nil
+		}
+		updated, removed, err := removeJSONValue(container[index], path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		result := append([]interface{}{}, container...)
+		result[index] = updated
+		return result, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("%s is not found", token)
+	}
+}
+
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = copyJSONValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = copyJSONValue(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// collectDataUpdate gives the field updates which change the stored data from one version to another. Objects are compared key by key
+// so that only the changed fields are written, everything else is set as a whole.
+func collectDataUpdate(from interface{}, to interface{}, path string, update *model.DataUpdate) {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if !fromIsMap || !toIsMap || !storablePathKeys(fromMap) || !storablePathKeys(toMap) {
+		if !reflect.DeepEqual(from, to) {
+			update.Set = append(update.Set, model.DataPathValue{Path: path, Value: to})
+		}
+		return
+	}
+
+	keys := make([]string, 0, len(fromMap)+len(toMap))
+	for key := range fromMap {
+		keys = append(keys, key)
+	}
+	for key := range toMap {
+		if _, ok := fromMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := path + "." + key
+		fromValue, inFrom := fromMap[key]
+		toValue, inTo := toMap[key]
+		switch {
+		case !inTo:
+			update.Unset = append(update.Unset, keyPath)
+		case !inFrom:
+			update.Set = append(update.Set, model.DataPathValue{Path: keyPath, Value: toValue})
+		default:
+			collectDataUpdate(fromValue, toValue, keyPath, update)
+		}
+	}
+}
+
+// storablePathKeys says if all the keys could be used within a field path
+func storablePathKeys(data map[string]interface{}) bool {
+	for key := range data {
+		if len(key) == 0 || strings.Contains(key, ".") || strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestApplyDataPatch(t *testing.T) {
+	data := `{"title": "news", "tags": ["a", "b"], "meta": {"author": "x", "a/b": 1, "c~d": 2}}`
+	tests := []struct {
+		name      string
+		patchType string
+		patch     string
+		want      string
+		wantErr   bool
+	}{
+		{name: "merge", patchType: model.DataPatchTypeMerge, patch: `{"title": "event", "meta": {"author": null, "place": "here"}}`,
+			want: `{"title": "event", "tags": ["a", "b"], "meta": {"a/b": 1, "c~d": 2, "place": "here"}}`},
+		{name: "merge replaces the arrays", patchType: model.DataPatchTypeMerge, patch: `{"tags": ["c"]}`,
+			want: `{"title": "news", "tags": ["c"], "meta": {"author": "x", "a/b": 1, "c~d": 2}}`},
+		{name: "merge with a non object", patchType: model.DataPatchTypeMerge, patch: `"text"`, want: `"text"`},
+		{name: "invalid merge", patchType: model.DataPatchTypeMerge, patch: `{`, wantErr: true},
+		{name: "add", patchType: model.DataPatchTypeJSON, patch: `[{"op": "add", "path": "/tags/1", "value": "c"}, {"op": "add", "path": "/tags/-", "value": "d"}]`,
+			want: `{"title": "news", "tags": ["a", "c", "b", "d"], "meta": {"author": "x", "a/b": 1, "c~d": 2}}`},
+		{name: "remove with escaped pointers", patchType: model.DataPatchTypeJSON, patch: `[{"op": "remove", "path": "/meta/a~1b"}, {"op": "remove", "path": "/meta/c~0d"}]`,
+			want: `{"title": "news", "tags": ["a", "b"], "meta": {"author": "x"}}`},
+		{name: "replace", patchType: model.DataPatchTypeJSON, patch: `[{"op": "replace", "path": "/title", "value": "event"}]`,
+			want: `{"title": "event", "tags": ["a", "b"], "meta": {"author": "x", "a/b": 1, "c~d": 2}}`},
+		{name: "replace the whole document", patchType: model.DataPatchTypeJSON, patch: `[{"op": "replace", "path": "", "value": {"title": "event"}}]`,
+			want: `{"title": "event"}`},
+		{name: "move", patchType: model.DataPatchTypeJSON, patch: `[{"op": "move", "from": "/meta/author", "path": "/author"}]`,
+			want: `{"title": "news", "author": "x", "tags": ["a", "b"], "meta": {"a/b": 1, "c~d": 2}}`},
+		{name: "copy", patchType: model.DataPatchTypeJSON, patch: `[{"op": "copy", "from": "/tags/0", "path": "/first"}]`,
+			want: `{"title": "news", "first": "a", "tags": ["a", "b"], "meta": {"author": "x", "a/b": 1, "c~d": 2}}`},
+		{name: "test", patchType: model.DataPatchTypeJSON, patch: `[{"op": "test", "path": "/tags", "value": ["a", "b"]}]`,
+			want: data},
+		{name: "failed test", patchType: model.DataPatchTypeJSON, patch: `[{"op": "test", "path": "/title", "value": "event"}]`, wantErr: true},
+		{name: "replace a missing value", patchType: model.DataPatchTypeJSON, patch: `[{"op": "replace", "path": "/missing", "value": 1}]`, wantErr: true},
+		{name: "index out of bounds", patchType: model.DataPatchTypeJSON, patch: `[{"op": "add", "path": "/tags/3", "value": "c"}]`, wantErr: true},
+		{name: "leading zero index", patchType: model.DataPatchTypeJSON, patch: `[{"op": "remove", "path": "/tags/01"}]`, wantErr: true},
+		{name: "move into itself", patchType: model.DataPatchTypeJSON, patch: `[{"op": "move", "from": "/meta", "path": "/meta/inner"}]`, wantErr: true},
+		{name: "missing value", patchType: model.DataPatchTypeJSON, patch: `[{"op": "add", "path": "/title"}]`, wantErr: true},
+		{name: "invalid path", patchType: model.DataPatchTypeJSON, patch: `[{"op": "remove", "path": "title"}]`, wantErr: true},
+		{name: "invalid op", patchType: model.DataPatchTypeJSON, patch: `[{"op": "delete", "path": "/title"}]`, wantErr: true},
+		{name: "invalid patch type", patchType: "xml", patch: `{}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document interface{}
+			if err := json.Unmarshal([]byte(data), &document); err != nil {
+				t.Fatal(err)
+			}
+			got, err := applyDataPatch(document, tt.patchType, json.RawMessage(tt.patch))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyDataPatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var want interface{}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("applyDataPatch() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"content/utils"
+	"strings"
+)
+
+// richTextFormat is the JSON Schema format of the data fields which have HTML, they are sanitized when they are written
+const richTextFormat = "rich-text"
+
+// defaultHTMLSanitizer is used for what the organization does not set
+var defaultHTMLSanitizer = model.HTMLSanitizer{
+	AllowedTags: []string{"a", "b", "blockquote", "br", "code", "div", "em", "h1", "h2", "h3", "h4", "h5", "h6", "hr", "i", "img", "li", "ol", "p", "pre",
+		"s", "span", "strong", "sub", "sup", "table", "tbody", "td", "th", "thead", "tr", "u", "ul"},
+	AllowedAttributes: map[string][]string{"a": {"href", "title"}, "img": {"src", "alt", "title", "width", "height"},
+		"td": {"colspan", "rowspan"}, "th": {"colspan", "rowspan"}},
+	AllowedURLSchemes: []string{"http", "https", "mailto", "tel"},
+}
+
+// htmlSanitizer gives the sanitizer settings of the organization, the defaults are used for what it does not set
+func (s *servicesImpl) htmlSanitizer(orgID string) (model.HTMLSanitizer, error) {
+	settings, err := s.app.storage.FindOrgSettings(orgID)
+	if err != nil {
+		return model.HTMLSanitizer{}, err
+	}
+	sanitizer := defaultHTMLSanitizer
+	if settings == nil || settings.HTMLSanitizer == nil {
+		return sanitizer, nil
+	}
+
+	if settings.HTMLSanitizer.AllowedTags != nil {
+		sanitizer.AllowedTags = settings.HTMLSanitizer.AllowedTags
+	}
+	if settings.HTMLSanitizer.AllowedAttributes != nil {
+		sanitizer.AllowedAttributes = settings.HTMLSanitizer.AllowedAttributes
+	}
+	if settings.HTMLSanitizer.AllowedURLSchemes != nil {
+		sanitizer.AllowedURLSchemes = settings.HTMLSanitizer.AllowedURLSchemes
+	}
+	sanitizer.RewriteLinks = settings.HTMLSanitizer.RewriteLinks
+	return sanitizer, nil
+}
+
+// sanitizeContentItemData sanitizes the rich text fields of the data in the category. It gives the sanitized data and the raw one,
+// the raw one is nil when the category does not have rich text fields.
+func (s *servicesImpl) sanitizeContentItemData(appID *string, orgID string, category string, data interface{}) (interface{}, interface{}, error) {
+	paths, err := s.contentSchemaFormatPaths(appID, orgID, category, richTextFormat)
+	if err != nil || len(paths) == 0 {
+		return data, nil, err
+	}
+	sanitizer, err := s.htmlSanitizer(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sanitized, raw := sanitizeRichTextFields(data, paths, sanitizer)
+	return sanitized, raw, nil
+}
+
+// sanitizeContentItem sanitizes the rich text fields of the data and the locale variants of the item, the raw ones are kept in the item too
+func (s *servicesImpl) sanitizeContentItem(appID *string, orgID string, item *model.ContentItem) error {
+	item.RawData = nil
+	item.RawLocales = nil
+	paths, err := s.contentSchemaFormatPaths(appID, orgID, item.Category, richTextFormat)
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+	sanitizer, err := s.htmlSanitizer(orgID)
+	if err != nil {
+		return err
+	}
+
+	item.Data, item.RawData = sanitizeRichTextFields(item.Data, paths, sanitizer)
+	if item.Locales != nil {
+		item.RawLocales = make(map[string]interface{}, len(item.Locales))
+		for locale, data := range item.Locales {
+			item.Locales[locale], item.RawLocales[locale] = sanitizeRichTextFields(data, paths, sanitizer)
+		}
+	}
+	return nil
+}
+
+// sanitizeRichTextFields gives the data with the sanitized rich text fields on the paths and a copy of the data as it is
+func sanitizeRichTextFields(data interface{}, paths []string, sanitizer model.HTMLSanitizer) (interface{}, interface{}) {
+	raw := normalizeJSONValue(data)
+	sanitized := normalizeJSONValue(data)
+	for _, path := range paths {
+		sanitized = referenceValues(sanitized, strings.Split(path, "."), func(value interface{}) interface{} {
+			if text, ok := value.(string); ok {
+				return sanitizeHTML(text, sanitizer)
+			}
+			return value
+		})
+	}
+	return sanitized, raw
+}
+
+// sanitizeHTML keeps only what the sanitizer allows. The links are rewritten first, as the rewritten ones have their text as html.
+func sanitizeHTML(value string, sanitizer model.HTMLSanitizer) string {
+	if sanitizer.RewriteLinks {
+		value = utils.ModifyHTMLContent(value)
+	}
+	return utils.SanitizeHTML(value, sanitizer.AllowedTags, sanitizer.AllowedAttributes, sanitizer.AllowedURLSchemes)
+}
+
+// useRawContentItemData puts back the raw data and locale variants of the item, so that they are sanitized again when the item is written as a new one
+func useRawContentItemData(item *model.ContentItem) {
+	if item.RawData != nil {
+		item.Data = item.RawData
+	}
+	for locale, data := range item.RawLocales {
+		if item.Locales == nil {
+			item.Locales = map[string]interface{}{}
+		}
+		item.Locales[locale] = data
+	}
+}
@@ -12,7 +12,9 @@ package interfaces
 
 import (
 	"content/core/model"
+	"encoding/json"
 	"io"
+	"time"
 
 	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
 	"go.mongodb.org/mongo-driver/bson"
@@ -34,16 +36,86 @@ type Services interface {
 	DeleteHealthLocation(appID string, orgID string, id string) error
 
 	//allApps says if the data is associated with the current app or it is for all the apps within the organization
-	GetContentItemsCategories(allApps bool, appID string, orgID string) ([]string, error)
-	GetContentItems(allApps bool, appID string, orgID string, ids []string, categoryList []string, offset *int64, limit *int64, order *string) ([]model.ContentItemResponse, error)
-	GetContentItem(allApps bool, appID string, orgID string, id string) (*model.ContentItemResponse, error)
-	CreateContentItem(allApps bool, appID string, orgID string, category string, data interface{}) (*model.ContentItem, error)
-	UpdateContentItem(allApps bool, appID string, orgID string, id string, category string, data interface{}) (*model.ContentItem, error)
-	UpdateContentItemData(allApps bool, appID string, orgID string, id string, category string, data interface{}) (*model.ContentItem, error)
-	DeleteContentItem(allApps bool, appID string, orgID string, id string) error
-	DeleteContentItemByCategory(allApps bool, appID string, orgID string, id string, category string) error
-
-	UploadImage(imageBytes []byte, path string, spec model.ImageSpec) (*string, error)
+	GetContentItemsCategories(allApps bool, appID string, orgID string, viewer *model.ContentItemsViewer) ([]string, error)
+	//publishedOnly says if only the published items within their publishing window should be given
+	//locales are the preferred locales of the client, the most preferred first. The items are given as they are stored when it is nil.
+	//expand is how many levels of the referenced content items are put in place of their ids in the data
+	//viewer is the client whose read access to the categories and the audience of the items are checked, the items which it cannot get are left out. Nothing is left out when it is nil.
+	GetContentItems(allApps bool, appID string, orgID string, ids []string, categoryList []string, dataQuery *model.ContentItemsDataQuery, offset *int64, limit *int64, order *string, publishedOnly bool, locales []string, format string, expand int, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, error)
+	GetContentItem(allApps bool, appID string, orgID string, id string, publishedOnly bool, locales []string, format string, expand int, viewer *model.ContentItemsViewer) (*model.ContentItemResponse, error)
+	GetContentItemsPage(allApps bool, appID string, orgID string, ids []string, categoryList []string, dataQuery *model.ContentItemsDataQuery, cursor *model.ContentItemsCursor, limit *int64, order *string, publishedOnly bool, locales []string, format string, expand int, viewer *model.ContentItemsViewer) (*model.ContentItemsPage, error)
+	//the published items changes are given on the channel until cancel is called. The channel is closed when the client cannot keep up, it has to subscribe again.
+	//lastEventID is the id of the last event which the client has got. A reset event is given first if the events after it are not kept anymore.
+	SubscribeContentItems(allApps bool, appID string, orgID string, categoryList []string, lastEventID string, locales []string, format string, viewer *model.ContentItemsViewer) (events <-chan model.ContentItemEvent, cancel func(), err error)
+	SearchContentItems(allApps bool, appID string, orgID string, text string, categoryList []string, offset *int64, limit *int64, publishedOnly bool, locales []string, format string, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, error)
+	CreateContentItem(claims *tokenauth.Claims, allApps bool, item model.ContentItem) (*model.ContentItem, error)
+	//version is the version of the item which the write expects to replace, it is not checked when it is nil
+	UpdateContentItem(claims *tokenauth.Claims, allApps bool, id string, category string, data interface{}, version *int64) (*model.ContentItem, error)
+	UpdateContentItemData(claims *tokenauth.Claims, allApps bool, id string, category string, data interface{}, version *int64) (*model.ContentItem, error)
+	UpdateContentItemStatus(claims *tokenauth.Claims, allApps bool, id string, status string, publishAt *time.Time, expireAt *time.Time, version *int64) (*model.ContentItem, error)
+	UpdateContentItemAudience(claims *tokenauth.Claims, allApps bool, id string, audience *model.ContentItemAudience, version *int64) (*model.ContentItem, error)
+	UpdateContentItemExpiry(claims *tokenauth.Claims, allApps bool, id string, expiresAt *time.Time, version *int64) (*model.ContentItem, error)
+	UpdateContentItemPin(claims *tokenauth.Claims, allApps bool, id string, pinned bool, pinnedUntil *time.Time, version *int64) (*model.ContentItem, error)
+	//ids are the items of the category in the desired order, they get the positions from 1. The other items of the category lose their positions.
+	ReorderContentItems(claims *tokenauth.Claims, allApps bool, category string, ids []string) ([]model.ContentItem, error)
+	//patchType is one of the DataPatchType values, the patch is applied on the data. The item could be in any category when category is empty.
+	PatchContentItemData(claims *tokenauth.Claims, allApps bool, id string, category string, patchType string, patch json.RawMessage, version *int64) (*model.ContentItem, error)
+	DeleteContentItem(claims *tokenauth.Claims, allApps bool, id string, version *int64) error
+	DeleteContentItemByCategory(claims *tokenauth.Claims, allApps bool, id string, category string, version *int64) error
+	//the deleted items are kept in the trash until they are purged
+	GetDeletedContentItems(allApps bool, appID string, orgID string, categoryList []string) ([]model.ContentItem, error)
+	RestoreContentItem(claims *tokenauth.Claims, allApps bool, id string) (*model.ContentItem, error)
+	UpdateContentItemLocale(claims *tokenauth.Claims, allApps bool, id string, locale string, data interface{}, version *int64) (*model.ContentItem, error)
+	DeleteContentItemLocale(claims *tokenauth.Claims, allApps bool, id string, locale string, version *int64) (*model.ContentItem, error)
+	GetContentItemsMissingTranslations(allApps bool, appID string, orgID string, categoryList []string, locales []string) ([]model.MissingTranslation, error)
+	//the operations are done in a single transaction, the batch is not committed if any of them fails
+	BatchContentItems(claims *tokenauth.Claims, allApps bool, operations []model.ContentItemsBatchOperation) (*model.ContentItemsBatchResult, error)
+	//all the items of the app or the organization are exported when there are no categories
+	ExportContentItems(allApps bool, appID string, orgID string, categoryList []string) ([]model.ContentItem, error)
+	//mode is one of the ContentItemsImportMode values, nothing is stored when dryRun is true or any of the items is not fine
+	ImportContentItems(claims *tokenauth.Claims, allApps bool, items []model.ContentItem, mode string, dryRun bool) (*model.ContentItemsImportReport, error)
+	//copies the items with the ids and the items in the categories to the target app, or to all the apps in the organization when targetAppID is nil
+	//onConflict is one of the ContentItemsCopyOnConflict values, nothing is stored when dryRun is true or any of the items cannot be copied
+	CopyContentItems(claims *tokenauth.Claims, allApps bool, targetAppID *string, ids []string, categoryList []string, onConflict string, dryRun bool) (*model.ContentItemsCopyReport, error)
+
+	//gives where the item is referenced by the other items, so that it is not deleted while it is still in use
+	GetContentItemReferences(allApps bool, appID string, orgID string, id string) ([]model.ContentItemReference, error)
+
+	GetContentItemRevisions(allApps bool, appID string, orgID string, id string) ([]model.ContentItemRevision, error)
+	GetContentItemRevisionsDiff(allApps bool, appID string, orgID string, id string, from string, to string) (*model.ContentItemRevisionsDiff, error)
+	RestoreContentItemRevision(claims *tokenauth.Claims, allApps bool, id string, revision int64) (*model.ContentItem, error)
+
+	GetContentItemSchemas(allApps bool, appID string, orgID string) ([]model.ContentItemSchema, error)
+	GetContentItemSchema(allApps bool, appID string, orgID string, category string) (*model.ContentItemSchema, error)
+	CreateContentItemSchema(allApps bool, appID string, orgID string, category string, schema json.RawMessage) (*model.ContentItemSchema, error)
+	UpdateContentItemSchema(allApps bool, appID string, orgID string, category string, schema json.RawMessage) (*model.ContentItemSchema, error)
+	DeleteContentItemSchema(allApps bool, appID string, orgID string, category string) error
+
+	//the categories which have content items but are not registered are given too
+	GetContentItemCategories(allApps bool, appID string, orgID string) ([]model.ContentItemCategory, error)
+	GetContentItemCategory(allApps bool, appID string, orgID string, name string) (*model.ContentItemCategory, error)
+	CreateContentItemCategory(allApps bool, appID string, orgID string, item model.ContentItemCategory) (*model.ContentItemCategory, error)
+	UpdateContentItemCategory(allApps bool, appID string, orgID string, item model.ContentItemCategory) (*model.ContentItemCategory, error)
+	DeleteContentItemCategory(allApps bool, appID string, orgID string, name string) error
+
+	GetOrgSettings(orgID string) (*model.OrgSettings, error)
+	UpdateOrgSettings(orgID string, item model.OrgSettings) (*model.OrgSettings, error)
+
+	//the latest entries are given first
+	GetAuditLogEntries(allApps bool, appID string, orgID string, filter model.AuditLogFilter, offset *int64, limit *int64) ([]model.AuditLogEntry, error)
+
+	GetWebhooks(allApps bool, appID string, orgID string) ([]model.Webhook, error)
+	GetWebhook(allApps bool, appID string, orgID string, id string) (*model.Webhook, error)
+	CreateWebhook(allApps bool, appID string, orgID string, item model.Webhook) (*model.Webhook, error)
+	UpdateWebhook(allApps bool, appID string, orgID string, item model.Webhook) (*model.Webhook, error)
+	RotateWebhookSecret(allApps bool, appID string, orgID string, id string) (*model.Webhook, error)
+	DeleteWebhook(allApps bool, appID string, orgID string, id string) error
+	//the latest deliveries are given first
+	GetWebhookDeliveries(allApps bool, appID string, orgID string, webhookID string, status *string, offset *int64, limit *int64) ([]model.WebhookDelivery, error)
+	GetWebhookDelivery(allApps bool, appID string, orgID string, webhookID string, id string) (*model.WebhookDelivery, error)
+	ReplayWebhookDelivery(allApps bool, appID string, orgID string, webhookID string, id string) (*model.WebhookDelivery, error)
+
+	UploadImage(claims *tokenauth.Claims, imageBytes []byte, path string, spec model.ImageSpec) (*string, error)
 	GetProfileImage(userID string, imageType string) ([]byte, error)
 	UploadProfileImage(userID string, bytes []byte) error
 	DeleteProfileImage(userID string) error
@@ -55,15 +127,21 @@ type Services interface {
 	GetTwitterPosts(userID string, twitterQueryParams string, force bool) (map[string]interface{}, error)
 
 	CreateDataContentItem(claims *tokenauth.Claims, item *model.DataContentItem) (*model.DataContentItem, error)
-	GetDataContentItem(claims *tokenauth.Claims, key string) (*model.DataContentItem, error)
-	UpdateDataContentItem(claims *tokenauth.Claims, item *model.DataContentItem) (*model.DataContentItem, error)
-	DeleteDataContentItem(claims *tokenauth.Claims, key string) error
-	GetDataContentItems(claims *tokenauth.Claims, category string) ([]*model.DataContentItem, error)
+	//checkReadAccess says if the read access of the category is checked, the items which the user cannot read are not given
+	GetDataContentItem(claims *tokenauth.Claims, key string, locales []string, checkReadAccess bool) (*model.DataContentItem, error)
+	UpdateDataContentItem(claims *tokenauth.Claims, item *model.DataContentItem, version *int64) (*model.DataContentItem, error)
+	DeleteDataContentItem(claims *tokenauth.Claims, key string, version *int64) error
+	GetDataContentItems(claims *tokenauth.Claims, category string, locales []string, checkReadAccess bool) ([]*model.DataContentItem, error)
+	GetDataContentItemsMissingTranslations(claims *tokenauth.Claims, category string, locales []string) ([]model.MissingTranslation, error)
+	GetDeletedDataContentItems(claims *tokenauth.Claims, category string) ([]model.DataContentItem, error)
+	RestoreDataContentItem(claims *tokenauth.Claims, id string) (*model.DataContentItem, error)
 
 	CreateCategory(claims *tokenauth.Claims, item *model.Category) (*model.Category, error)
 	GetCategory(claims *tokenauth.Claims, name string) (*model.Category, error)
-	UpdateCategory(claims *tokenauth.Claims, item *model.Category) (*model.Category, error)
-	DeleteCategory(claims *tokenauth.Claims, name string) error
+	UpdateCategory(claims *tokenauth.Claims, item *model.Category, version *int64) (*model.Category, error)
+	DeleteCategory(claims *tokenauth.Claims, name string, version *int64) error
+	GetDeletedCategories(claims *tokenauth.Claims) ([]model.Category, error)
+	RestoreCategory(claims *tokenauth.Claims, id string) (*model.Category, error)
 
 	UploadFileContentItem(file io.Reader, claims *tokenauth.Claims, fileName string, category string) error
 	GetFileContentItem(claims *tokenauth.Claims, fileName string, category string) (io.ReadCloser, error)
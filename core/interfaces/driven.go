@@ -12,6 +12,8 @@ package interfaces
 
 import (
 	"content/core/model"
+	"encoding/json"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -33,14 +35,54 @@ type Storage interface {
 	DeleteHealthLocation(appID string, orgID string, id string) error
 
 	GetContentItemsCategories(appID *string, orgID string) ([]string, error)
-	FindContentItems(appID *string, orgID string, ids []string, categoryList []string, offset *int64, limit *int64, order *string) ([]model.ContentItem, error)
-	GetContentItems(appID *string, orgID string, ids []string, categoryList []string, offset *int64, limit *int64, order *string) ([]model.ContentItemResponse, error)
-	GetContentItem(appID *string, orgID string, id string) (*model.ContentItemResponse, error)
+	FindContentItems(appID *string, orgID string, ids []string, categoryList []string, offset *int64, limit *int64, order *string, publishedOnly bool) ([]model.ContentItem, error)
+	FindContentItemsReferencing(appID *string, orgID string, paths map[string][]string, id string) ([]model.ContentItem, error)
+	FindContentItemsCopies(appID *string, orgID string, sourceIDs []string) ([]model.ContentItem, error)
+	GetContentItems(appID *string, orgID string, ids []string, categoryList []string, dataQuery *model.ContentItemsDataQuery, offset *int64, limit *int64, order *string, publishedOnly bool, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, error)
+	GetContentItem(appID *string, orgID string, id string, publishedOnly bool, viewer *model.ContentItemsViewer) (*model.ContentItemResponse, error)
+	GetContentItemsPage(appID *string, orgID string, ids []string, categoryList []string, dataQuery *model.ContentItemsDataQuery, after *model.ContentItemsCursor, limit int64, order *string, publishedOnly bool, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, *model.ContentItemsCursor, int64, error)
+	SearchContentItems(appID *string, orgID string, text string, categoryList []string, offset *int64, limit *int64, publishedOnly bool, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, error)
+	FindContentItemsMissingLocales(appID *string, orgID string, categoryList []string, locales []string) ([]model.MissingTranslation, error)
 	CreateContentItem(item model.ContentItem) (*model.ContentItem, error)
-	UpdateContentItem(appID *string, orgID string, id string, category string, data interface{}) (*model.ContentItem, error)
+	UpdateContentItem(appID *string, orgID string, id string, category string, data interface{}, rawData interface{}, renderings map[string]model.ContentItemRendering) (*model.ContentItem, error)
+	UpdateContentItemDataFields(appID *string, orgID string, id string, dataUpdate model.DataUpdate) (*model.ContentItem, error)
+	UpdateContentItemStatus(appID *string, orgID string, id string, status string, publishAt *time.Time, expireAt *time.Time) (*model.ContentItem, error)
+	UpdateContentItemAudience(appID *string, orgID string, id string, audience *model.ContentItemAudience) (*model.ContentItem, error)
+	UpdateContentItemExpiry(appID *string, orgID string, id string, expiresAt *time.Time) (*model.ContentItem, error)
+	UpdateContentItemPin(appID *string, orgID string, id string, pinned bool, pinnedUntil *time.Time) (*model.ContentItem, error)
+	UpdateContentItemPosition(appID *string, orgID string, id string, position *int64) error
 	DeleteContentItem(appID *string, orgID string, id string) error
+	FindDeletedContentItems(appID *string, orgID string, categoryList []string) ([]model.ContentItem, error)
+	FindDeletedContentItem(appID *string, orgID string, id string) (*model.ContentItem, error)
+	RestoreContentItem(appID *string, orgID string, id string) (*model.ContentItem, error)
+	PurgeDeletedContentItems(before time.Time) (int64, error)
+	PurgeOrphanedContentItemRevisions() (int64, error)
 	SaveContentItem(item model.ContentItem) error
 
+	CreateContentItemRevision(item model.ContentItemRevision) error
+	CountContentItemRevisions(contentItemID string) (int64, error)
+	FindContentItemRevisions(appID *string, orgID string, contentItemID string) ([]model.ContentItemRevision, error)
+	FindContentItemRevision(appID *string, orgID string, contentItemID string, revision int64) (*model.ContentItemRevision, error)
+
+	FindContentItemSchemas(appID *string, orgID string) ([]model.ContentItemSchema, error)
+	FindContentItemSchema(appID *string, orgID string, category string) (*model.ContentItemSchema, error)
+	CreateContentItemSchema(item model.ContentItemSchema) (*model.ContentItemSchema, error)
+	UpdateContentItemSchema(appID *string, orgID string, category string, schema json.RawMessage) (*model.ContentItemSchema, error)
+	DeleteContentItemSchema(appID *string, orgID string, category string) error
+
+	FindContentItemCategories(appID *string, orgID string) ([]model.ContentItemCategory, error)
+	FindContentItemCategory(appID *string, orgID string, name string) (*model.ContentItemCategory, error)
+	CreateContentItemCategory(item model.ContentItemCategory) error
+	UpdateContentItemCategory(item model.ContentItemCategory) error
+	DeleteContentItemCategory(appID *string, orgID string, name string) error
+	FindContentItemsNextTransition(appID *string, orgID string, ids []string, categoryList []string, after time.Time) (*time.Time, error)
+	FindNextContentItemsVisibilityChange(after time.Time) (*time.Time, error)
+	FindContentItemsVisibilityChanges(from time.Time, to time.Time) ([]model.ContentItemEvent, error)
+	GetContentItemsCategoriesStats(appID *string, orgID string) ([]model.ContentItemsCategoryStats, error)
+
+	FindOrgSettings(orgID string) (*model.OrgSettings, error)
+	SaveOrgSettings(item model.OrgSettings) error
+
 	//Used for multi-tenancy for already exisiting data.
 	//To be removed when this is applied to all environments.
 	FindAllContentItems() ([]model.ContentItemResponse, error)
@@ -51,14 +93,58 @@ type Storage interface {
 	FindDataContentItem(appID *string, orgID string, key string) (*model.DataContentItem, error)
 	UpdateDataContentItem(appID *string, orgID string, item *model.DataContentItem) (*model.DataContentItem, error)
 	DeleteDataContentItem(appID *string, orgID string, key string) error
+	FindDeletedDataContentItems(appID *string, orgID string, category string) ([]model.DataContentItem, error)
+	FindDeletedDataContentItem(appID *string, orgID string, id string) (*model.DataContentItem, error)
+	RestoreDataContentItem(appID *string, orgID string, id string) (*model.DataContentItem, error)
+	PurgeDeletedDataContentItems(before time.Time) (int64, error)
 	FindDataContentItems(appID *string, orgID string, key string) ([]*model.DataContentItem, error)
+	FindDataContentItemsMissingLocales(appID *string, orgID string, category string, locales []string) ([]model.MissingTranslation, error)
 
 	CreateCategory(item *model.Category) (*model.Category, error)
 	FindCategory(appID *string, orgID string, name string) (*model.Category, error)
+	FindCategoryByID(appID *string, orgID string, id string) (*model.Category, error)
 	UpdateCategory(appID *string, orgID string, item *model.Category) (*model.Category, error)
 	DeleteCategory(appID *string, orgID string, key string) error
+	FindDeletedCategories(appID *string, orgID string) ([]model.Category, error)
+	RestoreCategory(appID *string, orgID string, id string) (*model.Category, error)
+	PurgeDeletedCategories(before time.Time) (int64, error)
+
+	CreateAuditLogEntry(entry model.AuditLogEntry) error
+	FindAuditLogEntries(appID *string, orgID string, filter model.AuditLogFilter, offset *int64, limit *int64) ([]model.AuditLogEntry, error)
+
+	CreateWebhook(item model.Webhook) error
+	FindWebhooks(appID *string, orgID string) ([]model.Webhook, error)
+	FindWebhook(appID *string, orgID string, id string) (*model.Webhook, error)
+	UpdateWebhook(item model.Webhook) error
+	DeleteWebhook(appID *string, orgID string, id string) error
+	CreateWebhookDeliveries(items []model.WebhookDelivery) error
+	ClaimWebhookDelivery(now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	SaveWebhookDelivery(item model.WebhookDelivery) error
+	FindWebhookDeliveries(appID *string, orgID string, webhookID string, status *string, offset *int64, limit *int64) ([]model.WebhookDelivery, error)
+	FindWebhookDelivery(appID *string, orgID string, webhookID string, id string) (*model.WebhookDelivery, error)
+
+	RegisterStorageListener(listener StorageListener)
 }
 
+// StorageListener listens for the changes of the stored data. The changes made by all the instances of the service are given.
+type StorageListener interface {
+	OnContentItemChanged(event model.ContentItemEvent)
+	OnDataContentItemChanged(event model.DataContentItemEvent)
+	OnContentSettingsChanged(change model.ContentSettingsChange)
+}
+
+// DefaultStorageListener does nothing on the changes, the listeners embed it and override the changes which they are interested in
+type DefaultStorageListener struct{}
+
+// OnContentItemChanged does nothing
+func (d *DefaultStorageListener) OnContentItemChanged(event model.ContentItemEvent) {}
+
+// OnDataContentItemChanged does nothing
+func (d *DefaultStorageListener) OnDataContentItemChanged(event model.DataContentItemEvent) {}
+
+// OnContentSettingsChanged does nothing
+func (d *DefaultStorageListener) OnContentSettingsChanged(change model.ContentSettingsChange) {}
+
 // Core BB interface
 type Core interface {
 	LoadDeletedMemberships() ([]model.DeletedUserData, error)
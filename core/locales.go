@@ -0,0 +1,175 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// normalizeLocale gives the locale in lower case with "-" as separator, for example es_MX -> es-mx
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// normalizeLocales checks the locales of the variants and gives them normalized
+func normalizeLocales(variants map[string]interface{}) (map[string]interface{}, error) {
+	if len(variants) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]interface{}, len(variants))
+	for locale, data := range variants {
+		normalized := normalizeLocale(locale)
+		if !localePattern.MatchString(normalized) {
+			return nil, fmt.Errorf("invalid locale %s", locale)
+		}
+		if _, ok := result[normalized]; ok {
+			return nil, fmt.Errorf("duplicated locale %s", locale)
+		}
+		result[normalized] = data
+	}
+	return result, nil
+}
+
+// normalizeReportLocales checks and normalizes the locales for which the missing translations are looked for
+func normalizeReportLocales(locales []string) ([]string, error) {
+	if len(locales) == 0 {
+		return nil, errors.New("missing locales")
+	}
+	result := make([]string, len(locales))
+	for i, locale := range locales {
+		result[i] = normalizeLocale(locale)
+		if !localePattern.MatchString(result[i]) {
+			return nil, fmt.Errorf("invalid locale %s", locale)
+		}
+	}
+	return result, nil
+}
+
+// localeChain gives the locales to look for in order - every requested locale followed by its more general forms
+// (es-mx -> es) and then the configured fallback locales
+func localeChain(requested []string, fallback []string) []string {
+	chain := []string{}
+	added := map[string]bool{}
+	for _, locale := range append(append([]string{}, requested...), fallback...) {
+		locale = normalizeLocale(locale)
+		for len(locale) > 0 {
+			if !added[locale] {
+				chain = append(chain, locale)
+				added[locale] = true
+			}
+			index := strings.LastIndex(locale, "-")
+			if index < 0 {
+				break
+			}
+			locale = locale[:index]
+		}
+	}
+	return chain
+}
+
+// selectLocaleVariant gives the first variant found by the chain. It gives false if there is no such, in this case the default data is used.
+func selectLocaleVariant(variants interface{}, chain []string) (string, interface{}, bool) {
+	for _, locale := range chain {
+		if data, found := documentValue(variants, locale); found {
+			return locale, data, true
+		}
+	}
+	return "", nil, false
+}
+
+// documentValue gives the value of the key in the document, the document could be decoded from json or from bson
+func documentValue(document interface{}, key string) (interface{}, bool) {
+	switch v := document.(type) {
+	case map[string]interface{}:
+		value, found := v[key]
+		return value, found
+	case primitive.M:
+		value, found := v[key]
+		return value, found
+	case primitive.D:
+		for _, e := range v {
+			if e.Key == key {
+				return e.Value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// localizedDataQuery gives the query which has the locale variants to give the requested fields for, so that the items could be localized
+func (s *servicesImpl) localizedDataQuery(dataQuery *model.ContentItemsDataQuery, locales []string) *model.ContentItemsDataQuery {
+	if dataQuery == nil || len(dataQuery.Fields) == 0 {
+		return dataQuery
+	}
+	localized := *dataQuery
+	localized.Locales = localeChain(locales, s.app.localeFallback)
+	return &localized
+}
+
+// localizeContentItem puts the best matching variant in the format as data of the item. The variants are not given to the clients.
+func localizeContentItem(item model.ContentItemResponse, chain []string, format string) {
+	if item == nil {
+		return
+	}
+	formatContentItem(item, format)
+	locale, data, found := selectLocaleVariant(item["locales"], chain)
+	if found {
+		item["data"] = data
+		item["locale"] = locale
+	}
+	delete(item, "locales")
+	delete(item, "raw_data")
+	delete(item, "raw_locales")
+}
+
+// localizeDataContentItem puts the best matching variant as data of the item. The variants are not given to the clients.
+func localizeDataContentItem(item *model.DataContentItem, chain []string) {
+	if item == nil {
+		return
+	}
+	locale, data, found := selectLocaleVariant(item.Locales, chain)
+	if found {
+		item.Data = data
+		item.Locale = &locale
+	}
+	item.Locales = nil
+}
+
+// missingLocales gives the locales which the item does not have
+func missingLocales(itemLocales []string, locales []string) []string {
+	missing := []string{}
+	for _, locale := range locales {
+		found := false
+		for _, itemLocale := range itemLocales {
+			if itemLocale == locale {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, locale)
+		}
+	}
+	return missing
+}
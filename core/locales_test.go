@@ -0,0 +1,45 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocaleChain(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested []string
+		fallback  []string
+		want      []string
+	}{
+		{name: "nothing", want: []string{}},
+		{name: "locale", requested: []string{"es"}, want: []string{"es"}},
+		{name: "general forms", requested: []string{"zh-Hant-TW"}, want: []string{"zh-hant-tw", "zh-hant", "zh"}},
+		{name: "normalized", requested: []string{" ES_mx "}, want: []string{"es-mx", "es"}},
+		{name: "in order", requested: []string{"fr-ca", "es-mx"}, want: []string{"fr-ca", "fr", "es-mx", "es"}},
+		{name: "fallback", requested: []string{"es-mx"}, fallback: []string{"en"}, want: []string{"es-mx", "es", "en"}},
+		{name: "no duplicates", requested: []string{"es-mx", "es", "es-es"}, fallback: []string{"es"}, want: []string{"es-mx", "es", "es-es"}},
+		{name: "empty locales", requested: []string{"", "es"}, want: []string{"es"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := localeChain(tt.requested, tt.fallback); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("localeChain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
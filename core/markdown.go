@@ -0,0 +1,95 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"content/utils"
+	"strings"
+)
+
+// markdownFormat is the JSON Schema format of the data fields which have markdown, they are rendered when they are written
+const markdownFormat = "markdown"
+
+// renderedContentItemFormats are the formats in which the markdown fields are rendered
+var renderedContentItemFormats = []string{model.ContentItemFormatHTML, model.ContentItemFormatText}
+
+// renderContentItem renders the markdown fields of the data and the locale variants of the item in all the formats.
+// The html is sanitized as the rich text fields are. The item does not have renderings when its category does not have markdown fields.
+func (s *servicesImpl) renderContentItem(appID *string, orgID string, item *model.ContentItem) error {
+	item.Renderings = nil
+	paths, err := s.contentSchemaFormatPaths(appID, orgID, item.Category, markdownFormat)
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+	sanitizer, err := s.htmlSanitizer(orgID)
+	if err != nil {
+		return err
+	}
+
+	item.Renderings = make(map[string]model.ContentItemRendering, len(renderedContentItemFormats))
+	for _, format := range renderedContentItemFormats {
+		rendering := model.ContentItemRendering{Data: renderMarkdownFields(item.Data, paths, format, sanitizer)}
+		if len(item.Locales) > 0 {
+			rendering.Locales = make(map[string]interface{}, len(item.Locales))
+			for locale, data := range item.Locales {
+				rendering.Locales[locale] = renderMarkdownFields(data, paths, format, sanitizer)
+			}
+		}
+		item.Renderings[format] = rendering
+	}
+	return nil
+}
+
+// renderMarkdownFields gives a copy of the data with the markdown fields on the paths rendered in the format
+func renderMarkdownFields(data interface{}, paths []string, format string, sanitizer model.HTMLSanitizer) interface{} {
+	rendered := normalizeJSONValue(data)
+	for _, path := range paths {
+		rendered = referenceValues(rendered, strings.Split(path, "."), func(value interface{}) interface{} {
+			text, ok := value.(string)
+			if !ok {
+				return value
+			}
+			html := sanitizeHTML(utils.RenderMarkdown(text), sanitizer)
+			if format == model.ContentItemFormatText {
+				return utils.HTMLText(html)
+			}
+			return html
+		})
+	}
+	return rendered
+}
+
+// formatContentItem puts the rendering in the format as data and locale variants of the item, the markdown format is the data as it is.
+// The renderings are not given to the clients.
+func formatContentItem(item model.ContentItemResponse, format string) {
+	renderings := item["renderings"]
+	delete(item, "renderings")
+	if format == model.ContentItemFormatMarkdown {
+		return
+	}
+
+	rendering, found := documentValue(renderings, format)
+	if !found {
+		//the category does not have markdown fields
+		return
+	}
+	if data, found := documentValue(rendering, "data"); found {
+		item["data"] = data
+	}
+	if locales, found := documentValue(rendering, "locales"); found {
+		item["locales"] = locales
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+const (
+	//AuditActionCreate the resource was created
+	AuditActionCreate string = "create"
+	//AuditActionUpdate the resource was changed
+	AuditActionUpdate string = "update"
+	//AuditActionDelete the resource was deleted
+	AuditActionDelete string = "delete"
+	//AuditActionRestore the resource was taken out of the trash or an older revision of it was restored
+	AuditActionRestore string = "restore"
+	//AuditActionImport the resource was created or replaced by an import
+	AuditActionImport string = "import"
+	//AuditActionCopy the resource was created or replaced by a copy from another app scope
+	AuditActionCopy string = "copy"
+	//AuditActionUpload the file or the image was uploaded
+	AuditActionUpload string = "upload"
+
+	//AuditResourceContentItem content item
+	AuditResourceContentItem string = "content_item"
+	//AuditResourceDataContentItem data content item
+	AuditResourceDataContentItem string = "data_content_item"
+	//AuditResourceCategory category
+	AuditResourceCategory string = "category"
+	//AuditResourceFile file content item
+	AuditResourceFile string = "file"
+	//AuditResourceImage image
+	AuditResourceImage string = "image"
+)
+
+// AuditLogEntry records who changed a resource, when and how. Entries are never changed once created.
+type AuditLogEntry struct {
+	ID           string       `json:"id" bson:"_id"`
+	Actor        AuditActor   `json:"actor" bson:"actor"`
+	Action       string       `json:"action" bson:"action"`               // one of the AuditAction values
+	ResourceType string       `json:"resource_type" bson:"resource_type"` // one of the AuditResource values
+	ResourceID   string       `json:"resource_id" bson:"resource_id"`
+	OrgID        string       `json:"org_id" bson:"org_id"`
+	AppID        *string      `json:"app_id" bson:"app_id"`
+	Changes      []DataChange `json:"changes" bson:"changes"` // the difference between the resource before and after the change
+	DateCreated  time.Time    `json:"date_created" bson:"date_created"`
+} // @name AuditLogEntry
+
+// AuditActor is the user who made a change
+type AuditActor struct {
+	Subject     string   `json:"subject" bson:"subject"`
+	Name        string   `json:"name,omitempty" bson:"name,omitempty"`
+	Permissions []string `json:"permissions" bson:"permissions"`
+} // @name AuditActor
+
+// AuditLogFilter narrows the audit log entries, the empty fields are not checked
+type AuditLogFilter struct {
+	ActorSubject *string
+	ResourceType *string
+	ResourceID   *string
+	From         *time.Time // inclusive
+	To           *time.Time // exclusive
+}
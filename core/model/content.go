@@ -26,15 +26,51 @@ type DataContentItem struct {
 	OrgID       string      `json:"org_id" bson:"org_id"`
 	AppID       *string     `json:"app_id" bson:"app_id"`
 	Key         string      `json:"key" bson:"key"`
+
+	Locales map[string]interface{} `json:"locales,omitempty" bson:"locales,omitempty"` // the data for other locales, for example es or es-mx
+	Locale  *string                `json:"locale,omitempty" bson:"-"`                  // the locale of the data given to the clients when it is not the default one
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"` // the item is removed at this time
+
+	Version int64 `json:"version" bson:"version"` // increased on every write, the items created before it was introduced have 0
+
+	DateDeleted *time.Time `json:"date_deleted,omitempty" bson:"date_deleted,omitempty"` // set when the item is in the trash
 } // @name DataContentItem
 
+// DataContentItemEvent is a change of a stored data content item
+type DataContentItemEvent struct {
+	Previous *DataContentItem // before the change, nil when it has been created or it is not known
+	Current  *DataContentItem // after the change, nil when it has been removed or it is not known
+}
+
+const (
+	//ContentSettingsCategories the categories of the data content items with their read access
+	ContentSettingsCategories string = "categories"
+	//ContentSettingsContentItemCategories the registered categories of the content items with their read access
+	ContentSettingsContentItemCategories string = "content_item_categories"
+	//ContentSettingsContentItemSchemas the schemas of the content items
+	ContentSettingsContentItemSchemas string = "content_item_schemas"
+)
+
+// ContentSettingsChange is a change of the settings which apply to the content of an app
+type ContentSettingsChange struct {
+	Settings string  // one of the ContentSettings values
+	OrgID    *string // nil when it is not known
+	AppID    *string // nil for the settings for all the apps in the organization
+}
+
 // Category defines a category with permissions to allow editing of content items
 type Category struct {
-	ID          string     `json:"id" bson:"_id"`
-	Name        string     `json:"name" bson:"name"`
-	OrgID       string     `json:"org_id" bson:"org_id"`
-	AppID       *string    `json:"app_id" bson:"app_id"`
-	DateCreated time.Time  `json:"date_created" bson:"date_created"`
-	DateUpdated *time.Time `json:"date_updated,omitempty" bson:"date_updated,omitempty"`
-	Permissions []string   `json:"permissions" bson:"permissions"`
+	ID          string      `json:"id" bson:"_id"`
+	Name        string      `json:"name" bson:"name"`
+	OrgID       string      `json:"org_id" bson:"org_id"`
+	AppID       *string     `json:"app_id" bson:"app_id"`
+	DateCreated time.Time   `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time  `json:"date_updated,omitempty" bson:"date_updated,omitempty"`
+	Permissions []string    `json:"permissions" bson:"permissions"`
+	ReadAccess  *ReadAccess `json:"read_access,omitempty" bson:"read_access,omitempty"` // everybody could read the items when it is nil
+
+	Version int64 `json:"version" bson:"version"` // increased on every write, the categories created before it was introduced have 0
+
+	DateDeleted *time.Time `json:"date_deleted,omitempty" bson:"date_deleted,omitempty"` // set when the category is in the trash
 } // @name Category
@@ -0,0 +1,70 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// ContentItemCategory is a registered category of the content items
+type ContentItemCategory struct {
+	ID          string      `json:"id" bson:"_id"`
+	Name        string      `json:"name" bson:"name"` // the category which the content items have
+	DisplayName string      `json:"display_name" bson:"display_name"`
+	Description string      `json:"description" bson:"description"`
+	Icon        string      `json:"icon" bson:"icon"`                                   // the url or the name of the icon
+	ReadAccess  *ReadAccess `json:"read_access,omitempty" bson:"read_access,omitempty"` // everybody could read the items when it is nil
+	OrgID       string      `json:"org_id" bson:"org_id"`
+	AppID       *string     `json:"app_id" bson:"app_id"`
+
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated,omitempty" bson:"date_updated,omitempty"`
+
+	Registered   bool       `json:"registered" bson:"-"`    // false for the categories which have content items but are not registered
+	ItemsCount   int64      `json:"items_count" bson:"-"`   // the content items which are not deleted
+	LastModified *time.Time `json:"last_modified" bson:"-"` // the latest create or update of a content item in the category
+} // @name ContentItemCategory
+
+// ContentItemsCategoryStats gives how many content items a category has and when they have been modified
+type ContentItemsCategoryStats struct {
+	Category     string     `bson:"_id"`
+	ItemsCount   int64      `bson:"items_count"`
+	LastModified *time.Time `bson:"last_modified"`
+}
+
+// ReadAccess defines who could read the items in a category. All the given requirements must be met.
+type ReadAccess struct {
+	Authenticated bool     `json:"authenticated" bson:"authenticated"` // the anonymous users cannot read the items
+	Permissions   []string `json:"permissions" bson:"permissions"`     // the user must have at least one of them, the roles are given to the tokens as their permissions
+} // @name ReadAccess
+
+// Allows checks if the user could read the items
+func (a *ReadAccess) Allows(anonymous bool, permissions []string) bool {
+	if a == nil {
+		return true
+	}
+	if a.Authenticated && anonymous {
+		return false
+	}
+	if len(a.Permissions) == 0 {
+		return true
+	}
+	for _, permission := range a.Permissions {
+		for _, granted := range permissions {
+			if permission == granted {
+				return true
+			}
+		}
+	}
+	return false
+}
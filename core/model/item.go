@@ -14,7 +14,31 @@
 
 package model
 
-import "time"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	//ContentItemStatusDraft the content item is not visible to the clients
+	ContentItemStatusDraft string = "draft"
+	//ContentItemStatusPublished the content item is visible to the clients within its publish_at and expire_at window
+	ContentItemStatusPublished string = "published"
+	//ContentItemStatusArchived the content item is not visible to the clients anymore
+	ContentItemStatusArchived string = "archived"
+
+	//ContentItemsOrderPosition the manual order of the content items - the pinned items first, then the items by their position and at last the items without position by their creation
+	ContentItemsOrderPosition string = "position"
+
+	//ContentItemFormatMarkdown the markdown fields of the content items as they have been written
+	ContentItemFormatMarkdown string = "markdown"
+	//ContentItemFormatHTML the markdown fields of the content items rendered to sanitized html
+	ContentItemFormatHTML string = "html"
+	//ContentItemFormatText the markdown fields of the content items rendered to plain text
+	ContentItemFormatText string = "text"
+)
 
 // ContentItemResponse is a workaround due to problem with data json & bson encode and decode with abstract type
 type ContentItemResponse = map[string]interface{}
@@ -28,4 +52,322 @@ type ContentItem struct {
 	Data        interface{} `json:"data" bson:"data"` // could be eigther a primitive or nested json or array
 	OrgID       string      `json:"org_id" bson:"org_id"`
 	AppID       *string     `json:"app_id" bson:"app_id"`
+
+	Status    string     `json:"status,omitempty" bson:"status,omitempty"` // draft, published or archived. Items without status are published
+	PublishAt *time.Time `json:"publish_at,omitempty" bson:"publish_at,omitempty"`
+	ExpireAt  *time.Time `json:"expire_at,omitempty" bson:"expire_at,omitempty"`
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"` // the item is removed at this time, unlike expire_at which only hides it from the clients
+
+	Audience *ContentItemAudience `json:"audience,omitempty" bson:"audience,omitempty"` // all the clients get the item when it is nil
+
+	Position    *int64     `json:"position,omitempty" bson:"position,omitempty"`         // the manual order of the items, the items without it come after the ones with it
+	Pinned      bool       `json:"pinned,omitempty" bson:"pinned,omitempty"`             // the pinned items come first in the manual order
+	PinnedUntil *time.Time `json:"pinned_until,omitempty" bson:"pinned_until,omitempty"` // the item is not pinned anymore after it, it is pinned until unpinned when it is nil
+
+	Locales map[string]interface{} `json:"locales,omitempty" bson:"locales,omitempty"` // the data for other locales, for example es or es-mx
+
+	//the data and the locales as they have been written when the category has rich text fields, the rich text fields are sanitized in data and locales. Not given to the clients.
+	RawData    interface{}            `json:"raw_data,omitempty" bson:"raw_data,omitempty"`
+	RawLocales map[string]interface{} `json:"raw_locales,omitempty" bson:"raw_locales,omitempty"`
+
+	Renderings map[string]ContentItemRendering `json:"renderings,omitempty" bson:"renderings,omitempty"` // by format, set when the category has markdown fields. Not given to the clients.
+
+	CopiedFrom *string `json:"copied_from,omitempty" bson:"copied_from,omitempty"` // the id of the item which this one is a copy of, when it has been copied from another app scope
+
+	Version int64 `json:"version" bson:"version"` // increased on every write, the items created before it was introduced have 0
+
+	DateDeleted *time.Time `json:"date_deleted,omitempty" bson:"date_deleted,omitempty"` // set when the item is in the trash
 } // @name ContentItem
+
+// ContentItemRendering is the data and the locale variants of a content item with the markdown fields rendered in a format
+type ContentItemRendering struct {
+	Data    interface{}            `json:"data" bson:"data"`
+	Locales map[string]interface{} `json:"locales,omitempty" bson:"locales,omitempty"`
+} // @name ContentItemRendering
+
+// ContentItemAudience defines the clients which get a content item. All the given rules must match.
+// There are no rules for the roles and the groups, as the token has only the permissions which they grant - the items for a role or a group
+// are given to the permissions of the role or the group.
+type ContentItemAudience struct {
+	Permissions   []string `json:"permissions,omitempty" bson:"permissions,omitempty"`         // the user must have at least one of them
+	Anonymous     *bool    `json:"anonymous,omitempty" bson:"anonymous,omitempty"`             // true for the anonymous users only, false for the signed in users only
+	MinAppVersion *string  `json:"min_app_version,omitempty" bson:"min_app_version,omitempty"` // x.x.x or x.x, the version itself is included
+	MaxAppVersion *string  `json:"max_app_version,omitempty" bson:"max_app_version,omitempty"` // x.x.x or x.x, the version itself is included
+
+	//the versions as numbers, so that they could be compared when the items are looked for
+	MinAppVersionNumber *int64 `json:"-" bson:"min_app_version_number,omitempty"`
+	MaxAppVersionNumber *int64 `json:"-" bson:"max_app_version_number,omitempty"`
+} // @name ContentItemAudience
+
+// ContentItemsViewer is the client which reads the content items. The read access of the categories and the audience of the items are checked against it.
+type ContentItemsViewer struct {
+	Anonymous   bool
+	Permissions []string
+	AppVersion  *string // nil when the client has not given it, the items for specific app versions are not given then
+}
+
+// ContentItemsCursor points to the last content item of a page. The next page starts after it.
+type ContentItemsCursor struct {
+	DateCreated time.Time `json:"d"`
+	ID          string    `json:"i"`
+
+	//set only for the manual order
+	Pinned   bool   `json:"p,omitempty"`
+	Position *int64 `json:"o,omitempty"`
+}
+
+// Encode gives the opaque token which the clients pass to get the next page
+func (c ContentItemsCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeContentItemsCursor parses a token given by ContentItemsCursor.Encode
+func DecodeContentItemsCursor(token string) (*ContentItemsCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	var cursor ContentItemsCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil || len(cursor.ID) == 0 {
+		return nil, errors.New("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// ContentItemsPage is a page of content items
+type ContentItemsPage struct {
+	Items      []ContentItemResponse `json:"items"`
+	NextCursor *string               `json:"next_cursor"` // null when there are no more items
+	Total      int64                 `json:"total"`       // the count of all the items which match the filter
+} // @name ContentItemsPage
+
+const (
+	//DataFilterOperatorEq the field equals one of the values
+	DataFilterOperatorEq string = "eq"
+	//DataFilterOperatorNe the field does not equal any of the values
+	DataFilterOperatorNe string = "ne"
+	//DataFilterOperatorIn the field equals or, for arrays, contains one of the coma separated values
+	DataFilterOperatorIn string = "in"
+	//DataFilterOperatorNin the field does not equal or contain any of the coma separated values
+	DataFilterOperatorNin string = "nin"
+	//DataFilterOperatorGt the field is greater than the value
+	DataFilterOperatorGt string = "gt"
+	//DataFilterOperatorGte the field is greater than or equal to the value
+	DataFilterOperatorGte string = "gte"
+	//DataFilterOperatorLt the field is less than the value
+	DataFilterOperatorLt string = "lt"
+	//DataFilterOperatorLte the field is less than or equal to the value
+	DataFilterOperatorLte string = "lte"
+	//DataFilterOperatorExists the field exists or not depending on the value - true or false
+	DataFilterOperatorExists string = "exists"
+)
+
+// DataFilter is a condition on a field within the data of the content items, for example data.building=ECEB
+type DataFilter struct {
+	Path     string   // the full path of the field, for example data.building
+	Operator string   // one of the DataFilterOperator values
+	Values   []string // the values as they are given in the query
+}
+
+// ContentItemsDataQuery narrows the content items by their data and limits the fields which are given back
+type ContentItemsDataQuery struct {
+	Filters []DataFilter
+	Fields  []string // the fields to give back, all of them when empty. id and date_created are always given.
+	Locales []string // the locale variants which the fields are given for as well
+}
+
+// MissingTranslation says which of the requested locales a content item or a data content item does not have
+type MissingTranslation struct {
+	ID             string   `json:"id" bson:"_id"`
+	Category       string   `json:"category" bson:"category"`
+	Key            string   `json:"key,omitempty" bson:"key,omitempty"` // only for data content items
+	Locales        []string `json:"-" bson:"locales"`                   // the locales which the item has
+	MissingLocales []string `json:"missing_locales" bson:"-"`
+} // @name MissingTranslation
+
+const (
+	//ContentItemsImportModeUpsert the imported items replace the items with the same id, the others are created with their ids
+	ContentItemsImportModeUpsert string = "upsert"
+	//ContentItemsImportModeCreate all the imported items are created as new ones with new ids
+	ContentItemsImportModeCreate string = "create"
+)
+
+// ContentItemsImportReport says what an import did or, for a dry run, would do. Nothing is changed when there are errors.
+type ContentItemsImportReport struct {
+	Mode     string                    `json:"mode"`
+	DryRun   bool                      `json:"dry_run"`
+	Imported bool                      `json:"imported"` // false for a dry run or when there are errors
+	Total    int                       `json:"total"`
+	Created  int                       `json:"created"`
+	Updated  int                       `json:"updated"`
+	Errors   []ContentItemsImportError `json:"errors"`
+} // @name ContentItemsImportReport
+
+// ContentItemsImportError is the reason for which an imported item cannot be stored
+type ContentItemsImportError struct {
+	Line    int    `json:"line"` // the line of the item in the stream, the empty lines are not counted
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+} // @name ContentItemsImportError
+
+const (
+	//ContentItemsCopyOnConflictSkip the items which already have a copy in the target are not copied again
+	ContentItemsCopyOnConflictSkip string = "skip"
+	//ContentItemsCopyOnConflictReplace the existing copies in the target are replaced by new copies of the items
+	ContentItemsCopyOnConflictReplace string = "replace"
+	//ContentItemsCopyOnConflictFail nothing is copied when any of the items already has a copy in the target
+	ContentItemsCopyOnConflictFail string = "fail"
+
+	//ContentItemsCopyConflictExists the target already has a copy of the item
+	ContentItemsCopyConflictExists string = "exists"
+	//ContentItemsCopyConflictUnresolvedReference the item references an item which is not copied, so the reference is kept as it is
+	ContentItemsCopyConflictUnresolvedReference string = "unresolved_reference"
+)
+
+// ContentItemsCopyReport says what a copy between app scopes did or, for a dry run, would do. Nothing is changed when there are errors.
+type ContentItemsCopyReport struct {
+	SourceAppID *string                    `json:"source_app_id"` // nil for the items for all the apps in the organization
+	TargetAppID *string                    `json:"target_app_id"` // nil for the items for all the apps in the organization
+	OnConflict  string                     `json:"on_conflict"`
+	DryRun      bool                       `json:"dry_run"`
+	Copied      bool                       `json:"copied"` // false for a dry run, when there are errors or when it fails on a conflict
+	Total       int                        `json:"total"`
+	Created     int                        `json:"created"`
+	Replaced    int                        `json:"replaced"`
+	Skipped     int                        `json:"skipped"`
+	Items       []ContentItemsCopyItem     `json:"items"`
+	Conflicts   []ContentItemsCopyConflict `json:"conflicts"`
+	Errors      []ContentItemsCopyError    `json:"errors"`
+} // @name ContentItemsCopyReport
+
+// ContentItemsCopyItem gives the id which a copied item has in the target
+type ContentItemsCopyItem struct {
+	SourceID string `json:"source_id"`
+	TargetID string `json:"target_id"`
+	Action   string `json:"action"` // create, replace or skip
+} // @name ContentItemsCopyItem
+
+// ContentItemsCopyConflict is something which the admin should be aware of about a copied item
+type ContentItemsCopyConflict struct {
+	SourceID string `json:"source_id"`
+	TargetID string `json:"target_id,omitempty"` // the existing copy for the exists conflicts
+	Type     string `json:"type"`                // one of the ContentItemsCopyConflict values
+	Message  string `json:"message"`
+} // @name ContentItemsCopyConflict
+
+// ContentItemsCopyError is the reason for which an item cannot be copied
+type ContentItemsCopyError struct {
+	SourceID string `json:"source_id,omitempty"`
+	Message  string `json:"message"`
+} // @name ContentItemsCopyError
+
+const (
+	//ContentItemsBatchActionCreate creates a new content item
+	ContentItemsBatchActionCreate string = "create"
+	//ContentItemsBatchActionUpdate replaces the category and the data of a content item
+	ContentItemsBatchActionUpdate string = "update"
+	//ContentItemsBatchActionDelete deletes a content item
+	ContentItemsBatchActionDelete string = "delete"
+
+	//ContentItemsBatchStatusSucceeded the operation has been done
+	ContentItemsBatchStatusSucceeded string = "succeeded"
+	//ContentItemsBatchStatusFailed the operation cannot be done, the whole batch is rolled back
+	ContentItemsBatchStatusFailed string = "failed"
+	//ContentItemsBatchStatusRolledBack the operation could be done but it is rolled back because of a later failed one
+	ContentItemsBatchStatusRolledBack string = "rolled_back"
+	//ContentItemsBatchStatusSkipped the operation has not been tried because of an earlier failed one
+	ContentItemsBatchStatusSkipped string = "skipped"
+)
+
+// ContentItemsBatchOperation is an operation within a content items batch
+type ContentItemsBatchOperation struct {
+	Action   string      `json:"action"`             // one of the ContentItemsBatchAction values
+	ID       string      `json:"id,omitempty"`       // for update and delete
+	Category string      `json:"category,omitempty"` // for create and update
+	Data     interface{} `json:"data,omitempty"`     // for create and update
+
+	//for create only
+	Locales   map[string]interface{} `json:"locales,omitempty"`
+	Status    string                 `json:"status,omitempty"`
+	PublishAt *time.Time             `json:"publish_at,omitempty"`
+	ExpireAt  *time.Time             `json:"expire_at,omitempty"`
+	ExpiresAt *time.Time             `json:"expires_at,omitempty"`
+
+	Version *int64 `json:"version,omitempty"` // for update and delete - the expected version of the item, it is not checked when it is missing
+} // @name ContentItemsBatchOperation
+
+// ContentItemsBatchResult gives the result of every operation of a batch. The batch is committed only if all of them succeed.
+type ContentItemsBatchResult struct {
+	Committed bool                               `json:"committed"`
+	Results   []ContentItemsBatchOperationResult `json:"results"`
+} // @name ContentItemsBatchResult
+
+// ContentItemsBatchOperationResult is the result of an operation within a content items batch
+type ContentItemsBatchOperationResult struct {
+	Index  int          `json:"index"`
+	Action string       `json:"action"`
+	ID     string       `json:"id,omitempty"`
+	Status string       `json:"status"`         // one of the ContentItemsBatchStatus values
+	Item   *ContentItem `json:"item,omitempty"` // the created or the updated item when the batch is committed
+	Error  string       `json:"error,omitempty"`
+} // @name ContentItemsBatchOperationResult
+
+const (
+	//DataPatchTypeMerge a JSON merge patch as described in RFC 7396
+	DataPatchTypeMerge string = "merge"
+	//DataPatchTypeJSON a JSON patch as described in RFC 6902
+	DataPatchTypeJSON string = "json"
+)
+
+// JSONPatchOperation is an operation of a JSON patch (RFC 6902)
+type JSONPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`  // for move and copy
+	Value json.RawMessage `json:"value,omitempty"` // for add, replace and test
+} // @name JSONPatchOperation
+
+// DataUpdate is a partial update of the stored data which changes only the given fields
+type DataUpdate struct {
+	Set   []DataPathValue
+	Unset []string // the full paths of the removed fields
+}
+
+// DataPathValue is the new value of a field
+type DataPathValue struct {
+	Path  string // the full path of the field, for example data.title
+	Value interface{}
+}
+
+const (
+	//ContentItemEventCreated the content item has been created or restored from the trash
+	ContentItemEventCreated string = "created"
+	//ContentItemEventUpdated the content item has been changed
+	ContentItemEventUpdated string = "updated"
+	//ContentItemEventDeleted the content item has been deleted or it is not visible to the clients anymore
+	ContentItemEventDeleted string = "deleted"
+	//ContentItemEventReset the events after the last one which the client has got are not available anymore, the client has to load the items again
+	ContentItemEventReset string = "reset"
+)
+
+// ContentItemEvent is a change of a content item which is pushed to the subscribed clients
+type ContentItemEvent struct {
+	ID       string              `json:"-"` // the position of the change in the change stream or of the scheduled change, the clients pass it back to resume
+	Type     string              `json:"type"`
+	ItemID   string              `json:"id,omitempty"`
+	Category string              `json:"category,omitempty"`
+	Item     ContentItemResponse `json:"item,omitempty"` // the item as the clients get it, it is not given for the deleted items
+
+	Content  *ContentItem `json:"-"` // the stored item after the change, it is used to find the subscribers which get the event. nil when it is not known.
+	Previous *ContentItem `json:"-"` // the stored item before the change, nil when it is not known
+} // @name ContentItemEvent
+
+// ContentItemReference is a place where a content item is referenced by another content item
+type ContentItemReference struct {
+	ID       string `json:"id"` // the referencing item
+	Category string `json:"category"`
+	Path     string `json:"path"` // the field which holds the reference, for example data.location_id
+} // @name ContentItemReference
@@ -0,0 +1,65 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestContentItemsCursor(t *testing.T) {
+	position := int64(3)
+	dateCreated := time.Date(2026, 5, 1, 10, 30, 0, 123000000, time.UTC)
+	tests := []struct {
+		name   string
+		cursor ContentItemsCursor
+	}{
+		{name: "by date", cursor: ContentItemsCursor{DateCreated: dateCreated, ID: "id1"}},
+		{name: "by position", cursor: ContentItemsCursor{DateCreated: dateCreated, ID: "id2", Position: &position}},
+		{name: "pinned", cursor: ContentItemsCursor{DateCreated: dateCreated, ID: "id3", Pinned: true, Position: &position}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeContentItemsCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodeContentItemsCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.cursor) {
+				t.Errorf("DecodeContentItemsCursor() = %+v, want %+v", *got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeContentItemsCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "not base64", token: "***"},
+		{name: "not json", token: "bm90IGpzb24"},
+		{name: "missing id", token: ContentItemsCursor{DateCreated: time.Now().UTC()}.Encode()},
+		{name: "padded", token: ContentItemsCursor{ID: "id1"}.Encode() + "="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeContentItemsCursor(tt.token); err == nil {
+				t.Errorf("DecodeContentItemsCursor(%q) error = nil, want an error", tt.token)
+			}
+		})
+	}
+}
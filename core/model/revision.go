@@ -0,0 +1,74 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+const (
+	//RevisionActionUpdate the content item was updated
+	RevisionActionUpdate string = "update"
+	//RevisionActionDelete the content item was deleted
+	RevisionActionDelete string = "delete"
+	//RevisionActionRestore an older revision was restored as the current version of the content item
+	RevisionActionRestore string = "restore"
+	//RevisionActionImport the content item was replaced by an imported one
+	RevisionActionImport string = "import"
+	//RevisionActionCopy the content item was replaced by a new copy from another app scope
+	RevisionActionCopy string = "copy"
+
+	//RevisionCurrent refers to the current version of the content item when comparing revisions
+	RevisionCurrent string = "current"
+)
+
+// ContentItemRevision keeps a previous version of a content item. Revisions are never changed once created.
+type ContentItemRevision struct {
+	ID            string      `json:"id" bson:"_id"`
+	ContentItemID string      `json:"content_item_id" bson:"content_item_id"`
+	Revision      int64       `json:"revision" bson:"revision"` // sequence number within the content item, starting from 1
+	Action        string      `json:"action" bson:"action"`     // the write which replaced this version
+	Category      string      `json:"category" bson:"category"`
+	Data          interface{} `json:"data" bson:"data"`
+	OrgID         string      `json:"org_id" bson:"org_id"`
+	AppID         *string     `json:"app_id" bson:"app_id"`
+	ChangedBy     string      `json:"changed_by" bson:"changed_by"` // the subject of the user who made the write
+	DateCreated   time.Time   `json:"date_created" bson:"date_created"`
+
+	Locales    map[string]interface{} `json:"locales,omitempty" bson:"locales,omitempty"`         // the data for other locales
+	RawData    interface{}            `json:"raw_data,omitempty" bson:"raw_data,omitempty"`       // the data as it has been written, when the rich text has been sanitized
+	RawLocales map[string]interface{} `json:"raw_locales,omitempty" bson:"raw_locales,omitempty"` // the locale variants as they have been written
+
+	//the publishing state of the version, the item gets it back when it is not there anymore. The older revisions do not have it.
+	Status    string               `json:"status,omitempty" bson:"status,omitempty"`
+	PublishAt *time.Time           `json:"publish_at,omitempty" bson:"publish_at,omitempty"`
+	ExpireAt  *time.Time           `json:"expire_at,omitempty" bson:"expire_at,omitempty"`
+	ExpiresAt *time.Time           `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	Audience  *ContentItemAudience `json:"audience,omitempty" bson:"audience,omitempty"`
+} // @name ContentItemRevision
+
+// DataChange describes a single difference between two versions of the same data
+type DataChange struct {
+	Path string      `json:"path" bson:"path"`
+	Op   string      `json:"op" bson:"op"` // added, removed or changed
+	From interface{} `json:"from,omitempty" bson:"from,omitempty"`
+	To   interface{} `json:"to,omitempty" bson:"to,omitempty"`
+} // @name DataChange
+
+// ContentItemRevisionsDiff contains the differences between two revisions of a content item
+type ContentItemRevisionsDiff struct {
+	ContentItemID string       `json:"content_item_id"`
+	From          string       `json:"from"`
+	To            string       `json:"to"`
+	Changes       []DataChange `json:"changes"`
+} // @name ContentItemRevisionsDiff
@@ -0,0 +1,53 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ContentItemSchema defines the JSON Schema which the data of the content items within a category must match
+type ContentItemSchema struct {
+	ID          string          `json:"id" bson:"_id"`
+	Category    string          `json:"category" bson:"category"`
+	Schema      json.RawMessage `json:"schema" bson:"schema"`
+	OrgID       string          `json:"org_id" bson:"org_id"`
+	AppID       *string         `json:"app_id" bson:"app_id"`
+	DateCreated time.Time       `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time      `json:"date_updated,omitempty" bson:"date_updated,omitempty"`
+} // @name ContentItemSchema
+
+// SchemaFieldError describes a single place where the content data does not match its schema
+type SchemaFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+} // @name SchemaFieldError
+
+// SchemaValidationError is returned when the content data does not match the schema registered for its category
+type SchemaValidationError struct {
+	Category string
+	Errors   []SchemaFieldError
+}
+
+func (e *SchemaValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fieldErr.Field + " " + fieldErr.Message
+	}
+	return fmt.Sprintf("data does not match the schema for category %s: %s", e.Category, strings.Join(messages, "; "))
+}
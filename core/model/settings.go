@@ -0,0 +1,37 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// OrgSettings are the content settings of an organization
+type OrgSettings struct {
+	ID    string `json:"id" bson:"_id"`
+	OrgID string `json:"org_id" bson:"org_id"`
+
+	RequireRegisteredCategories bool           `json:"require_registered_categories" bson:"require_registered_categories"` // the content items could be created only in the registered categories
+	HTMLSanitizer               *HTMLSanitizer `json:"html_sanitizer" bson:"html_sanitizer,omitempty"`                     // the defaults are used when it is nil
+
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated,omitempty" bson:"date_updated,omitempty"`
+} // @name OrgSettings
+
+// HTMLSanitizer defines what the rich text fields of the content items could have. The defaults are used for the lists which are not given.
+type HTMLSanitizer struct {
+	AllowedTags       []string            `json:"allowed_tags" bson:"allowed_tags"`
+	AllowedAttributes map[string][]string `json:"allowed_attributes" bson:"allowed_attributes"`   // by tag, the ones for "*" are allowed for all the tags
+	AllowedURLSchemes []string            `json:"allowed_url_schemes" bson:"allowed_url_schemes"` // the relative urls are always allowed
+	RewriteLinks      bool                `json:"rewrite_links" bson:"rewrite_links"`             // the links which are not for the web are replaced by their text and the pdf links by their text followed by the url
+} // @name HTMLSanitizer
@@ -0,0 +1,89 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+const (
+	//WebhookDeliveryStatusPending the delivery has not been sent yet or it is waiting for a retry
+	WebhookDeliveryStatusPending string = "pending"
+	//WebhookDeliveryStatusSucceeded the receiver has accepted the delivery
+	WebhookDeliveryStatusSucceeded string = "succeeded"
+	//WebhookDeliveryStatusFailed all the attempts have failed, the delivery could be replayed
+	WebhookDeliveryStatusFailed string = "failed"
+)
+
+// Webhook is a subscription of another system for the content changes
+type Webhook struct {
+	ID     string  `json:"id" bson:"_id"`
+	OrgID  string  `json:"org_id" bson:"org_id"`
+	AppID  *string `json:"app_id" bson:"app_id"`
+	URL    string  `json:"url" bson:"url"`
+	Secret string  `json:"-" bson:"secret"` // the key for the HMAC signature of the deliveries, it is given only when it is created or rotated
+
+	Categories    []string `json:"categories" bson:"categories"`         // the changes in all the categories are delivered when it is empty
+	ResourceTypes []string `json:"resource_types" bson:"resource_types"` // content_item, data_content_item or file, all of them when it is empty
+	Active        bool     `json:"active" bson:"active"`
+
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated,omitempty" bson:"date_updated,omitempty"`
+} // @name Webhook
+
+// WebhookWithSecret is a webhook together with its secret, it is given only when the webhook is created or its secret is rotated
+type WebhookWithSecret struct {
+	Webhook
+	Secret string `json:"secret"`
+} // @name WebhookWithSecret
+
+// WebhookEvent is the content change which is sent to the webhooks
+type WebhookEvent struct {
+	ID           string      `json:"id"`    // the same for all the deliveries of the change and their replays
+	Event        string      `json:"event"` // <resource_type>.<action>, for example content_item.update
+	ResourceType string      `json:"resource_type"`
+	ResourceID   string      `json:"resource_id"`
+	Category     string      `json:"category"`
+	OrgID        string      `json:"org_id"`
+	AppID        *string     `json:"app_id"`
+	Actor        string      `json:"actor"`          // the subject of the user who made the change
+	Data         interface{} `json:"data,omitempty"` // the resource after the change, before it for the deleted ones
+	Date         time.Time   `json:"date"`
+} // @name WebhookEvent
+
+// WebhookDelivery is a change which is sent to a webhook
+type WebhookDelivery struct {
+	ID        string  `json:"id" bson:"_id"`
+	WebhookID string  `json:"webhook_id" bson:"webhook_id"`
+	OrgID     string  `json:"org_id" bson:"org_id"`
+	AppID     *string `json:"app_id" bson:"app_id"`
+	Event     string  `json:"event" bson:"event"`
+	Payload   string  `json:"payload" bson:"payload"` // the sent body - the WebhookEvent as JSON
+
+	Status      string                   `json:"status" bson:"status"`             // one of the WebhookDeliveryStatus values
+	NextAttempt *time.Time               `json:"next_attempt" bson:"next_attempt"` // set only for the pending deliveries
+	Attempts    []WebhookDeliveryAttempt `json:"attempts" bson:"attempts"`
+	ReplayOf    *string                  `json:"replay_of,omitempty" bson:"replay_of,omitempty"` // the delivery which has been replayed by this one
+
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated,omitempty" bson:"date_updated,omitempty"`
+} // @name WebhookDelivery
+
+// WebhookDeliveryAttempt is an attempt to send a delivery
+type WebhookDeliveryAttempt struct {
+	Date         time.Time `json:"date" bson:"date"`
+	StatusCode   int       `json:"status_code,omitempty" bson:"status_code,omitempty"` // the response status, it is missing when there is no response
+	Error        string    `json:"error,omitempty" bson:"error,omitempty"`
+	DurationMS   int64     `json:"duration_ms" bson:"duration_ms"`
+	ResponseBody string    `json:"response_body,omitempty" bson:"response_body,omitempty"` // the beginning of the response body
+} // @name WebhookDeliveryAttempt
@@ -0,0 +1,276 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/interfaces"
+	"content/core/model"
+	"sort"
+	"strings"
+)
+
+// The content items reference other content items with string data fields which hold the ids of the referenced items.
+// Such fields are declared in the JSON Schema of the category with "format": "content-item-ref", for example:
+// {"type": "object", "properties": {"location_id": {"type": "string", "format": "content-item-ref"}}}
+// The declaration is valid within arrays too - {"type": "array", "items": {"type": "string", "format": "content-item-ref"}}.
+
+const (
+	// contentItemRefFormat is the JSON Schema format of the data fields which reference content items
+	contentItemRefFormat = "content-item-ref"
+	// maxContentItemsExpandDepth is how many levels of references could be expanded in a single request
+	maxContentItemsExpandDepth = 3
+)
+
+// schemaFormatPaths gives the data paths which are declared with the format in the schema, for example steps.guide_id for the references
+func schemaFormatPaths(schema interface{}, format string, path string) []string {
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var paths []string
+	if value, ok := schemaMap["format"].(string); ok && value == format && len(path) > 0 {
+		paths = append(paths, path)
+	}
+	if properties, ok := schemaMap["properties"].(map[string]interface{}); ok {
+		for name, property := range properties {
+			propertyPath := name
+			if len(path) > 0 {
+				propertyPath = path + "." + name
+			}
+			paths = append(paths, schemaFormatPaths(property, format, propertyPath)...)
+		}
+	}
+	//the array items are on the same path as mongo matches the array elements too
+	paths = append(paths, schemaFormatPaths(schemaMap["items"], format, path)...)
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if list, ok := schemaMap[keyword].([]interface{}); ok {
+			for _, item := range list {
+				paths = append(paths, schemaFormatPaths(item, format, path)...)
+			}
+		}
+	}
+	return paths
+}
+
+// contentSchemaFormatPaths gives the data paths which are declared with the format in the schema of the category
+func (s *servicesImpl) contentSchemaFormatPaths(appID *string, orgID string, category string, format string) ([]string, error) {
+	contentSchema, err := s.findContentItemSchema(appID, orgID, category)
+	if err != nil || contentSchema == nil {
+		return nil, err
+	}
+	schema, err := parseContentSchema(contentSchema.Schema)
+	if err != nil {
+		//the schema is checked when it is stored, so it is just skipped here
+		return nil, nil
+	}
+	return uniqueStrings(schemaFormatPaths(schema, format, "")), nil
+}
+
+// contentItemsReferencePaths gives the declared reference paths of the categories which have a schema.
+// The schema for the exact app is used when there is one, otherwise the one for all the apps in the organization.
+func contentItemsReferencePaths(storage interfaces.Storage, appID *string, orgID string) (map[string][]string, error) {
+	schemas, err := storage.FindContentItemSchemas(appID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if appID != nil {
+		orgSchemas, err := storage.FindContentItemSchemas(nil, orgID)
+		if err != nil {
+			return nil, err
+		}
+		//the app schemas come last, so they override the organization ones
+		schemas = append(orgSchemas, schemas...)
+	}
+
+	result := map[string][]string{}
+	for _, contentSchema := range schemas {
+		schema, err := parseContentSchema(contentSchema.Schema)
+		if err != nil {
+			//the schema is checked when it is stored, so it is just skipped here
+			continue
+		}
+		result[contentSchema.Category] = uniqueStrings(schemaFormatPaths(schema, contentItemRefFormat, ""))
+	}
+	for category, paths := range result {
+		if len(paths) == 0 {
+			delete(result, category)
+		}
+	}
+	return result, nil
+}
+
+// referenceValues visits the values on the path in the data. The arrays on the way are walked through.
+// visit could replace the value by giving a new one.
+func referenceValues(data interface{}, segments []string, visit func(value interface{}) interface{}) interface{} {
+	if list, ok := data.([]interface{}); ok {
+		for i, item := range list {
+			list[i] = referenceValues(item, segments, visit)
+		}
+		return list
+	}
+	if len(segments) == 0 {
+		return visit(data)
+	}
+	fields, ok := data.(map[string]interface{})
+	if !ok {
+		return data
+	}
+	if value, ok := fields[segments[0]]; ok {
+		fields[segments[0]] = referenceValues(value, segments[1:], visit)
+	}
+	return fields
+}
+
+// referenceIDs gives the ids referenced on the path in the data
+func referenceIDs(data interface{}, path string) []string {
+	var ids []string
+	referenceValues(data, strings.Split(path, "."), func(value interface{}) interface{} {
+		if id, ok := value.(string); ok && len(id) > 0 {
+			ids = append(ids, id)
+		}
+		return value
+	})
+	return ids
+}
+
+// expandContentItems puts the referenced items in place of their ids in the data of the items, up to depth levels.
+// The referenced items are looked for within the same app and organization and with the same visibility as the items.
+// The ids of the items which are not found, are in the hidden categories or are not for the viewer are kept as they are.
+func (s *servicesImpl) expandContentItems(appID *string, orgID string, items []model.ContentItemResponse, depth int, publishedOnly bool, locales []string, format string, hidden map[string]bool, viewer *model.ContentItemsViewer) error {
+	if depth <= 0 || len(items) == 0 {
+		return nil
+	}
+	if depth > maxContentItemsExpandDepth {
+		depth = maxContentItemsExpandDepth
+	}
+
+	referencePaths, err := contentItemsReferencePaths(s.app.storage, appID, orgID)
+	if err != nil {
+		return err
+	}
+	if len(referencePaths) == 0 {
+		return nil
+	}
+
+	level := items
+	for i := 0; i < depth && len(level) > 0; i++ {
+		//find all the items referenced on this level at once
+		var ids []string
+		for _, item := range level {
+			category, _ := item["category"].(string)
+			paths := referencePaths[category]
+			if len(paths) == 0 {
+				continue
+			}
+			item["data"] = normalizeJSONValue(item["data"])
+			for _, path := range paths {
+				ids = append(ids, referenceIDs(item["data"], path)...)
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		referencedItems, err := s.app.storage.GetContentItems(appID, orgID, uniqueStrings(ids), nil, nil, nil, nil, nil, publishedOnly, viewer)
+		if err != nil {
+			return err
+		}
+		hideContentItemsAudienceNumbers(referencedItems)
+		if len(hidden) > 0 {
+			//the items which the viewer cannot read stay as ids
+			readableItems := []model.ContentItemResponse{}
+			for _, referencedItem := range referencedItems {
+				if category, _ := referencedItem["category"].(string); !hidden[category] {
+					readableItems = append(readableItems, referencedItem)
+				}
+			}
+			referencedItems = readableItems
+		}
+		s.localizeContentItems(referencedItems, locales, format)
+		referenced := make(map[string]model.ContentItemResponse, len(referencedItems))
+		for _, referencedItem := range referencedItems {
+			if id, ok := referencedItem["_id"].(string); ok {
+				referenced[id] = referencedItem
+			}
+		}
+
+		for _, item := range level {
+			category, _ := item["category"].(string)
+			for _, path := range referencePaths[category] {
+				item["data"] = referenceValues(item["data"], strings.Split(path, "."), func(value interface{}) interface{} {
+					if id, ok := value.(string); ok {
+						if referencedItem, ok := referenced[id]; ok {
+							return referencedItem
+						}
+					}
+					return value
+				})
+			}
+		}
+
+		//the next level is loaded again, so the expanded items never contain themselves
+		level = referencedItems
+	}
+	return nil
+}
+
+func (s *servicesImpl) GetContentItemReferences(allApps bool, appID string, orgID string, id string) ([]model.ContentItemReference, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	referencePaths, err := contentItemsReferencePaths(s.app.storage, appIDParam, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(referencePaths) == 0 {
+		return []model.ContentItemReference{}, nil
+	}
+
+	items, err := s.app.storage.FindContentItemsReferencing(appIDParam, orgID, referencePaths, id)
+	if err != nil {
+		return nil, err
+	}
+
+	references := []model.ContentItemReference{}
+	for _, item := range items {
+		data := normalizeJSONValue(item.Data)
+		for _, path := range referencePaths[item.Category] {
+			for _, referencedID := range referenceIDs(data, path) {
+				if referencedID == id {
+					references = append(references, model.ContentItemReference{ID: item.ID, Category: item.Category, Path: schemaRootField + "." + path})
+					break
+				}
+			}
+		}
+	}
+	return references, nil
+}
+
+func uniqueStrings(list []string) []string {
+	set := make(map[string]bool, len(list))
+	result := []string{}
+	for _, item := range list {
+		if !set[item] {
+			set[item] = true
+			result = append(result, item)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
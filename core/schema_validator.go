@@ -0,0 +1,534 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The validator supports the subset of the JSON Schema keywords which is useful for describing content data:
+// type, enum, const, properties, required, additionalProperties, patternProperties, items, minItems, maxItems,
+// uniqueItems, minLength, maxLength, pattern, format, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// multipleOf, allOf, anyOf, oneOf and not. Unknown keywords are ignored as the specification requires.
+
+const schemaRootField = "data"
+
+var schemaTypes = map[string]bool{"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "integer": true, "string": true}
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// parseContentSchema parses a JSON Schema document and makes sure it is well formed
+func parseContentSchema(raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("schema is required")
+	}
+	var schema interface{}
+	err := json.Unmarshal(raw, &schema)
+	if err != nil {
+		return nil, fmt.Errorf("schema is not a valid JSON: %s", err)
+	}
+	if _, ok := schema.(map[string]interface{}); !ok {
+		return nil, errors.New("schema must be a JSON object")
+	}
+	err = checkSchema(schema, "#")
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// validateContentData validates the content data against the schema registered for its category
+func validateContentData(contentSchema *model.ContentItemSchema, data interface{}) error {
+	schema, err := parseContentSchema(contentSchema.Schema)
+	if err != nil {
+		return fmt.Errorf("invalid schema for category %s: %s", contentSchema.Category, err)
+	}
+
+	fieldErrors := validateSchema(schema, normalizeJSONValue(data), schemaRootField)
+	if len(fieldErrors) > 0 {
+		return &model.SchemaValidationError{Category: contentSchema.Category, Errors: fieldErrors}
+	}
+	return nil
+}
+
+// checkSchema verifies the keywords the validator relies on
+func checkSchema(schema interface{}, path string) error {
+	if _, ok := schema.(bool); ok {
+		return nil
+	}
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be a schema object or a boolean", path)
+	}
+
+	if typeValue, ok := schemaMap["type"]; ok {
+		var types []interface{}
+		switch t := typeValue.(type) {
+		case string:
+			types = []interface{}{t}
+		case []interface{}:
+			types = t
+		default:
+			return fmt.Errorf("%s/type must be a string or an array of strings", path)
+		}
+		for _, item := range types {
+			name, ok := item.(string)
+			if !ok || !schemaTypes[name] {
+				return fmt.Errorf("%s/type has an unknown type %v", path, item)
+			}
+		}
+	}
+	if pattern, ok := schemaMap["pattern"]; ok {
+		patternString, ok := pattern.(string)
+		if !ok {
+			return fmt.Errorf("%s/pattern must be a string", path)
+		}
+		if _, err := regexp.Compile(patternString); err != nil {
+			return fmt.Errorf("%s/pattern is not a valid regular expression: %s", path, err)
+		}
+	}
+	if required, ok := schemaMap["required"]; ok {
+		list, ok := required.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s/required must be an array of strings", path)
+		}
+		for _, item := range list {
+			if _, ok := item.(string); !ok {
+				return fmt.Errorf("%s/required must be an array of strings", path)
+			}
+		}
+	}
+	if enum, ok := schemaMap["enum"]; ok {
+		if _, ok := enum.([]interface{}); !ok {
+			return fmt.Errorf("%s/enum must be an array", path)
+		}
+	}
+	for _, keyword := range []string{"minimum", "maximum", "multipleOf", "minLength", "maxLength", "minItems", "maxItems"} {
+		if value, ok := schemaMap[keyword]; ok {
+			if _, ok := value.(float64); !ok {
+				return fmt.Errorf("%s/%s must be a number", path, keyword)
+			}
+		}
+	}
+	for _, keyword := range []string{"properties", "patternProperties"} {
+		if value, ok := schemaMap[keyword]; ok {
+			properties, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s/%s must be an object", path, keyword)
+			}
+			for name, property := range properties {
+				if keyword == "patternProperties" {
+					if _, err := regexp.Compile(name); err != nil {
+						return fmt.Errorf("%s/%s/%s is not a valid regular expression: %s", path, keyword, name, err)
+					}
+				}
+				if err := checkSchema(property, path+"/"+keyword+"/"+name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, keyword := range []string{"additionalProperties", "items", "not"} {
+		if value, ok := schemaMap[keyword]; ok {
+			if err := checkSchema(value, path+"/"+keyword); err != nil {
+				return err
+			}
+		}
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if value, ok := schemaMap[keyword]; ok {
+			list, ok := value.([]interface{})
+			if !ok || len(list) == 0 {
+				return fmt.Errorf("%s/%s must be a non-empty array of schemas", path, keyword)
+			}
+			for i, item := range list {
+				if err := checkSchema(item, fmt.Sprintf("%s/%s/%d", path, keyword, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateSchema validates a normalized value against a schema and returns the field errors
+func validateSchema(schema interface{}, value interface{}, field string) []model.SchemaFieldError {
+	if allowed, ok := schema.(bool); ok {
+		if allowed {
+			return nil
+		}
+		return []model.SchemaFieldError{{Field: field, Message: "is not allowed"}}
+	}
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if typeValue, ok := schemaMap["type"]; ok {
+		types := schemaTypeNames(typeValue)
+		if !matchesAnyType(types, value) {
+			//no point in checking the other keywords for a value of a wrong type
+			return []model.SchemaFieldError{{Field: field, Message: "must be of type " + strings.Join(types, " or ")}}
+		}
+	}
+
+	var fieldErrors []model.SchemaFieldError
+	addError := func(message string) {
+		fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: message})
+	}
+
+	if enum, ok := schemaMap["enum"].([]interface{}); ok {
+		found := false
+		for _, option := range enum {
+			if schemaValuesEqual(option, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			addError("must be one of " + formatSchemaValues(enum))
+		}
+	}
+	if constValue, ok := schemaMap["const"]; ok && !schemaValuesEqual(constValue, value) {
+		addError("must be equal to " + formatSchemaValues([]interface{}{constValue}))
+	}
+
+	switch v := value.(type) {
+	case string:
+		fieldErrors = append(fieldErrors, validateSchemaString(schemaMap, v, field)...)
+	case float64:
+		fieldErrors = append(fieldErrors, validateSchemaNumber(schemaMap, v, field)...)
+	case []interface{}:
+		fieldErrors = append(fieldErrors, validateSchemaArray(schemaMap, v, field)...)
+	case map[string]interface{}:
+		fieldErrors = append(fieldErrors, validateSchemaObject(schemaMap, v, field)...)
+	}
+
+	if allOf, ok := schemaMap["allOf"].([]interface{}); ok {
+		for _, subSchema := range allOf {
+			fieldErrors = append(fieldErrors, validateSchema(subSchema, value, field)...)
+		}
+	}
+	if anyOf, ok := schemaMap["anyOf"].([]interface{}); ok {
+		if countMatchingSchemas(anyOf, value, field) == 0 {
+			addError("must match at least one of the allowed schemas")
+		}
+	}
+	if oneOf, ok := schemaMap["oneOf"].([]interface{}); ok {
+		if countMatchingSchemas(oneOf, value, field) != 1 {
+			addError("must match exactly one of the allowed schemas")
+		}
+	}
+	if not, ok := schemaMap["not"]; ok {
+		if len(validateSchema(not, value, field)) == 0 {
+			addError("must not match the disallowed schema")
+		}
+	}
+
+	return fieldErrors
+}
+
+func validateSchemaString(schema map[string]interface{}, value string, field string) []model.SchemaFieldError {
+	var fieldErrors []model.SchemaFieldError
+	length := utf8.RuneCountInString(value)
+	if minLength, ok := schema["minLength"].(float64); ok && float64(length) < minLength {
+		fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must be at least %v characters long", minLength)})
+	}
+	if maxLength, ok := schema["maxLength"].(float64); ok && float64(length) > maxLength {
+		fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must be at most %v characters long", maxLength)})
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if regex, err := regexp.Compile(pattern); err == nil && !regex.MatchString(value) {
+			fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: "must match the pattern " + pattern})
+		}
+	}
+	if format, ok := schema["format"].(string); ok && !matchesSchemaFormat(format, value) {
+		fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: "must be a valid " + format})
+	}
+	return fieldErrors
+}
+
+func validateSchemaNumber(schema map[string]interface{}, value float64, field string) []model.SchemaFieldError {
+	var fieldErrors []model.SchemaFieldError
+	exclusiveMinimum, _ := schema["exclusiveMinimum"].(bool)
+	exclusiveMaximum, _ := schema["exclusiveMaximum"].(bool)
+	if minimum, ok := schema["minimum"].(float64); ok {
+		if exclusiveMinimum && value <= minimum {
+			fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must be greater than %v", minimum)})
+		} else if value < minimum {
+			fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must be greater than or equal to %v", minimum)})
+		}
+	}
+	if maximum, ok := schema["maximum"].(float64); ok {
+		if exclusiveMaximum && value >= maximum {
+			fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must be less than %v", maximum)})
+		} else if value > maximum {
+			fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must be less than or equal to %v", maximum)})
+		}
+	}
+	if minimum, ok := schema["exclusiveMinimum"].(float64); ok && value <= minimum {
+		fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must be greater than %v", minimum)})
+	}
+	if maximum, ok := schema["exclusiveMaximum"].(float64); ok && value >= maximum {
+		fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must be less than %v", maximum)})
+	}
+	if multipleOf, ok := schema["multipleOf"].(float64); ok && multipleOf > 0 {
+		quotient := value / multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must be a multiple of %v", multipleOf)})
+		}
+	}
+	return fieldErrors
+}
+
+func validateSchemaArray(schema map[string]interface{}, value []interface{}, field string) []model.SchemaFieldError {
+	var fieldErrors []model.SchemaFieldError
+	if minItems, ok := schema["minItems"].(float64); ok && float64(len(value)) < minItems {
+		fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must contain at least %v items", minItems)})
+	}
+	if maxItems, ok := schema["maxItems"].(float64); ok && float64(len(value)) > maxItems {
+		fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: fmt.Sprintf("must contain at most %v items", maxItems)})
+	}
+	if uniqueItems, ok := schema["uniqueItems"].(bool); ok && uniqueItems {
+	unique:
+		for i := 0; i < len(value); i++ {
+			for j := i + 1; j < len(value); j++ {
+				if schemaValuesEqual(value[i], value[j]) {
+					fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field, Message: "must contain unique items"})
+					break unique
+				}
+			}
+		}
+	}
+	if items, ok := schema["items"]; ok {
+		for i, item := range value {
+			fieldErrors = append(fieldErrors, validateSchema(items, item, fmt.Sprintf("%s[%d]", field, i))...)
+		}
+	}
+	return fieldErrors
+}
+
+func validateSchemaObject(schema map[string]interface{}, value map[string]interface{}, field string) []model.SchemaFieldError {
+	var fieldErrors []model.SchemaFieldError
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			nameString, _ := name.(string)
+			if _, ok := value[nameString]; !ok {
+				fieldErrors = append(fieldErrors, model.SchemaFieldError{Field: field + "." + nameString, Message: "is required"})
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	patternProperties, _ := schema["patternProperties"].(map[string]interface{})
+	additionalProperties, hasAdditionalProperties := schema["additionalProperties"]
+
+	//go through the keys in order so that the errors are stable
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		propertyField := field + "." + key
+		matched := false
+		if propertySchema, ok := properties[key]; ok {
+			matched = true
+			fieldErrors = append(fieldErrors, validateSchema(propertySchema, value[key], propertyField)...)
+		}
+		for pattern, propertySchema := range patternProperties {
+			if regex, err := regexp.Compile(pattern); err == nil && regex.MatchString(key) {
+				matched = true
+				fieldErrors = append(fieldErrors, validateSchema(propertySchema, value[key], propertyField)...)
+			}
+		}
+		if !matched && hasAdditionalProperties {
+			fieldErrors = append(fieldErrors, validateSchema(additionalProperties, value[key], propertyField)...)
+		}
+	}
+	return fieldErrors
+}
+
+func countMatchingSchemas(schemas []interface{}, value interface{}, field string) int {
+	count := 0
+	for _, subSchema := range schemas {
+		if len(validateSchema(subSchema, value, field)) == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func schemaTypeNames(typeValue interface{}) []string {
+	switch t := typeValue.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if name, ok := item.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesAnyType(types []string, value interface{}) bool {
+	for _, name := range types {
+		switch name {
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "integer":
+			if number, ok := value.(float64); ok && number == math.Trunc(number) {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesSchemaFormat(format string, value string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case "email":
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	case "uri":
+		parsed, err := url.Parse(value)
+		return err == nil && parsed.Scheme != ""
+	case "uuid":
+		return uuidRegex.MatchString(value)
+	}
+	//unknown formats are only annotations
+	return true
+}
+
+func schemaValuesEqual(a interface{}, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func formatSchemaValues(values []interface{}) string {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Sprintf("%v", values)
+	}
+	return string(data)
+}
+
+// normalizeJSONValue converts the data as it comes from the request or from the storage
+// to the types the JSON decoder would produce
+func normalizeJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, string, float64:
+		return v
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		number, err := v.Float64()
+		if err != nil {
+			return v.String()
+		}
+		return number
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = normalizeJSONValue(item)
+		}
+		return result
+	case primitive.M:
+		return normalizeJSONValue(map[string]interface{}(v))
+	case primitive.D:
+		result := make(map[string]interface{}, len(v))
+		for _, element := range v {
+			result[element.Key] = normalizeJSONValue(element.Value)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = normalizeJSONValue(item)
+		}
+		return result
+	case primitive.A:
+		return normalizeJSONValue([]interface{}(v))
+	case primitive.DateTime:
+		return v.Time().UTC().Format(time.RFC3339Nano)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+
+	//anything else goes through its JSON representation
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var result interface{}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return value
+	}
+	return result
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseContentSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{name: "object", schema: `{"type": "object", "properties": {"title": {"type": "string"}}}`},
+		{name: "boolean subschema", schema: `{"type": "object", "additionalProperties": false}`},
+		{name: "empty", schema: ``, wantErr: true},
+		{name: "not json", schema: `{"type":`, wantErr: true},
+		{name: "not an object", schema: `["string"]`, wantErr: true},
+		{name: "unknown type", schema: `{"type": "text"}`, wantErr: true},
+		{name: "bad pattern", schema: `{"type": "string", "pattern": "("}`, wantErr: true},
+		{name: "bad required", schema: `{"required": "title"}`, wantErr: true},
+		{name: "bad minimum", schema: `{"minimum": "1"}`, wantErr: true},
+		{name: "empty anyOf", schema: `{"anyOf": []}`, wantErr: true},
+		{name: "bad nested property", schema: `{"properties": {"title": {"type": 1}}}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseContentSchema(json.RawMessage(tt.schema))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseContentSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateContentData(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["title"],
+		"additionalProperties": false,
+		"properties": {
+			"title": {"type": "string", "minLength": 1, "maxLength": 5},
+			"count": {"type": "integer", "minimum": 0, "exclusiveMaximum": 10, "multipleOf": 2},
+			"kind": {"enum": ["news", "event"]},
+			"email": {"type": "string", "format": "email"},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2, "uniqueItems": true},
+			"link": {"anyOf": [{"type": "null"}, {"type": "string", "format": "uri"}]},
+			"id": {"oneOf": [{"type": "string", "format": "uuid"}, {"type": "integer"}]},
+			"code": {"type": "string", "pattern": "^[A-Z]+$", "not": {"const": "NONE"}}
+		}
+	}`
+	tests := []struct {
+		name string
+		data interface{}
+		want []model.SchemaFieldError
+	}{
+		{name: "valid", data: map[string]interface{}{"title": "news", "count": 4, "kind": "news", "email": "a@b.org",
+			"tags": []interface{}{"a", "b"}, "link": nil, "id": 3, "code": "ABC"}},
+		{name: "int64 from the storage", data: map[string]interface{}{"title": "a", "count": int64(2)}},
+		{name: "not an object", data: "news",
+			want: []model.SchemaFieldError{{Field: "data", Message: "must be of type object"}}},
+		{name: "missing required", data: map[string]interface{}{},
+			want: []model.SchemaFieldError{{Field: "data.title", Message: "is required"}}},
+		{name: "additional property", data: map[string]interface{}{"title": "a", "other": 1},
+			want: []model.SchemaFieldError{{Field: "data.other", Message: "is not allowed"}}},
+		{name: "too long", data: map[string]interface{}{"title": "events"},
+			want: []model.SchemaFieldError{{Field: "data.title", Message: "must be at most 5 characters long"}}},
+		{name: "too long runes", data: map[string]interface{}{"title": "ünïcö"}},
+		{name: "not an integer", data: map[string]interface{}{"title": "a", "count": 2.5},
+			want: []model.SchemaFieldError{{Field: "data.count", Message: "must be of type integer"}}},
+		{name: "number limits", data: map[string]interface{}{"title": "a", "count": 11},
+			want: []model.SchemaFieldError{{Field: "data.count", Message: "must be less than 10"},
+				{Field: "data.count", Message: "must be a multiple of 2"}}},
+		{name: "enum", data: map[string]interface{}{"title": "a", "kind": "post"},
+			want: []model.SchemaFieldError{{Field: "data.kind", Message: `must be one of ["news","event"]`}}},
+		{name: "format", data: map[string]interface{}{"title": "a", "email": "Name <a@b.org>"},
+			want: []model.SchemaFieldError{{Field: "data.email", Message: "must be a valid email"}}},
+		{name: "array items", data: map[string]interface{}{"title": "a", "tags": []interface{}{"a", 1, "b"}},
+			want: []model.SchemaFieldError{{Field: "data.tags", Message: "must contain at most 2 items"},
+				{Field: "data.tags[1]", Message: "must be of type string"}}},
+		{name: "unique items", data: map[string]interface{}{"title": "a", "tags": []interface{}{"a", "a"}},
+			want: []model.SchemaFieldError{{Field: "data.tags", Message: "must contain unique items"}}},
+		{name: "anyOf", data: map[string]interface{}{"title": "a", "link": "example"},
+			want: []model.SchemaFieldError{{Field: "data.link", Message: "must match at least one of the allowed schemas"}}},
+		{name: "oneOf", data: map[string]interface{}{"title": "a", "id": "x"},
+			want: []model.SchemaFieldError{{Field: "data.id", Message: "must match exactly one of the allowed schemas"}}},
+		{name: "pattern and not", data: map[string]interface{}{"title": "a", "code": "NONE"},
+			want: []model.SchemaFieldError{{Field: "data.code", Message: "must not match the disallowed schema"}}},
+	}
+	contentSchema := &model.ContentItemSchema{Category: "news", Schema: json.RawMessage(schema)}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateContentData(contentSchema, tt.data)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("validateContentData() error = %v, want nil", err)
+				}
+				return
+			}
+			var validationErr *model.SchemaValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("validateContentData() error = %v, want a schema validation error", err)
+			}
+			if !reflect.DeepEqual(validationErr.Errors, tt.want) {
+				t.Errorf("validateContentData() errors = %v, want %v", validationErr.Errors, tt.want)
+			}
+		})
+	}
+}
@@ -16,7 +16,9 @@ package core
 
 import (
 	"bytes"
+	"content/core/interfaces"
 	"content/core/model"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"image"
@@ -24,6 +26,7 @@ import (
 	_ "image/jpeg" // Allow image.Decode to detect JPEGs
 	_ "image/png"  // Allow image.Decode to detect PNGs
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,6 +40,11 @@ import (
 	"github.com/kolesa-team/go-webp/webp"
 )
 
+const defaultContentItemsPageLimit int64 = 50
+
+// maxContentItemsBatchOperations is the max count of the operations within a content items batch
+const maxContentItemsBatchOperations int = 500
+
 func (s *servicesImpl) GetVersion() string {
 	return s.app.version
 }
@@ -48,201 +56,1771 @@ func (s *servicesImpl) GetStudentGuides(appID string, orgID string, ids []string
 	if err != nil {
 		return nil, err
 	}
-	return items, nil
-}
+	return items, nil
+}
+
+func (s *servicesImpl) GetStudentGuide(appID string, orgID string, id string) (bson.M, error) {
+	item, err := s.app.storage.GetStudentGuide(appID, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *servicesImpl) CreateStudentGuide(appID string, orgID string, item bson.M) (bson.M, error) {
+	items, err := s.app.storage.CreateStudentGuide(appID, orgID, item)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *servicesImpl) UpdateStudentGuide(appID string, orgID string, id string, item bson.M) (bson.M, error) {
+	items, err := s.app.storage.UpdateStudentGuide(appID, orgID, id, item)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *servicesImpl) DeleteStudentGuide(appID string, orgID string, id string) error {
+	err := s.app.storage.DeleteStudentGuide(appID, orgID, id)
+	return err
+}
+
+// Health Locations
+
+func (s *servicesImpl) GetHealthLocations(appID string, orgID string, ids []string) ([]bson.M, error) {
+	items, err := s.app.storage.GetHealthLocations(appID, orgID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *servicesImpl) GetHealthLocation(appID string, orgID string, id string) (bson.M, error) {
+	item, err := s.app.storage.GetHealthLocation(appID, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *servicesImpl) CreateHealthLocation(appID string, orgID string, item bson.M) (bson.M, error) {
+	items, err := s.app.storage.CreateHealthLocation(appID, orgID, item)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *servicesImpl) UpdateHealthLocation(appID string, orgID string, id string, item bson.M) (bson.M, error) {
+	items, err := s.app.storage.UpdateHealthLocation(appID, orgID, id, item)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *servicesImpl) DeleteHealthLocation(appID string, orgID string, id string) error {
+	err := s.app.storage.DeleteHealthLocation(appID, orgID, id)
+	return err
+}
+
+// Content Items
+
+func (s *servicesImpl) GetContentItemsCategories(allApps bool, appID string, orgID string, viewer *model.ContentItemsViewer) ([]string, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	categories, err := s.app.storage.GetContentItemsCategories(appIDParam, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	//the categories which the viewer cannot read are left out
+	hidden, err := s.hiddenContentItemsCategories(viewer, appIDParam, orgID)
+	if err != nil {
+		return nil, err
+	}
+	readable := []string{}
+	for _, category := range categories {
+		if !hidden[category] {
+			readable = append(readable, category)
+		}
+	}
+	return readable, nil
+}
+
+func (s *servicesImpl) GetContentItems(allApps bool, appID string, orgID string, ids []string, categoryList []string, dataQuery *model.ContentItemsDataQuery, offset *int64, limit *int64, order *string, publishedOnly bool, locales []string, format string, expand int, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	//the client reads are served from the cache until the content which they depend on is changed
+	var cacheKey string
+	if viewer != nil {
+		cacheKey = s.contentItemsCacheKey(appIDParam, orgID, ids, categoryList, expand, "items", ids, categoryList, dataQuery, offset, limit, order, publishedOnly, locales, format, viewer)
+		if cached, ok := s.cachedContent(cacheKey).([]model.ContentItemResponse); ok {
+			return cached, nil
+		}
+	}
+
+	//the categories which the viewer cannot read are left out
+	hidden, err := s.hiddenContentItemsCategories(viewer, appIDParam, orgID)
+	if err != nil {
+		return nil, err
+	}
+	categoryList, readable, err := s.readableContentItemsCategories(appIDParam, orgID, categoryList, hidden)
+	if err != nil {
+		return nil, err
+	}
+	if !readable {
+		return []model.ContentItemResponse{}, nil
+	}
+
+	dataQuery = s.localizedDataQuery(dataQuery, locales)
+	items, err := s.app.storage.GetContentItems(appIDParam, orgID, ids, categoryList, dataQuery, offset, limit, order, publishedOnly, viewer)
+	if err != nil {
+		return nil, err
+	}
+	hideContentItemsAudienceNumbers(items)
+	s.localizeContentItems(items, locales, format)
+	err = s.expandContentItems(appIDParam, orgID, items, expand, publishedOnly, locales, format, hidden, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if viewer != nil {
+		s.cacheContentItems(cacheKey, appIDParam, orgID, ids, categoryList, expand, items)
+	}
+	return items, nil
+}
+
+func (s *servicesImpl) GetContentItem(allApps bool, appID string, orgID string, id string, publishedOnly bool, locales []string, format string, expand int, viewer *model.ContentItemsViewer) (*model.ContentItemResponse, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	//the client reads are cached
+	var cacheKey string
+	if viewer != nil {
+		cacheKey = s.contentItemsCacheKey(appIDParam, orgID, []string{id}, nil, expand, "item", id, publishedOnly, locales, format, viewer)
+		if cached, ok := s.cachedContent(cacheKey).(*model.ContentItemResponse); ok {
+			return cached, nil
+		}
+	}
+
+	item, err := s.app.storage.GetContentItem(appIDParam, orgID, id, publishedOnly, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if item != nil {
+		hideContentItemsAudienceNumbers([]model.ContentItemResponse{*item})
+
+		//the viewer gets nothing when it cannot read the category of the item
+		hidden, err := s.hiddenContentItemsCategories(viewer, appIDParam, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if category, _ := (*item)["category"].(string); hidden[category] {
+			return nil, nil
+		}
+
+		s.localizeContentItems([]model.ContentItemResponse{*item}, locales, format)
+		err = s.expandContentItems(appIDParam, orgID, []model.ContentItemResponse{*item}, expand, publishedOnly, locales, format, hidden, viewer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if viewer != nil && item != nil {
+		s.cacheContentItems(cacheKey, appIDParam, orgID, []string{id}, nil, expand, item)
+	}
+	return item, nil
+}
+
+func (s *servicesImpl) GetContentItemsPage(allApps bool, appID string, orgID string, ids []string, categoryList []string, dataQuery *model.ContentItemsDataQuery, cursor *model.ContentItemsCursor, limit *int64, order *string, publishedOnly bool, locales []string, format string, expand int, viewer *model.ContentItemsViewer) (*model.ContentItemsPage, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	pageLimit := defaultContentItemsPageLimit
+	if limit != nil && *limit > 0 {
+		pageLimit = *limit
+	}
+
+	//the client reads are cached
+	var cacheKey string
+	if viewer != nil {
+		cacheKey = s.contentItemsCacheKey(appIDParam, orgID, ids, categoryList, expand, "page", ids, categoryList, dataQuery, cursor, pageLimit, order, publishedOnly, locales, format, viewer)
+		if cached, ok := s.cachedContent(cacheKey).(*model.ContentItemsPage); ok {
+			return cached, nil
+		}
+	}
+
+	//the categories which the viewer cannot read are left out
+	hidden, err := s.hiddenContentItemsCategories(viewer, appIDParam, orgID)
+	if err != nil {
+		return nil, err
+	}
+	categoryList, readable, err := s.readableContentItemsCategories(appIDParam, orgID, categoryList, hidden)
+	if err != nil {
+		return nil, err
+	}
+	if !readable {
+		return &model.ContentItemsPage{Items: []model.ContentItemResponse{}}, nil
+	}
+
+	dataQuery = s.localizedDataQuery(dataQuery, locales)
+	items, next, total, err := s.app.storage.GetContentItemsPage(appIDParam, orgID, ids, categoryList, dataQuery, cursor, pageLimit, order, publishedOnly, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.ContentItemResponse{}
+	}
+	hideContentItemsAudienceNumbers(items)
+	s.localizeContentItems(items, locales, format)
+	err = s.expandContentItems(appIDParam, orgID, items, expand, publishedOnly, locales, format, hidden, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	page := model.ContentItemsPage{Items: items, Total: total}
+	if next != nil {
+		nextCursor := next.Encode()
+		page.NextCursor = &nextCursor
+	}
+	if viewer != nil {
+		s.cacheContentItems(cacheKey, appIDParam, orgID, ids, categoryList, expand, &page)
+	}
+	return &page, nil
+}
+
+func (s *servicesImpl) SearchContentItems(allApps bool, appID string, orgID string, text string, categoryList []string, offset *int64, limit *int64, publishedOnly bool, locales []string, format string, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	//the client reads are cached
+	var cacheKey string
+	if viewer != nil {
+		cacheKey = s.contentItemsCacheKey(appIDParam, orgID, nil, categoryList, 0, "search", text, categoryList, offset, limit, publishedOnly, locales, format, viewer)
+		if cached, ok := s.cachedContent(cacheKey).([]model.ContentItemResponse); ok {
+			return cached, nil
+		}
+	}
+
+	//the categories which the viewer cannot read are left out
+	hidden, err := s.hiddenContentItemsCategories(viewer, appIDParam, orgID)
+	if err != nil {
+		return nil, err
+	}
+	categoryList, readable, err := s.readableContentItemsCategories(appIDParam, orgID, categoryList, hidden)
+	if err != nil {
+		return nil, err
+	}
+	if !readable {
+		return []model.ContentItemResponse{}, nil
+	}
+
+	items, err := s.app.storage.SearchContentItems(appIDParam, orgID, text, categoryList, offset, limit, publishedOnly, viewer)
+	if err != nil {
+		return nil, err
+	}
+	hideContentItemsAudienceNumbers(items)
+	s.localizeContentItems(items, locales, format)
+	if viewer != nil {
+		s.cacheContentItems(cacheKey, appIDParam, orgID, nil, categoryList, 0, items)
+	}
+	return items, nil
+}
+
+func (s *servicesImpl) SubscribeContentItems(allApps bool, appID string, orgID string, categoryList []string, lastEventID string, locales []string, format string, viewer *model.ContentItemsViewer) (<-chan model.ContentItemEvent, func(), error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	hidden, err := s.hiddenContentItemsCategories(viewer, appIDParam, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subscriber := &contentItemsSubscriber{orgID: orgID, appID: appIDParam, categories: map[string]bool{}, hidden: hidden, viewer: viewer,
+		events: make(chan model.ContentItemEvent, contentItemsStreamBuffer)}
+	for _, category := range categoryList {
+		subscriber.categories[category] = true
+	}
+	if locales != nil {
+		subscriber.locales = localeChain(locales, s.app.localeFallback)
+		subscriber.format = format
+	}
+
+	s.app.contentItemsStream.subscribe(subscriber, lastEventID)
+	return subscriber.events, func() { s.app.contentItemsStream.unsubscribe(subscriber) }, nil
+}
+
+// localizeContentItems gives the items in the requested locales and format. Nothing is changed when locales is nil - the admins get the items as they are.
+func (s *servicesImpl) localizeContentItems(items []model.ContentItemResponse, locales []string, format string) {
+	if locales == nil {
+		return
+	}
+	chain := localeChain(locales, s.app.localeFallback)
+	for _, item := range items {
+		localizeContentItem(item, chain, format)
+	}
+}
+
+// localizeDataContentItems gives the items in the requested locales. Nothing is changed when locales is nil - the admins get the items as they are.
+func (s *servicesImpl) localizeDataContentItems(items []*model.DataContentItem, locales []string) {
+	if locales == nil {
+		return
+	}
+	chain := localeChain(locales, s.app.localeFallback)
+	for _, item := range items {
+		localizeDataContentItem(item, chain)
+	}
+}
+
+func (s *servicesImpl) CreateContentItem(claims *tokenauth.Claims, allApps bool, item model.ContentItem) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	err := s.prepareContentItem(appIDParam, claims.OrgID, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	item.ID = uuid.NewString()
+	item.DateCreated = time.Now().UTC()
+	item.DateUpdated = nil
+	item.OrgID = claims.OrgID
+	item.AppID = appIDParam
+	item.Version = 1
+
+	var createdItem *model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		createdItem, err = storage.CreateContentItem(item)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionCreate, model.AuditResourceContentItem, createdItem.ID, nil, createdItem)
+	}
+
+	err = s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return createdItem, nil
+}
+
+// prepareContentItem checks a content item which is about to be created and sets its defaults
+func (s *servicesImpl) prepareContentItem(appID *string, orgID string, item *model.ContentItem) error {
+	//the items go live right away unless anything else is requested
+	if len(item.Status) == 0 {
+		item.Status = model.ContentItemStatusPublished
+	}
+	err := validateContentItemStatus(item.Status, item.PublishAt, item.ExpireAt)
+	if err != nil {
+		return err
+	}
+	err = validateExpiresAt(item.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	err = s.checkContentItemCategory(appID, orgID, item.Category)
+	if err != nil {
+		return err
+	}
+	err = prepareContentItemAudience(item.Audience)
+	if err != nil {
+		return err
+	}
+	err = validateContentItemPin(item.Pinned, item.PinnedUntil)
+	if err != nil {
+		return err
+	}
+
+	//validate the data and its locale variants
+	err = s.validateContentItemData(appID, orgID, item.Category, item.Data)
+	if err != nil {
+		return err
+	}
+	item.Locales, err = normalizeLocales(item.Locales)
+	if err != nil {
+		return err
+	}
+	for _, data := range item.Locales {
+		err = s.validateContentItemData(appID, orgID, item.Category, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	//the rich text is sanitized, it is kept as it is written too
+	err = s.sanitizeContentItem(appID, orgID, item)
+	if err != nil {
+		return err
+	}
+	return s.renderContentItem(appID, orgID, item)
+}
+
+func (s *servicesImpl) UpdateContentItem(claims *tokenauth.Claims, allApps bool, id string, category string, data interface{}, version *int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	//validate the data
+	err := s.validateContentItemData(appIDParam, claims.OrgID, category, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		item, err = s.updateContentItem(storage, claims, appIDParam, id, category, data, version)
+		return err
+	}
+
+	err = s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *servicesImpl) PatchContentItemData(claims *tokenauth.Claims, allApps bool, id string, category string, patchType string, patch json.RawMessage, version *int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+	var categoryList []string
+	if len(category) > 0 {
+		categoryList = []string{category}
+	}
+
+	var item *model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		//find the current version
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, categoryList, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(items) != 1 {
+			return fmt.Errorf("content item with id: %s is not found", id)
+		}
+		err = checkVersion("content item", id, version, items[0].Version)
+		if err != nil {
+			return err
+		}
+
+		//apply the patch and validate the result, the patch is applied on the raw data when the rich text has been sanitized
+		data := normalizeJSONValue(items[0].Data)
+		rawData := normalizeJSONValue(items[0].RawData)
+		patched := data
+		if rawData != nil {
+			patched = rawData
+		}
+		patchedData, err := applyDataPatch(patched, patchType, patch)
+		if err != nil {
+			return err
+		}
+		err = s.validateContentItemData(appIDParam, claims.OrgID, items[0].Category, patchedData)
+		if err != nil {
+			return err
+		}
+		sanitizedData, patchedRawData, err := s.sanitizeContentItemData(appIDParam, claims.OrgID, items[0].Category, patchedData)
+		if err != nil {
+			return err
+		}
+		rendered := model.ContentItem{Category: items[0].Category, Data: sanitizedData, Locales: items[0].Locales}
+		err = s.renderContentItem(appIDParam, claims.OrgID, &rendered)
+		if err != nil {
+			return err
+		}
+
+		//write only the changed fields
+		var dataUpdate model.DataUpdate
+		collectDataUpdate(data, sanitizedData, "data", &dataUpdate)
+		if rawData != nil || patchedRawData != nil {
+			collectDataUpdate(rawData, patchedRawData, "raw_data", &dataUpdate)
+		}
+		if items[0].Renderings != nil || rendered.Renderings != nil {
+			collectDataUpdate(normalizeJSONValue(items[0].Renderings), normalizeJSONValue(rendered.Renderings), "renderings", &dataUpdate)
+		}
+		if len(dataUpdate.Set) == 0 && len(dataUpdate.Unset) == 0 {
+			item = &items[0]
+			return nil
+		}
+
+		//keep it as a revision
+		err = s.createContentItemRevision(storage, items[0], claims.Subject, model.RevisionActionUpdate)
+		if err != nil {
+			return err
+		}
+
+		item, err = storage.UpdateContentItemDataFields(appIDParam, claims.OrgID, id, dataUpdate)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionUpdate, model.AuditResourceContentItem, id, items[0], item)
+	}
+
+	err := s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// updateContentItem replaces the category and the data of a content item within a transaction, the data must be already validated
+func (s *servicesImpl) updateContentItem(storage interfaces.Storage, claims *tokenauth.Claims, appID *string, id string, category string, data interface{}, version *int64) (*model.ContentItem, error) {
+	//find the current version
+	items, err := storage.FindContentItems(appID, claims.OrgID, []string{id}, nil, nil, nil, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) != 1 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+	err = checkVersion("content item", id, version, items[0].Version)
+	if err != nil {
+		return nil, err
+	}
+	if category != items[0].Category {
+		err = s.checkContentItemCategory(appID, claims.OrgID, category)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//keep it as a revision
+	err = s.createContentItemRevision(storage, items[0], claims.Subject, model.RevisionActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	//the locale variants must fit the schema of the new category too
+	if category != items[0].Category && len(items[0].Locales) > 0 {
+		return s.moveContentItem(storage, claims, appID, items[0], category, data)
+	}
+
+	//update with the sanitized rich text and the rendered markdown
+	data, rawData, err := s.sanitizeContentItemData(appID, claims.OrgID, category, data)
+	if err != nil {
+		return nil, err
+	}
+	rendered := model.ContentItem{Category: category, Data: data, Locales: items[0].Locales}
+	err = s.renderContentItem(appID, claims.OrgID, &rendered)
+	if err != nil {
+		return nil, err
+	}
+	item, err := storage.UpdateContentItem(appID, claims.OrgID, id, category, data, rawData, rendered.Renderings)
+	if err != nil {
+		return nil, err
+	}
+	err = s.recordChange(storage, claims, appID, model.AuditActionUpdate, model.AuditResourceContentItem, id, items[0], item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// moveContentItem updates the data of the item which is moved to another category. Its locale variants are validated against the schema of the new category
+// and sanitized again from the raw ones, as the rich text fields of the new category could be other ones.
+func (s *servicesImpl) moveContentItem(storage interfaces.Storage, claims *tokenauth.Claims, appID *string, current model.ContentItem, category string, data interface{}) (*model.ContentItem, error) {
+	item := current
+	item.Locales = make(map[string]interface{}, len(current.Locales))
+	for locale, localeData := range current.Locales {
+		item.Locales[locale] = localeData
+	}
+	useRawContentItemData(&item)
+	item.Category = category
+	item.Data = data
+
+	err := s.validateContentItemVariants(appID, claims.OrgID, category, data, item.Locales)
+	if err != nil {
+		return nil, err
+	}
+	err = s.sanitizeContentItem(appID, claims.OrgID, &item)
+	if err != nil {
+		return nil, err
+	}
+	err = s.renderContentItem(appID, claims.OrgID, &item)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	item.DateUpdated = &now
+	item.Version++
+
+	err = storage.SaveContentItem(item)
+	if err != nil {
+		return nil, err
+	}
+	err = s.recordChange(storage, claims, appID, model.AuditActionUpdate, model.AuditResourceContentItem, item.ID, current, item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (s *servicesImpl) UpdateContentItemData(claims *tokenauth.Claims, allApps bool, id string, category string, data interface{}, version *int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	//validate the data
+	err := s.validateContentItemData(appIDParam, claims.OrgID, category, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var item model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		//find the item
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, []string{category}, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(items) != 1 {
+			return errors.New("not found")
+		}
+		item = items[0]
+		err = checkVersion("content item", id, version, item.Version)
+		if err != nil {
+			return err
+		}
+
+		//keep the current version as a revision
+		err = s.createContentItemRevision(storage, item, claims.Subject, model.RevisionActionUpdate)
+		if err != nil {
+			return err
+		}
+
+		//update the data with the sanitized rich text
+		before := auditSnapshot(item)
+		item.Data, item.RawData, err = s.sanitizeContentItemData(appIDParam, claims.OrgID, category, data)
+		if err != nil {
+			return err
+		}
+		err = s.renderContentItem(appIDParam, claims.OrgID, &item)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		item.DateUpdated = &now
+		item.Version++
+
+		//save it
+		err = storage.SaveContentItem(item)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionUpdate, model.AuditResourceContentItem, id, before, item)
+	}
+
+	err = s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (s *servicesImpl) UpdateContentItemStatus(claims *tokenauth.Claims, allApps bool, id string, status string, publishAt *time.Time, expireAt *time.Time, version *int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	err := validateContentItemStatus(status, publishAt, expireAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		//find the item to check its version and to keep the change in the audit log
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, nil, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(items) != 1 {
+			return fmt.Errorf("content item with id: %s is not found", id)
+		}
+		err = checkVersion("content item", id, version, items[0].Version)
+		if err != nil {
+			return err
+		}
+
+		item, err = storage.UpdateContentItemStatus(appIDParam, claims.OrgID, id, status, publishAt, expireAt)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionUpdate, model.AuditResourceContentItem, id, items[0], item)
+	}
+
+	err = s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *servicesImpl) UpdateContentItemAudience(claims *tokenauth.Claims, allApps bool, id string, audience *model.ContentItemAudience, version *int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	err := prepareContentItemAudience(audience)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		//find the item to check its version and to keep the change in the audit log
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, nil, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(items) != 1 {
+			return fmt.Errorf("content item with id: %s is not found", id)
+		}
+		err = checkVersion("content item", id, version, items[0].Version)
+		if err != nil {
+			return err
+		}
+
+		item, err = storage.UpdateContentItemAudience(appIDParam, claims.OrgID, id, audience)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionUpdate, model.AuditResourceContentItem, id, items[0], item)
+	}
+
+	err = s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *servicesImpl) UpdateContentItemExpiry(claims *tokenauth.Claims, allApps bool, id string, expiresAt *time.Time, version *int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	err := validateExpiresAt(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		//find the item to check its version and to keep the change in the audit log
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, nil, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(items) != 1 {
+			return fmt.Errorf("content item with id: %s is not found", id)
+		}
+		err = checkVersion("content item", id, version, items[0].Version)
+		if err != nil {
+			return err
+		}
+
+		item, err = storage.UpdateContentItemExpiry(appIDParam, claims.OrgID, id, expiresAt)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionUpdate, model.AuditResourceContentItem, id, items[0], item)
+	}
+
+	err = s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *servicesImpl) UpdateContentItemPin(claims *tokenauth.Claims, allApps bool, id string, pinned bool, pinnedUntil *time.Time, version *int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	err := validateContentItemPin(pinned, pinnedUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		//find the item to check its version and to keep the change in the audit log
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, nil, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(items) != 1 {
+			return fmt.Errorf("content item with id: %s is not found", id)
+		}
+		err = checkVersion("content item", id, version, items[0].Version)
+		if err != nil {
+			return err
+		}
+
+		item, err = storage.UpdateContentItemPin(appIDParam, claims.OrgID, id, pinned, pinnedUntil)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionUpdate, model.AuditResourceContentItem, id, items[0], item)
+	}
+
+	err = s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *servicesImpl) ReorderContentItems(claims *tokenauth.Claims, allApps bool, category string, ids []string) ([]model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	if len(category) == 0 {
+		return nil, errors.New("missing category")
+	}
+	positions := map[string]int64{}
+	for i, id := range ids {
+		if _, ok := positions[id]; ok {
+			return nil, fmt.Errorf("content item with id: %s is given more than once", id)
+		}
+		positions[id] = int64(i + 1)
+	}
+
+	var reordered []model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, nil, []string{category}, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		itemsByID := make(map[string]model.ContentItem, len(items))
+		for _, item := range items {
+			itemsByID[item.ID] = item
+		}
+		for _, id := range ids {
+			if _, ok := itemsByID[id]; !ok {
+				return fmt.Errorf("content item with id: %s is not found in category %s", id, category)
+			}
+		}
+
+		//only the items whose position changes are updated
+		now := time.Now().UTC()
+		for _, item := range items {
+			var position *int64
+			if value, ok := positions[item.ID]; ok {
+				position = &value
+			}
+			if (position == nil && item.Position == nil) || (position != nil && item.Position != nil && *position == *item.Position) {
+				continue
+			}
+
+			err = storage.UpdateContentItemPosition(appIDParam, claims.OrgID, item.ID, position)
+			if err != nil {
+				return err
+			}
+			updated := item
+			updated.Position = position
+			updated.DateUpdated = &now
+			updated.Version = item.Version + 1
+			err = s.recordChange(storage, claims, appIDParam, model.AuditActionUpdate, model.AuditResourceContentItem, item.ID, item, updated)
+			if err != nil {
+				return err
+			}
+			itemsByID[item.ID] = updated
+		}
+
+		reordered = make([]model.ContentItem, len(ids))
+		for i, id := range ids {
+			reordered[i] = itemsByID[id]
+		}
+		return nil
+	}
+
+	err := s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return reordered, nil
+}
+
+func (s *servicesImpl) DeleteContentItem(claims *tokenauth.Claims, allApps bool, id string, version *int64) error {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	transaction := func(storage interfaces.Storage) error {
+		return s.deleteContentItem(storage, claims, appIDParam, id, version)
+	}
+
+	return s.performTransaction(transaction)
+}
+
+// deleteContentItem deletes a content item within a transaction
+func (s *servicesImpl) deleteContentItem(storage interfaces.Storage, claims *tokenauth.Claims, appID *string, id string, version *int64) error {
+	//find the item
+	items, err := storage.FindContentItems(appID, claims.OrgID, []string{id}, nil, nil, nil, nil, false)
+	if err != nil {
+		return err
+	}
+	if len(items) != 1 {
+		return fmt.Errorf("content item with id: %s is not found", id)
+	}
+	err = checkVersion("content item", id, version, items[0].Version)
+	if err != nil {
+		return err
+	}
+
+	//keep the last version as a revision so that the item could be restored
+	err = s.createContentItemRevision(storage, items[0], claims.Subject, model.RevisionActionDelete)
+	if err != nil {
+		return err
+	}
+
+	//delete it
+	err = storage.DeleteContentItem(appID, claims.OrgID, id)
+	if err != nil {
+		return err
+	}
+	return s.recordChange(storage, claims, appID, model.AuditActionDelete, model.AuditResourceContentItem, id, items[0], nil)
+}
+
+func (s *servicesImpl) DeleteContentItemByCategory(claims *tokenauth.Claims, allApps bool, id string, category string, version *int64) error {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	transaction := func(storage interfaces.Storage) error {
+		//find the item
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, []string{category}, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(items) != 1 {
+			return errors.New("not found")
+		}
+		err = checkVersion("content item", id, version, items[0].Version)
+		if err != nil {
+			return err
+		}
+
+		//keep the last version as a revision so that the item could be restored
+		err = s.createContentItemRevision(storage, items[0], claims.Subject, model.RevisionActionDelete)
+		if err != nil {
+			return err
+		}
+
+		//delete it
+		err = storage.DeleteContentItem(appIDParam, claims.OrgID, id)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionDelete, model.AuditResourceContentItem, id, items[0], nil)
+	}
+
+	return s.performTransaction(transaction)
+}
+
+func (s *servicesImpl) GetDeletedContentItems(allApps bool, appID string, orgID string, categoryList []string) ([]model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+	return s.app.storage.FindDeletedContentItems(appIDParam, orgID, categoryList)
+}
+
+func (s *servicesImpl) RestoreContentItem(claims *tokenauth.Claims, allApps bool, id string) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	var item *model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		var err error
+		item, err = storage.RestoreContentItem(appIDParam, claims.OrgID, id)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionRestore, model.AuditResourceContentItem, id, nil, item)
+	}
+
+	err := s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *servicesImpl) UpdateContentItemLocale(claims *tokenauth.Claims, allApps bool, id string, locale string, data interface{}, version *int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	locale = normalizeLocale(locale)
+	if !localePattern.MatchString(locale) {
+		return nil, fmt.Errorf("invalid locale %s", locale)
+	}
+
+	var item model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		//find the item
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, nil, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(items) != 1 {
+			return fmt.Errorf("content item with id: %s is not found", id)
+		}
+		item = items[0]
+		err = checkVersion("content item", id, version, item.Version)
+		if err != nil {
+			return err
+		}
+
+		//the variant must match the schema of the category as the default data does
+		err = s.validateContentItemData(appIDParam, claims.OrgID, item.Category, data)
+		if err != nil {
+			return err
+		}
+
+		//keep the current version as a revision
+		err = s.createContentItemRevision(storage, item, claims.Subject, model.RevisionActionUpdate)
+		if err != nil {
+			return err
+		}
+
+		//set the variant with the sanitized rich text
+		sanitizedData, rawData, err := s.sanitizeContentItemData(appIDParam, claims.OrgID, item.Category, data)
+		if err != nil {
+			return err
+		}
+		before := auditSnapshot(item)
+		if item.Locales == nil {
+			item.Locales = map[string]interface{}{}
+		}
+		item.Locales[locale] = sanitizedData
+		if rawData != nil {
+			if item.RawLocales == nil {
+				item.RawLocales = map[string]interface{}{}
+			}
+			item.RawLocales[locale] = rawData
+		} else {
+			delete(item.RawLocales, locale)
+		}
+		err = s.renderContentItem(appIDParam, claims.OrgID, &item)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		item.DateUpdated = &now
+		item.Version++
+
+		err = storage.SaveContentItem(item)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionUpdate, model.AuditResourceContentItem, id, before, item)
+	}
+
+	err := s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (s *servicesImpl) DeleteContentItemLocale(claims *tokenauth.Claims, allApps bool, id string, locale string, version *int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	locale = normalizeLocale(locale)
+
+	var item model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		//find the item
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, nil, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(items) != 1 {
+			return fmt.Errorf("content item with id: %s is not found", id)
+		}
+		item = items[0]
+		err = checkVersion("content item", id, version, item.Version)
+		if err != nil {
+			return err
+		}
+		if _, ok := item.Locales[locale]; !ok {
+			return fmt.Errorf("content item with id: %s does not have locale %s", id, locale)
+		}
+
+		//keep the current version as a revision
+		err = s.createContentItemRevision(storage, item, claims.Subject, model.RevisionActionUpdate)
+		if err != nil {
+			return err
+		}
+
+		//remove the variant
+		before := auditSnapshot(item)
+		delete(item.Locales, locale)
+		delete(item.RawLocales, locale)
+		err = s.renderContentItem(appIDParam, claims.OrgID, &item)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		item.DateUpdated = &now
+		item.Version++
+
+		err = storage.SaveContentItem(item)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionUpdate, model.AuditResourceContentItem, id, before, item)
+	}
+
+	err := s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (s *servicesImpl) GetContentItemsMissingTranslations(allApps bool, appID string, orgID string, categoryList []string, locales []string) ([]model.MissingTranslation, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	locales, err := normalizeReportLocales(locales)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.app.storage.FindContentItemsMissingLocales(appIDParam, orgID, categoryList, locales)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].MissingLocales = missingLocales(items[i].Locales, locales)
+	}
+	return items, nil
+}
+
+func (s *servicesImpl) BatchContentItems(claims *tokenauth.Claims, allApps bool, operations []model.ContentItemsBatchOperation) (*model.ContentItemsBatchResult, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	if len(operations) == 0 {
+		return nil, errors.New("missing operations")
+	}
+	if len(operations) > maxContentItemsBatchOperations {
+		return nil, fmt.Errorf("too many operations - max %d", maxContentItemsBatchOperations)
+	}
+
+	result := model.ContentItemsBatchResult{Results: make([]model.ContentItemsBatchOperationResult, len(operations))}
+	for i, operation := range operations {
+		result.Results[i] = model.ContentItemsBatchOperationResult{Index: i, Action: operation.Action, ID: operation.ID,
+			Status: model.ContentItemsBatchStatusSkipped}
+	}
+
+	failed := -1
+	transaction := func(storage interfaces.Storage) error {
+		for i, operation := range operations {
+			item, err := s.applyContentItemsBatchOperation(storage, claims, appIDParam, operation)
+			if err != nil {
+				failed = i
+				result.Results[i].Status = model.ContentItemsBatchStatusFailed
+				result.Results[i].Error = err.Error()
+				return err
+			}
+			result.Results[i].Status = model.ContentItemsBatchStatusSucceeded
+			if item != nil {
+				result.Results[i].ID = item.ID
+				result.Results[i].Item = item
+			}
+		}
+		return nil
+	}
+
+	err := s.performTransaction(transaction)
+	if err != nil {
+		if failed < 0 {
+			return nil, err
+		}
+
+		//nothing has been changed
+		for i := 0; i < failed; i++ {
+			result.Results[i].Status = model.ContentItemsBatchStatusRolledBack
+			result.Results[i].Item = nil
+		}
+		return &result, nil
+	}
+
+	result.Committed = true
+	return &result, nil
+}
+
+// applyContentItemsBatchOperation performs a batch operation within the batch transaction. It gives the created or the updated item.
+func (s *servicesImpl) applyContentItemsBatchOperation(storage interfaces.Storage, claims *tokenauth.Claims, appID *string, operation model.ContentItemsBatchOperation) (*model.ContentItem, error) {
+	switch operation.Action {
+	case model.ContentItemsBatchActionCreate:
+		if len(operation.Category) == 0 || operation.Data == nil {
+			return nil, errors.New("missing category or data")
+		}
+		item := model.ContentItem{Category: operation.Category, Data: operation.Data, Locales: operation.Locales,
+			Status: operation.Status, PublishAt: operation.PublishAt, ExpireAt: operation.ExpireAt, ExpiresAt: operation.ExpiresAt}
+		err := s.prepareContentItem(appID, claims.OrgID, &item)
+		if err != nil {
+			return nil, err
+		}
+
+		item.ID = uuid.NewString()
+		item.DateCreated = time.Now().UTC()
+		item.OrgID = claims.OrgID
+		item.AppID = appID
+		item.Version = 1
+		createdItem, err := storage.CreateContentItem(item)
+		if err != nil {
+			return nil, err
+		}
+		err = s.recordChange(storage, claims, appID, model.AuditActionCreate, model.AuditResourceContentItem, createdItem.ID, nil, createdItem)
+		if err != nil {
+			return nil, err
+		}
+		return createdItem, nil
+	case model.ContentItemsBatchActionUpdate:
+		if len(operation.ID) == 0 || len(operation.Category) == 0 || operation.Data == nil {
+			return nil, errors.New("missing id, category or data")
+		}
+		err := s.validateContentItemData(appID, claims.OrgID, operation.Category, operation.Data)
+		if err != nil {
+			return nil, err
+		}
+		return s.updateContentItem(storage, claims, appID, operation.ID, operation.Category, operation.Data, operation.Version)
+	case model.ContentItemsBatchActionDelete:
+		if len(operation.ID) == 0 {
+			return nil, errors.New("missing id")
+		}
+		return nil, s.deleteContentItem(storage, claims, appID, operation.ID, operation.Version)
+	default:
+		return nil, fmt.Errorf("invalid action %s - possible values: %s, %s, %s", operation.Action,
+			model.ContentItemsBatchActionCreate, model.ContentItemsBatchActionUpdate, model.ContentItemsBatchActionDelete)
+	}
+}
+
+func (s *servicesImpl) ExportContentItems(allApps bool, appID string, orgID string, categoryList []string) ([]model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	order := "asc"
+	return s.app.storage.FindContentItems(appIDParam, orgID, nil, categoryList, nil, nil, &order, false)
+}
+
+func (s *servicesImpl) ImportContentItems(claims *tokenauth.Claims, allApps bool, items []model.ContentItem, mode string, dryRun bool) (*model.ContentItemsImportReport, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
+	}
+
+	if len(mode) == 0 {
+		mode = model.ContentItemsImportModeUpsert
+	}
+	if mode != model.ContentItemsImportModeUpsert && mode != model.ContentItemsImportModeCreate {
+		return nil, fmt.Errorf("invalid import mode %s - possible values: %s, %s", mode,
+			model.ContentItemsImportModeUpsert, model.ContentItemsImportModeCreate)
+	}
+
+	report := model.ContentItemsImportReport{Mode: mode, DryRun: dryRun, Total: len(items), Errors: []model.ContentItemsImportError{}}
+	transaction := func(storage interfaces.Storage) error {
+		now := time.Now().UTC()
+
+		//check all the items first, nothing is stored if any of them is not fine
+		replaced := map[string]model.ContentItem{}
+		ids := map[string]bool{}
+		for i := range items {
+			item := &items[i]
+			useRawContentItemData(item)
+			err := s.prepareContentItem(appIDParam, claims.OrgID, item)
+			if err != nil {
+				report.Errors = append(report.Errors, model.ContentItemsImportError{Line: i + 1, ID: item.ID, Message: err.Error()})
+				continue
+			}
+
+			if mode == model.ContentItemsImportModeCreate || len(item.ID) == 0 {
+				item.ID = uuid.NewString()
+			} else if ids[item.ID] {
+				report.Errors = append(report.Errors, model.ContentItemsImportError{Line: i + 1, ID: item.ID, Message: "duplicated id"})
+				continue
+			}
+			ids[item.ID] = true
+
+			current, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{item.ID}, nil, nil, nil, nil, false)
+			if err != nil {
+				return err
+			}
+			if len(current) == 1 {
+				replaced[item.ID] = current[0]
+				item.DateCreated = current[0].DateCreated
+				item.DateUpdated = &now
+				item.Version = current[0].Version + 1
+				report.Updated++
+			} else {
+				item.DateCreated = now
+				item.DateUpdated = nil
+				item.Version = 1
+				report.Created++
+			}
+			item.OrgID = claims.OrgID
+			item.AppID = appIDParam
+		}
+		if dryRun || len(report.Errors) > 0 {
+			return nil
+		}
+
+		//store them
+		for _, item := range items {
+			var before interface{}
+			if current, ok := replaced[item.ID]; ok {
+				err := s.createContentItemRevision(storage, current, claims.Subject, model.RevisionActionImport)
+				if err != nil {
+					return err
+				}
+				before = current
+			}
+			err := storage.SaveContentItem(item)
+			if err != nil {
+				return fmt.Errorf("error on storing content item with id: %s - %s", item.ID, err)
+			}
+			err = s.recordChange(storage, claims, appIDParam, model.AuditActionImport, model.AuditResourceContentItem, item.ID, before, item)
+			if err != nil {
+				return err
+			}
+		}
+		report.Imported = true
+		return nil
+	}
+
+	err := s.performTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// checkVersion gives an error if the expected version is not the current one. Nothing is checked when there is no expected version.
+func checkVersion(resource string, id string, expected *int64, current int64) error {
+	if expected == nil || *expected == current {
+		return nil
+	}
+	return &model.VersionMismatchError{Resource: resource, ID: id, Expected: *expected, Current: current}
+}
+
+// validateContentItemStatus checks the status and the publishing window of a content item
+func validateContentItemStatus(status string, publishAt *time.Time, expireAt *time.Time) error {
+	switch status {
+	case model.ContentItemStatusDraft, model.ContentItemStatusPublished, model.ContentItemStatusArchived:
+	default:
+		return fmt.Errorf("invalid status %s - possible values: %s, %s, %s", status,
+			model.ContentItemStatusDraft, model.ContentItemStatusPublished, model.ContentItemStatusArchived)
+	}
+	if publishAt != nil && expireAt != nil && !expireAt.After(*publishAt) {
+		return errors.New("expire_at must be after publish_at")
+	}
+	return nil
+}
+
+// validateExpiresAt checks that the item is not removed right away
+func validateExpiresAt(expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return errors.New("expires_at must be in the future")
+	}
+	return nil
+}
+
+func validateContentItemPin(pinned bool, pinnedUntil *time.Time) error {
+	if !pinned && pinnedUntil != nil {
+		return errors.New("pinned_until is allowed only for the pinned items")
+	}
+	return nil
+}
+
+// createContentItemRevision stores the version of the item which is about to be replaced
+func (s *servicesImpl) createContentItemRevision(storage interfaces.Storage, item model.ContentItem, changedBy string, action string) error {
+	count, err := storage.CountContentItemRevisions(item.ID)
+	if err != nil {
+		return err
+	}
+
+	revision := model.ContentItemRevision{ID: uuid.NewString(), ContentItemID: item.ID, Revision: count + 1, Action: action,
+		Category: item.Category, Data: item.Data, Locales: item.Locales, RawData: item.RawData, RawLocales: item.RawLocales, OrgID: item.OrgID,
+		AppID: item.AppID, ChangedBy: changedBy, DateCreated: time.Now().UTC(), Status: item.Status, PublishAt: item.PublishAt,
+		ExpireAt: item.ExpireAt, ExpiresAt: item.ExpiresAt, Audience: item.Audience}
+	return storage.CreateContentItemRevision(revision)
+}
+
+// Content Item Revisions
+
+func (s *servicesImpl) GetContentItemRevisions(allApps bool, appID string, orgID string, id string) ([]model.ContentItemRevision, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+	return s.app.storage.FindContentItemRevisions(appIDParam, orgID, id)
+}
+
+func (s *servicesImpl) GetContentItemRevisionsDiff(allApps bool, appID string, orgID string, id string, from string, to string) (*model.ContentItemRevisionsDiff, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	fromVersion, err := s.findContentItemVersion(appIDParam, orgID, id, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := s.findContentItemVersion(appIDParam, orgID, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := diffData(fromVersion, toVersion, "")
+	return &model.ContentItemRevisionsDiff{ContentItemID: id, From: from, To: to, Changes: changes}, nil
+}
+
+// findContentItemVersion gives the category and the data of a revision or of the current version of the item
+func (s *servicesImpl) findContentItemVersion(appID *string, orgID string, id string, revision string) (map[string]interface{}, error) {
+	if revision == model.RevisionCurrent {
+		items, err := s.app.storage.FindContentItems(appID, orgID, []string{id}, nil, nil, nil, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) != 1 {
+			return nil, fmt.Errorf("content item with id: %s is not found", id)
+		}
+		return map[string]interface{}{"category": items[0].Category, "data": items[0].Data, "locales": items[0].Locales}, nil
+	}
 
-func (s *servicesImpl) GetStudentGuide(appID string, orgID string, id string) (bson.M, error) {
-	item, err := s.app.storage.GetStudentGuide(appID, orgID, id)
+	number, err := strconv.ParseInt(revision, 10, 64)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid revision %s", revision)
 	}
-	return item, nil
-}
-
-func (s *servicesImpl) CreateStudentGuide(appID string, orgID string, item bson.M) (bson.M, error) {
-	items, err := s.app.storage.CreateStudentGuide(appID, orgID, item)
+	item, err := s.app.storage.FindContentItemRevision(appID, orgID, id, number)
 	if err != nil {
 		return nil, err
 	}
-	return items, nil
+	if item == nil {
+		return nil, fmt.Errorf("revision %d of content item with id: %s is not found", number, id)
+	}
+	return map[string]interface{}{"category": item.Category, "data": item.Data, "locales": item.Locales}, nil
 }
 
-func (s *servicesImpl) UpdateStudentGuide(appID string, orgID string, id string, item bson.M) (bson.M, error) {
-	items, err := s.app.storage.UpdateStudentGuide(appID, orgID, id, item)
-	if err != nil {
-		return nil, err
+func (s *servicesImpl) RestoreContentItemRevision(claims *tokenauth.Claims, allApps bool, id string, revision int64) (*model.ContentItem, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &claims.AppID //associated with current app
 	}
-	return items, nil
-}
 
-func (s *servicesImpl) DeleteStudentGuide(appID string, orgID string, id string) error {
-	err := s.app.storage.DeleteStudentGuide(appID, orgID, id)
-	return err
-}
+	var item model.ContentItem
+	transaction := func(storage interfaces.Storage) error {
+		//find the revision
+		revisionItem, err := storage.FindContentItemRevision(appIDParam, claims.OrgID, id, revision)
+		if err != nil {
+			return err
+		}
+		if revisionItem == nil {
+			return fmt.Errorf("revision %d of content item with id: %s is not found", revision, id)
+		}
 
-// Health Locations
+		//the schema could have been changed since the revision was kept
+		err = s.validateContentItemVariants(appIDParam, claims.OrgID, revisionItem.Category, revisionItem.Data, revisionItem.Locales)
+		if err != nil {
+			return err
+		}
 
-func (s *servicesImpl) GetHealthLocations(appID string, orgID string, ids []string) ([]bson.M, error) {
-	items, err := s.app.storage.GetHealthLocations(appID, orgID, ids)
+		//the item in the trash is restored with its status, audience and the rest as they are, so it must be taken out of the trash first
+		deletedItem, err := storage.FindDeletedContentItem(appIDParam, claims.OrgID, id)
+		if err != nil {
+			return err
+		}
+		if deletedItem != nil {
+			return fmt.Errorf("content item with id: %s is in the trash, it must be restored first", id)
+		}
+
+		//find the current version
+		items, err := storage.FindContentItems(appIDParam, claims.OrgID, []string{id}, nil, nil, nil, nil, false)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		var before interface{}
+		if len(items) == 1 {
+			item = items[0]
+			before = auditSnapshot(item)
+
+			//keep the current version as a revision as well
+			err = s.createContentItemRevision(storage, item, claims.Subject, model.RevisionActionRestore)
+			if err != nil {
+				return err
+			}
+		} else {
+			//the item is brought back as it was published, the revisions kept before the publishing state was kept give a draft
+			item = model.ContentItem{ID: id, DateCreated: now, OrgID: revisionItem.OrgID, AppID: revisionItem.AppID, Status: revisionItem.Status,
+				PublishAt: revisionItem.PublishAt, ExpireAt: revisionItem.ExpireAt, Audience: revisionItem.Audience}
+			if len(item.Status) == 0 {
+				item.Status = model.ContentItemStatusDraft
+			}
+			//the item could have been removed as it expired
+			if revisionItem.ExpiresAt != nil && revisionItem.ExpiresAt.After(now) {
+				item.ExpiresAt = revisionItem.ExpiresAt
+			}
+		}
+		item.Version++
+
+		//the category could have been unregistered since the revision was kept
+		if len(items) != 1 || items[0].Category != revisionItem.Category {
+			err = s.checkContentItemCategory(appIDParam, claims.OrgID, revisionItem.Category)
+			if err != nil {
+				return err
+			}
+		}
+
+		//put back the old version
+		item.Category = revisionItem.Category
+		item.Data = revisionItem.Data
+		item.Locales = revisionItem.Locales
+		item.RawData = revisionItem.RawData
+		item.RawLocales = revisionItem.RawLocales
+		item.DateUpdated = &now
+		err = s.renderContentItem(appIDParam, claims.OrgID, &item)
+		if err != nil {
+			return err
+		}
+
+		err = storage.SaveContentItem(item)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, appIDParam, model.AuditActionRestore, model.AuditResourceContentItem, id, before, item)
+	}
+
+	err := s.performTransaction(transaction)
 	if err != nil {
 		return nil, err
 	}
-	return items, nil
+
+	return &item, nil
 }
 
-func (s *servicesImpl) GetHealthLocation(appID string, orgID string, id string) (bson.M, error) {
-	item, err := s.app.storage.GetHealthLocation(appID, orgID, id)
+// validateContentItemData validates the data against the schema registered for the category.
+// The schema for the exact app is used when there is one, otherwise the one for all the apps in the organization.
+func (s *servicesImpl) validateContentItemData(appID *string, orgID string, category string, data interface{}) error {
+	schema, err := s.findContentItemSchema(appID, orgID, category)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return item, nil
+	if schema == nil {
+		//no schema for this category
+		return nil
+	}
+
+	return validateContentData(schema, data)
 }
 
-func (s *servicesImpl) CreateHealthLocation(appID string, orgID string, item bson.M) (bson.M, error) {
-	items, err := s.app.storage.CreateHealthLocation(appID, orgID, item)
+// validateContentItemVariants validates the data and all its locale variants against the schema registered for the category
+func (s *servicesImpl) validateContentItemVariants(appID *string, orgID string, category string, data interface{}, locales map[string]interface{}) error {
+	err := s.validateContentItemData(appID, orgID, category, data)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return items, nil
+	for _, localeData := range locales {
+		err = s.validateContentItemData(appID, orgID, category, localeData)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (s *servicesImpl) UpdateHealthLocation(appID string, orgID string, id string, item bson.M) (bson.M, error) {
-	items, err := s.app.storage.UpdateHealthLocation(appID, orgID, id, item)
+// findContentItemSchema gives the schema of the category for the app, otherwise the one for all the apps in the organization. It gives nil when there is no schema.
+func (s *servicesImpl) findContentItemSchema(appID *string, orgID string, category string) (*model.ContentItemSchema, error) {
+	schema, err := s.app.storage.FindContentItemSchema(appID, orgID, category)
 	if err != nil {
 		return nil, err
 	}
-	return items, nil
-}
-
-func (s *servicesImpl) DeleteHealthLocation(appID string, orgID string, id string) error {
-	err := s.app.storage.DeleteHealthLocation(appID, orgID, id)
-	return err
+	if schema == nil && appID != nil {
+		return s.app.storage.FindContentItemSchema(nil, orgID, category)
+	}
+	return schema, nil
 }
 
-// Content Items
+// Content Item Schemas
 
-func (s *servicesImpl) GetContentItemsCategories(allApps bool, appID string, orgID string) ([]string, error) {
+func (s *servicesImpl) GetContentItemSchemas(allApps bool, appID string, orgID string) ([]model.ContentItemSchema, error) {
 	//logic
 	var appIDParam *string
 	if !allApps {
 		appIDParam = &appID //associated with current app
 	}
-	return s.app.storage.GetContentItemsCategories(appIDParam, orgID)
+	return s.app.storage.FindContentItemSchemas(appIDParam, orgID)
 }
 
-func (s *servicesImpl) GetContentItems(allApps bool, appID string, orgID string, ids []string, categoryList []string, offset *int64, limit *int64, order *string) ([]model.ContentItemResponse, error) {
+func (s *servicesImpl) GetContentItemSchema(allApps bool, appID string, orgID string, category string) (*model.ContentItemSchema, error) {
 	//logic
 	var appIDParam *string
 	if !allApps {
 		appIDParam = &appID //associated with current app
 	}
-	return s.app.storage.GetContentItems(appIDParam, orgID, ids, categoryList, offset, limit, order)
-}
 
-func (s *servicesImpl) GetContentItem(allApps bool, appID string, orgID string, id string) (*model.ContentItemResponse, error) {
-	//logic
-	var appIDParam *string
-	if !allApps {
-		appIDParam = &appID //associated with current app
+	schema, err := s.app.storage.FindContentItemSchema(appIDParam, orgID, category)
+	if err != nil {
+		return nil, err
 	}
-	return s.app.storage.GetContentItem(appIDParam, orgID, id)
-}
-
-func (s *servicesImpl) CreateContentItem(allApps bool, appID string, orgID string, category string, data interface{}) (*model.ContentItem, error) {
-	//logic
-	var appIDParam *string
-	if !allApps {
-		appIDParam = &appID //associated with current app
+	if schema == nil {
+		return nil, fmt.Errorf("schema for category %s is not found", category)
 	}
-	cItem := model.ContentItem{ID: uuid.NewString(), Category: category, DateCreated: time.Now().UTC(),
-		Data: data, OrgID: orgID, AppID: appIDParam}
-	return s.app.storage.CreateContentItem(cItem)
+	return schema, nil
 }
 
-func (s *servicesImpl) UpdateContentItem(allApps bool, appID string, orgID string, id string, category string, data interface{}) (*model.ContentItem, error) {
+func (s *servicesImpl) CreateContentItemSchema(allApps bool, appID string, orgID string, category string, schema json.RawMessage) (*model.ContentItemSchema, error) {
 	//logic
 	var appIDParam *string
 	if !allApps {
 		appIDParam = &appID //associated with current app
 	}
 
-	//update
-	item, err := s.app.storage.UpdateContentItem(appIDParam, orgID, id, category, data)
+	//check if the schema is valid
+	_, err := parseContentSchema(schema)
 	if err != nil {
 		return nil, err
 	}
 
-	return item, nil
+	item := model.ContentItemSchema{ID: uuid.NewString(), Category: category, Schema: schema,
+		OrgID: orgID, AppID: appIDParam, DateCreated: time.Now().UTC()}
+	created, err := s.app.storage.CreateContentItemSchema(item)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateContentItemsScope(appIDParam, orgID)
+	return created, nil
 }
 
-func (s *servicesImpl) UpdateContentItemData(allApps bool, appID string, orgID string, id string, category string, data interface{}) (*model.ContentItem, error) {
+func (s *servicesImpl) UpdateContentItemSchema(allApps bool, appID string, orgID string, category string, schema json.RawMessage) (*model.ContentItemSchema, error) {
 	//logic
 	var appIDParam *string
 	if !allApps {
 		appIDParam = &appID //associated with current app
 	}
 
-	//find the item
-	items, err := s.app.storage.FindContentItems(appIDParam, orgID, []string{id}, []string{category}, nil, nil, nil)
+	//check if the schema is valid
+	_, err := parseContentSchema(schema)
 	if err != nil {
 		return nil, err
 	}
-	if len(items) != 1 {
-		return nil, errors.New("not found")
-	}
-	item := items[0]
-
-	//update the data
-	item.Data = data
-	now := time.Now()
-	item.DateUpdated = &now
 
-	//save it
-	err = s.app.storage.SaveContentItem(item)
+	updated, err := s.app.storage.UpdateContentItemSchema(appIDParam, orgID, category, schema)
 	if err != nil {
 		return nil, err
 	}
-
-	return &item, nil
-}
-
-func (s *servicesImpl) DeleteContentItem(allApps bool, appID string, orgID string, id string) error {
-	//logic
-	var appIDParam *string
-	if !allApps {
-		appIDParam = &appID //associated with current app
-	}
-	return s.app.storage.DeleteContentItem(appIDParam, orgID, id)
+	s.invalidateContentItemsScope(appIDParam, orgID)
+	return updated, nil
 }
 
-func (s *servicesImpl) DeleteContentItemByCategory(allApps bool, appID string, orgID string, id string, category string) error {
+func (s *servicesImpl) DeleteContentItemSchema(allApps bool, appID string, orgID string, category string) error {
 	//logic
 	var appIDParam *string
 	if !allApps {
 		appIDParam = &appID //associated with current app
 	}
-
-	//find the item
-	items, err := s.app.storage.FindContentItems(appIDParam, orgID, []string{id}, []string{category}, nil, nil, nil)
-	if err != nil {
-		return err
-	}
-	if len(items) != 1 {
-		return errors.New("not found")
-	}
-
-	//delete it
-	err = s.app.storage.DeleteContentItem(appIDParam, orgID, id)
+	err := s.app.storage.DeleteContentItemSchema(appIDParam, orgID, category)
 	if err != nil {
 		return err
 	}
-
+	s.invalidateContentItemsScope(appIDParam, orgID)
 	return nil
 }
 
 // Misc
 
-func (s *servicesImpl) UploadImage(imageBytes []byte, path string, spec model.ImageSpec) (*string, error) {
+func (s *servicesImpl) UploadImage(claims *tokenauth.Claims, imageBytes []byte, path string, spec model.ImageSpec) (*string, error) {
 	image, _, err := image.Decode(bytes.NewReader(imageBytes))
 	if err != nil {
 		return nil, fmt.Errorf("Error decoding image: %s", err)
@@ -271,6 +1849,7 @@ func (s *servicesImpl) UploadImage(imageBytes []byte, path string, spec model.Im
 	if err != nil {
 		return nil, fmt.Errorf("Unable to upload to S3: %s", err)
 	}
+	s.logChange(claims, &claims.AppID, model.AuditActionUpload, model.AuditResourceImage, path, nil, map[string]interface{}{"path": path, "url": url})
 
 	if url != nil {
 		return url, nil
@@ -414,19 +1993,67 @@ func (s *servicesImpl) GetTwitterPosts(userID string, twitterQueryParams string,
 	return posts, err
 }
 
-func (s *servicesImpl) GetDataContentItem(claims *tokenauth.Claims, key string) (*model.DataContentItem, error) {
+func (s *servicesImpl) GetDataContentItem(claims *tokenauth.Claims, key string, locales []string, checkReadAccess bool) (*model.DataContentItem, error) {
+	//the client reads are served from the cache until the item or the read access of the categories is changed
+	var cacheKey string
+	if checkReadAccess {
+		cacheKey = s.dataContentItemsCacheKey(&claims.AppID, claims.OrgID, dataContentKeyCacheTag(key), "item", key, locales, claims.Anonymous, claims.Permissions)
+		if cached, ok := s.cachedContent(cacheKey).(*model.DataContentItem); ok {
+			return cached, nil
+		}
+	}
+
 	item, err := s.app.storage.FindDataContentItem(&claims.AppID, claims.OrgID, key)
 	if err != nil {
 		return nil, err
 	}
+	if item != nil && checkReadAccess {
+		//the user gets nothing when it cannot read the category of the item
+		canRead, err := s.canReadDataContentItems(claims, item.Category)
+		if err != nil {
+			return nil, err
+		}
+		if !canRead {
+			return nil, nil
+		}
+	}
+	if item != nil {
+		s.localizeDataContentItems([]*model.DataContentItem{item}, locales)
+		if checkReadAccess {
+			s.cacheDataContentItems(cacheKey, []*model.DataContentItem{item}, item)
+		}
+	}
 	return item, nil
 }
 
-func (s *servicesImpl) GetDataContentItems(claims *tokenauth.Claims, category string) ([]*model.DataContentItem, error) {
+func (s *servicesImpl) GetDataContentItems(claims *tokenauth.Claims, category string, locales []string, checkReadAccess bool) ([]*model.DataContentItem, error) {
+	//the client reads are served from the cache until the items or the read access of the categories is changed
+	var cacheKey string
+	if checkReadAccess {
+		cacheKey = s.dataContentItemsCacheKey(&claims.AppID, claims.OrgID, contentCategoryCacheTag(category), "items", category, locales, claims.Anonymous, claims.Permissions)
+		if cached, ok := s.cachedContent(cacheKey).([]*model.DataContentItem); ok {
+			return cached, nil
+		}
+	}
+
+	if checkReadAccess {
+		canRead, err := s.canReadDataContentItems(claims, category)
+		if err != nil {
+			return nil, err
+		}
+		if !canRead {
+			return []*model.DataContentItem{}, nil
+		}
+	}
+
 	item, err := s.app.storage.FindDataContentItems(&claims.AppID, claims.OrgID, category)
 	if err != nil {
 		return nil, err
 	}
+	s.localizeDataContentItems(item, locales)
+	if checkReadAccess {
+		s.cacheDataContentItems(cacheKey, item, item)
+	}
 	return item, nil
 }
 
@@ -441,18 +2068,38 @@ func (s *servicesImpl) CreateDataContentItem(claims *tokenauth.Claims, item *mod
 		return nil, fmt.Errorf("unauthorized to create data content item: [%s]", strings.Join(category.Permissions, ", "))
 	}
 
+	item.Locales, err = normalizeLocales(item.Locales)
+	if err != nil {
+		return nil, err
+	}
+	err = validateExpiresAt(item.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
 	item.ID = uuid.NewString()
 	item.AppID = &claims.AppID
 	item.OrgID = claims.OrgID
 	item.DateCreated = time.Now().UTC()
-	item, err = s.app.storage.CreateDataContentItem(item)
+	item.Version = 1
+
+	var createdItem *model.DataContentItem
+	transaction := func(storage interfaces.Storage) error {
+		createdItem, err = storage.CreateDataContentItem(item)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, &claims.AppID, model.AuditActionCreate, model.AuditResourceDataContentItem, createdItem.ID, nil, createdItem)
+	}
+
+	err = s.performTransaction(transaction)
 	if err != nil {
 		return nil, err
 	}
-	return item, nil
+	return createdItem, nil
 }
 
-func (s *servicesImpl) UpdateDataContentItem(claims *tokenauth.Claims, item *model.DataContentItem) (*model.DataContentItem, error) {
+func (s *servicesImpl) UpdateDataContentItem(claims *tokenauth.Claims, item *model.DataContentItem, version *int64) (*model.DataContentItem, error) {
 	var dataItem *model.DataContentItem
 
 	category, err := s.app.storage.FindCategory(&claims.AppID, claims.OrgID, item.Category)
@@ -464,23 +2111,50 @@ func (s *servicesImpl) UpdateDataContentItem(claims *tokenauth.Claims, item *mod
 		return nil, fmt.Errorf("unauthorized to update data content item: [%s]", strings.Join(category.Permissions, ", "))
 	}
 
-	oldItem, err := s.app.storage.FindDataContentItem(&claims.AppID, claims.OrgID, item.Key)
+	item.Locales, err = normalizeLocales(item.Locales)
+	if err != nil {
+		return nil, err
+	}
+	err = validateExpiresAt(item.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
 
-	if item.Category != oldItem.Category {
-		category, err = s.app.storage.FindCategory(&claims.AppID, claims.OrgID, oldItem.Category)
+	transaction := func(storage interfaces.Storage) error {
+		oldItem, err := storage.FindDataContentItem(&claims.AppID, claims.OrgID, item.Key)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if oldItem == nil {
+			//it could have expired as well
+			return fmt.Errorf("data content item with key: %s is not found", item.Key)
 		}
 
-		if !checkPermissions(category.Permissions, claims.Permissions) {
-			return nil, fmt.Errorf("unauthorized to update data content item: [%s]", strings.Join(category.Permissions, ", "))
+		if item.Category != oldItem.Category {
+			category, err = storage.FindCategory(&claims.AppID, claims.OrgID, oldItem.Category)
+			if err != nil {
+				return err
+			}
+
+			if !checkPermissions(category.Permissions, claims.Permissions) {
+				return fmt.Errorf("unauthorized to update data content item: [%s]", strings.Join(category.Permissions, ", "))
+			}
 		}
+
+		err = checkVersion("data content item", item.Key, version, oldItem.Version)
+		if err != nil {
+			return err
+		}
+
+		dataItem, err = storage.UpdateDataContentItem(&claims.AppID, claims.OrgID, item)
+		if err != nil {
+			return err
+		}
+		dataItem.Version = oldItem.Version + 1
+		return s.recordChange(storage, claims, &claims.AppID, model.AuditActionUpdate, model.AuditResourceDataContentItem, oldItem.ID, oldItem, dataItem)
 	}
 
-	dataItem, err = s.app.storage.UpdateDataContentItem(&claims.AppID, claims.OrgID, item)
+	err = s.performTransaction(transaction)
 	if err != nil {
 		return nil, err
 	}
@@ -488,28 +2162,90 @@ func (s *servicesImpl) UpdateDataContentItem(claims *tokenauth.Claims, item *mod
 	return dataItem, err
 }
 
-func (s *servicesImpl) DeleteDataContentItem(claims *tokenauth.Claims, key string) error {
+func (s *servicesImpl) DeleteDataContentItem(claims *tokenauth.Claims, key string, version *int64) error {
+	transaction := func(storage interfaces.Storage) error {
+		item, err := storage.FindDataContentItem(&claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+
+		category, err := storage.FindCategory(&claims.AppID, claims.OrgID, item.Category)
+		if err != nil {
+			return err
+		}
 
-	item, err := s.app.storage.FindDataContentItem(&claims.AppID, claims.OrgID, key)
+		if !checkPermissions(category.Permissions, claims.Permissions) {
+			return fmt.Errorf("unauthorized to delete data content item: [%s]", strings.Join(category.Permissions, ", "))
+		}
+
+		err = checkVersion("data content item", key, version, item.Version)
+		if err != nil {
+			return err
+		}
+
+		err = storage.DeleteDataContentItem(&claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, &claims.AppID, model.AuditActionDelete, model.AuditResourceDataContentItem, item.ID, item, nil)
+	}
+
+	return s.performTransaction(transaction)
+}
+
+func (s *servicesImpl) GetDataContentItemsMissingTranslations(claims *tokenauth.Claims, category string, locales []string) ([]model.MissingTranslation, error) {
+	locales, err := normalizeReportLocales(locales)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	category, err := s.app.storage.FindCategory(&claims.AppID, claims.OrgID, item.Category)
+	items, err := s.app.storage.FindDataContentItemsMissingLocales(&claims.AppID, claims.OrgID, category, locales)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	for i := range items {
+		items[i].MissingLocales = missingLocales(items[i].Locales, locales)
 	}
+	return items, nil
+}
 
-	if !checkPermissions(category.Permissions, claims.Permissions) {
-		return fmt.Errorf("unauthorized to delete data content item: [%s]", strings.Join(category.Permissions, ", "))
+func (s *servicesImpl) GetDeletedDataContentItems(claims *tokenauth.Claims, category string) ([]model.DataContentItem, error) {
+	return s.app.storage.FindDeletedDataContentItems(&claims.AppID, claims.OrgID, category)
+}
+
+func (s *servicesImpl) RestoreDataContentItem(claims *tokenauth.Claims, id string) (*model.DataContentItem, error) {
+	var dataItem *model.DataContentItem
+	transaction := func(storage interfaces.Storage) error {
+		item, err := storage.FindDeletedDataContentItem(&claims.AppID, claims.OrgID, id)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return fmt.Errorf("deleted data content item with id: %s is not found", id)
+		}
+
+		//the category has to be restored first if it is in the trash as well
+		category, err := storage.FindCategory(&claims.AppID, claims.OrgID, item.Category)
+		if err != nil {
+			return fmt.Errorf("category %s of the data content item with id: %s is not found - %s", item.Category, id, err)
+		}
+
+		if !checkPermissions(category.Permissions, claims.Permissions) {
+			return fmt.Errorf("unauthorized to restore data content item: [%s]", strings.Join(category.Permissions, ", "))
+		}
+
+		dataItem, err = storage.RestoreDataContentItem(&claims.AppID, claims.OrgID, id)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, &claims.AppID, model.AuditActionRestore, model.AuditResourceDataContentItem, id, nil, dataItem)
 	}
 
-	err = s.app.storage.DeleteDataContentItem(&claims.AppID, claims.OrgID, key)
+	err := s.performTransaction(transaction)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	return nil
+	return dataItem, nil
 }
 
 func (s *servicesImpl) CreateCategory(claims *tokenauth.Claims, item *model.Category) (*model.Category, error) {
@@ -517,11 +2253,23 @@ func (s *servicesImpl) CreateCategory(claims *tokenauth.Claims, item *model.Cate
 	item.AppID = &claims.AppID
 	item.OrgID = claims.OrgID
 	item.DateCreated = time.Now().UTC()
-	item, err := s.app.storage.CreateCategory(item)
+	item.Version = 1
+
+	var category *model.Category
+	transaction := func(storage interfaces.Storage) error {
+		var err error
+		category, err = storage.CreateCategory(item)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, &claims.AppID, model.AuditActionCreate, model.AuditResourceCategory, category.ID, nil, category)
+	}
+
+	err := s.performTransaction(transaction)
 	if err != nil {
 		return nil, err
 	}
-	return item, nil
+	return category, nil
 }
 
 func (s *servicesImpl) GetCategory(claims *tokenauth.Claims, name string) (*model.Category, error) {
@@ -532,20 +2280,79 @@ func (s *servicesImpl) GetCategory(claims *tokenauth.Claims, name string) (*mode
 	return item, nil
 }
 
-func (s *servicesImpl) UpdateCategory(claims *tokenauth.Claims, item *model.Category) (*model.Category, error) {
-	item, err := s.app.storage.UpdateCategory(&claims.AppID, claims.OrgID, item)
+func (s *servicesImpl) UpdateCategory(claims *tokenauth.Claims, item *model.Category, version *int64) (*model.Category, error) {
+	var category *model.Category
+	transaction := func(storage interfaces.Storage) error {
+		current, err := storage.FindCategoryByID(&claims.AppID, claims.OrgID, item.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return fmt.Errorf("category with id: %s is not found", item.ID)
+		}
+		err = checkVersion("category", item.ID, version, current.Version)
+		if err != nil {
+			return err
+		}
+
+		category, err = storage.UpdateCategory(&claims.AppID, claims.OrgID, item)
+		if err != nil {
+			return err
+		}
+		category.Version = current.Version + 1
+		return s.recordChange(storage, claims, &claims.AppID, model.AuditActionUpdate, model.AuditResourceCategory, current.ID, current, category)
+	}
+
+	err := s.performTransaction(transaction)
 	if err != nil {
 		return nil, err
 	}
-	return item, nil
+	return category, nil
+}
+
+func (s *servicesImpl) DeleteCategory(claims *tokenauth.Claims, name string, version *int64) error {
+	transaction := func(storage interfaces.Storage) error {
+		current, err := storage.FindCategory(&claims.AppID, claims.OrgID, name)
+		if err != nil {
+			return err
+		}
+		if current != nil {
+			err = checkVersion("category", name, version, current.Version)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = storage.DeleteCategory(&claims.AppID, claims.OrgID, name)
+		if err != nil || current == nil {
+			return err
+		}
+		return s.recordChange(storage, claims, &claims.AppID, model.AuditActionDelete, model.AuditResourceCategory, current.ID, current, nil)
+	}
+
+	return s.performTransaction(transaction)
+}
+
+func (s *servicesImpl) GetDeletedCategories(claims *tokenauth.Claims) ([]model.Category, error) {
+	return s.app.storage.FindDeletedCategories(&claims.AppID, claims.OrgID)
 }
 
-func (s *servicesImpl) DeleteCategory(claims *tokenauth.Claims, name string) error {
-	err := s.app.storage.DeleteCategory(&claims.AppID, claims.OrgID, name)
+func (s *servicesImpl) RestoreCategory(claims *tokenauth.Claims, id string) (*model.Category, error) {
+	var category *model.Category
+	transaction := func(storage interfaces.Storage) error {
+		var err error
+		category, err = storage.RestoreCategory(&claims.AppID, claims.OrgID, id)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(storage, claims, &claims.AppID, model.AuditActionRestore, model.AuditResourceCategory, id, nil, category)
+	}
+
+	err := s.performTransaction(transaction)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return category, nil
 }
 
 func (s *servicesImpl) UploadFileContentItem(file io.Reader, claims *tokenauth.Claims, fileName string, category string) error {
@@ -566,6 +2373,7 @@ func (s *servicesImpl) UploadFileContentItem(file io.Reader, claims *tokenauth.C
 		return fmt.Errorf("unable to upload to S3: %s", err)
 	}
 
+	s.logChange(claims, &claims.AppID, model.AuditActionUpload, model.AuditResourceFile, path, nil, map[string]interface{}{"category": category, "file_name": fileName})
 	return nil
 }
 
@@ -637,6 +2445,7 @@ func (s *servicesImpl) DeleteFileContentItem(claims *tokenauth.Claims, fileName
 		return err
 	}
 
+	s.logChange(claims, &claims.AppID, model.AuditActionDelete, model.AuditResourceFile, path, map[string]interface{}{"category": category, "file_name": fileName}, nil)
 	return nil
 }
 
@@ -0,0 +1,311 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/interfaces"
+	"content/core/model"
+	"content/utils"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/core-auth-library-go/v3/authutils"
+	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
+)
+
+// webhookResourceTypes are the resources which changes are delivered to the webhooks
+var webhookResourceTypes = []string{model.AuditResourceContentItem, model.AuditResourceDataContentItem, model.AuditResourceFile}
+
+// queueWebhookDeliveries stores a delivery of the change for every active webhook which is subscribed for it.
+// The deliveries are sent later by the webhooks logic.
+func (s *servicesImpl) queueWebhookDeliveries(storage interfaces.Storage, claims *tokenauth.Claims, appID *string, action string,
+	resourceType string, resourceID string, data interface{}, date time.Time) error {
+	if !authutils.ContainsString(webhookResourceTypes, resourceType) {
+		return nil
+	}
+
+	webhooks, err := storage.FindWebhooks(appID, claims.OrgID)
+	if err != nil {
+		return err
+	}
+	if appID != nil {
+		//the webhooks for all the apps within the organization get the changes of every app
+		orgWebhooks, err := storage.FindWebhooks(nil, claims.OrgID)
+		if err != nil {
+			return err
+		}
+		webhooks = append(webhooks, orgWebhooks...)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	var category string
+	if fields, ok := data.(map[string]interface{}); ok {
+		category, _ = fields["category"].(string)
+	}
+
+	event := model.WebhookEvent{ID: uuid.NewString(), Event: resourceType + "." + action, ResourceType: resourceType,
+		ResourceID: resourceID, Category: category, OrgID: claims.OrgID, AppID: appID, Actor: claims.Subject, Data: data, Date: date}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []model.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !webhook.Active {
+			continue
+		}
+		if len(webhook.ResourceTypes) > 0 && !authutils.ContainsString(webhook.ResourceTypes, resourceType) {
+			continue
+		}
+		if len(webhook.Categories) > 0 && !authutils.ContainsString(webhook.Categories, category) {
+			continue
+		}
+
+		nextAttempt := date
+		deliveries = append(deliveries, model.WebhookDelivery{ID: uuid.NewString(), WebhookID: webhook.ID, OrgID: webhook.OrgID,
+			AppID: webhook.AppID, Event: event.Event, Payload: string(payload), Status: model.WebhookDeliveryStatusPending,
+			NextAttempt: &nextAttempt, Attempts: []model.WebhookDeliveryAttempt{}, DateCreated: date})
+	}
+	return storage.CreateWebhookDeliveries(deliveries)
+}
+
+// signWebhookPayload gives the hex encoded HMAC-SHA256 of "<timestamp>.<payload>" with the webhook secret
+func signWebhookPayload(secret string, timestamp string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// prepareWebhook checks the webhook fields and generates a secret if it is not set
+func prepareWebhook(item *model.Webhook) error {
+	webhookURL, err := url.Parse(item.URL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return fmt.Errorf("invalid url %s - it must be an absolute http or https url", item.URL)
+	}
+	err = checkWebhookHost(webhookURL.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, resourceType := range item.ResourceTypes {
+		if !authutils.ContainsString(webhookResourceTypes, resourceType) {
+			return fmt.Errorf("invalid resource type %s - possible values: %s", resourceType, strings.Join(webhookResourceTypes, ", "))
+		}
+	}
+	for _, category := range item.Categories {
+		if len(strings.TrimSpace(category)) == 0 {
+			return errors.New("empty category")
+		}
+	}
+
+	if len(item.Secret) == 0 {
+		item.Secret, err = generateWebhookSecret()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkWebhookHost checks that the webhook does not target the internal network - all the addresses of the host must be public.
+// The deliveries are checked again when they are sent as the host could be resolved to other addresses later.
+func checkWebhookHost(host string) error {
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return fmt.Errorf("the host %s of the url cannot be resolved - %s", host, err)
+	}
+	for _, ip := range ips {
+		if !utils.IsPublicIP(ip) {
+			return fmt.Errorf("the host %s of the url is not public", host)
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// Webhooks
+
+func (s *servicesImpl) GetWebhooks(allApps bool, appID string, orgID string) ([]model.Webhook, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+	return s.app.storage.FindWebhooks(appIDParam, orgID)
+}
+
+func (s *servicesImpl) GetWebhook(allApps bool, appID string, orgID string, id string) (*model.Webhook, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	webhook, err := s.app.storage.FindWebhook(appIDParam, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, fmt.Errorf("webhook with id: %s is not found", id)
+	}
+	return webhook, nil
+}
+
+func (s *servicesImpl) CreateWebhook(allApps bool, appID string, orgID string, item model.Webhook) (*model.Webhook, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	err := prepareWebhook(&item)
+	if err != nil {
+		return nil, err
+	}
+
+	item.ID = uuid.NewString()
+	item.OrgID = orgID
+	item.AppID = appIDParam
+	item.DateCreated = time.Now().UTC()
+	item.DateUpdated = nil
+	err = s.app.storage.CreateWebhook(item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (s *servicesImpl) UpdateWebhook(allApps bool, appID string, orgID string, item model.Webhook) (*model.Webhook, error) {
+	current, err := s.GetWebhook(allApps, appID, orgID, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	//keep the current secret if a new one is not set
+	if len(item.Secret) == 0 {
+		item.Secret = current.Secret
+	}
+	err = prepareWebhook(&item)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	item.OrgID = current.OrgID
+	item.AppID = current.AppID
+	item.DateCreated = current.DateCreated
+	item.DateUpdated = &now
+	err = s.app.storage.UpdateWebhook(item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// RotateWebhookSecret generates a new secret for the webhook, the deliveries are signed with it from now on
+func (s *servicesImpl) RotateWebhookSecret(allApps bool, appID string, orgID string, id string) (*model.Webhook, error) {
+	item, err := s.GetWebhook(allApps, appID, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Secret, err = generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	item.DateUpdated = &now
+	err = s.app.storage.UpdateWebhook(*item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *servicesImpl) DeleteWebhook(allApps bool, appID string, orgID string, id string) error {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+	return s.app.storage.DeleteWebhook(appIDParam, orgID, id)
+}
+
+func (s *servicesImpl) GetWebhookDeliveries(allApps bool, appID string, orgID string, webhookID string, status *string, offset *int64, limit *int64) ([]model.WebhookDelivery, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+	return s.app.storage.FindWebhookDeliveries(appIDParam, orgID, webhookID, status, offset, limit)
+}
+
+func (s *servicesImpl) GetWebhookDelivery(allApps bool, appID string, orgID string, webhookID string, id string) (*model.WebhookDelivery, error) {
+	//logic
+	var appIDParam *string
+	if !allApps {
+		appIDParam = &appID //associated with current app
+	}
+
+	delivery, err := s.app.storage.FindWebhookDelivery(appIDParam, orgID, webhookID, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, fmt.Errorf("delivery with id: %s of webhook with id: %s is not found", id, webhookID)
+	}
+	return delivery, nil
+}
+
+// ReplayWebhookDelivery sends again the payload of a delivery as a new delivery
+func (s *servicesImpl) ReplayWebhookDelivery(allApps bool, appID string, orgID string, webhookID string, id string) (*model.WebhookDelivery, error) {
+	delivery, err := s.GetWebhookDelivery(allApps, appID, orgID, webhookID, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status == model.WebhookDeliveryStatusPending {
+		return nil, fmt.Errorf("delivery with id: %s is still pending", id)
+	}
+
+	now := time.Now().UTC()
+	replay := model.WebhookDelivery{ID: uuid.NewString(), WebhookID: delivery.WebhookID, OrgID: delivery.OrgID, AppID: delivery.AppID,
+		Event: delivery.Event, Payload: delivery.Payload, Status: model.WebhookDeliveryStatusPending, NextAttempt: &now,
+		Attempts: []model.WebhookDeliveryAttempt{}, ReplayOf: &delivery.ID, DateCreated: now}
+	err = s.app.storage.CreateWebhookDeliveries([]model.WebhookDelivery{replay})
+	if err != nil {
+		return nil, err
+	}
+	return &replay, nil
+}
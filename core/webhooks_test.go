@@ -0,0 +1,76 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"content/core/model"
+	"testing"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		payload   string
+		want      string
+	}{
+		{name: "payload", secret: "secret", timestamp: "1700000000", payload: `{"event":"content_item.created"}`,
+			want: "a91204821fd405186abf263f761744cd3a3d4efe7ef1cb53431fb858919ce9d5"},
+		{name: "empty", secret: "", timestamp: "0", payload: "",
+			want: "b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3"},
+		{name: "timestamp is signed with the payload", secret: "Jefe", timestamp: "what do ya want", payload: "for nothing?",
+			want: "e33f91577b90d4b3bbd73cb61803074f1965d27701b5527942dd9ea9443af37c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signWebhookPayload(tt.secret, tt.timestamp, tt.payload); got != tt.want {
+				t.Errorf("signWebhookPayload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook model.Webhook
+		wantErr bool
+	}{
+		{name: "public address", webhook: model.Webhook{URL: "https://93.184.215.14/hooks"}},
+		{name: "public address with a port", webhook: model.Webhook{URL: "http://93.184.215.14:8080/hooks", Categories: []string{"news"}}},
+		{name: "relative url", webhook: model.Webhook{URL: "/hooks"}, wantErr: true},
+		{name: "not http", webhook: model.Webhook{URL: "ftp://93.184.215.14/hooks"}, wantErr: true},
+		{name: "loopback", webhook: model.Webhook{URL: "http://127.0.0.1/hooks"}, wantErr: true},
+		{name: "loopback ipv6", webhook: model.Webhook{URL: "http://[::1]/hooks"}, wantErr: true},
+		{name: "private", webhook: model.Webhook{URL: "http://10.1.2.3/hooks"}, wantErr: true},
+		{name: "link-local metadata", webhook: model.Webhook{URL: "http://169.254.169.254/latest"}, wantErr: true},
+		{name: "unspecified", webhook: model.Webhook{URL: "http://0.0.0.0/hooks"}, wantErr: true},
+		{name: "unknown resource type", webhook: model.Webhook{URL: "https://93.184.215.14/hooks", ResourceTypes: []string{"users"}}, wantErr: true},
+		{name: "empty category", webhook: model.Webhook{URL: "https://93.184.215.14/hooks", Categories: []string{" "}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := tt.webhook
+			err := prepareWebhook(&webhook)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("prepareWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(webhook.Secret) != 64 {
+				t.Errorf("prepareWebhook() secret = %q, want a generated one", webhook.Secret)
+			}
+		})
+	}
+}
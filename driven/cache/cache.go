@@ -16,16 +16,43 @@ package cacheadapter
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/patrickmn/go-cache"
 )
 
+const (
+	//ContentItemsNamespace the client reads of the content items
+	ContentItemsNamespace string = "content_items"
+	//DataContentItemsNamespace the client reads of the data content items
+	DataContentItemsNamespace string = "data_content_items"
+
+	//ContentAllTag the tag of the reads which depend on all the content of an app, it is invalidated on every change
+	ContentAllTag string = "*"
+)
+
 // CacheAdapter structure
 type CacheAdapter struct {
-	cache *cache.Cache
+	cache      *cache.Cache
+	expiration time.Duration
+
+	//the content keys have the generations of their tags, a tag is invalidated by giving it a new generation.
+	//The generations are taken from a counter, so that a tag never gets a generation which it has had before.
+	//A tag is dropped once nothing could be cached with its generation anymore, it has generation 0 then.
+	generations     map[string]contentGeneration
+	counter         uint64
+	pruned          time.Time
+	generationsLock *sync.Mutex
+}
+
+// contentGeneration is the generation of a content scope or tag
+type contentGeneration struct {
+	value     uint64
+	increased time.Time
 }
 
 // NewCacheAdapter creates new instance
@@ -42,7 +69,11 @@ func NewCacheAdapter(defaultCacheExpirationSeconds string) *CacheAdapter {
 	cache := cache.New(duration, duration)
 
 	return &CacheAdapter{
-		cache: cache,
+		cache:           cache,
+		expiration:      duration,
+		generations:     map[string]contentGeneration{},
+		pruned:          time.Now(),
+		generationsLock: &sync.Mutex{},
 	}
 }
 
@@ -77,3 +108,109 @@ func (s *CacheAdapter) ClearTwitterCacheForUser(userID string) {
 		}
 	}
 }
+
+// ContentKey gives the key of a content read with the query in the namespace. appID is nil for the content for all the apps in the organization.
+// The key is taken before the content is read, so that it is not cached under the key when any of the tags is invalidated in the meantime.
+func (s *CacheAdapter) ContentKey(namespace string, orgID string, appID *string, tags []string, query string) string {
+	tags = append([]string{}, tags...)
+	sort.Strings(tags)
+
+	s.generationsLock.Lock()
+	defer s.generationsLock.Unlock()
+
+	scope := contentScope(namespace, orgID, appID)
+	parts := []string{scope, strconv.FormatUint(s.generation(namespace), 10), strconv.FormatUint(s.generation(contentOrgScope(namespace, orgID)), 10),
+		strconv.FormatUint(s.generation(scope), 10)}
+	for _, tag := range tags {
+		parts = append(parts, fmt.Sprintf("%q:%d", tag, s.generation(scope+"."+strconv.Quote(tag))))
+	}
+	return fmt.Sprintf("content.%s.params.%s", strings.Join(parts, "."), query)
+}
+
+// GetContent gives the cached content for the key, nil when it is not cached
+func (s *CacheAdapter) GetContent(key string) interface{} {
+	obj, _ := s.cache.Get(key)
+	return obj
+}
+
+// SetContent caches the content for the key. It is not kept after until when it is set, as the content changes then.
+func (s *CacheAdapter) SetContent(key string, content interface{}, until *time.Time) {
+	expiration := cache.DefaultExpiration
+	if until != nil {
+		remaining := time.Until(*until)
+		if remaining <= 0 {
+			return
+		}
+		if s.expiration <= 0 || remaining < s.expiration {
+			expiration = remaining
+		}
+	}
+	s.cache.Set(key, content, expiration)
+}
+
+// InvalidateContent invalidates the cached reads with any of the tags and the ones which depend on all the content of the app
+func (s *CacheAdapter) InvalidateContent(namespace string, orgID string, appID *string, tags []string) {
+	s.generationsLock.Lock()
+	defer s.generationsLock.Unlock()
+
+	scope := contentScope(namespace, orgID, appID)
+	for _, tag := range append(tags, ContentAllTag) {
+		s.increaseGeneration(scope + "." + strconv.Quote(tag))
+	}
+}
+
+// InvalidateContentScope invalidates all the cached reads of the app. All the apps in the organization are invalidated when appID is nil,
+// as the settings for all the apps apply to every app.
+func (s *CacheAdapter) InvalidateContentScope(namespace string, orgID string, appID *string) {
+	s.generationsLock.Lock()
+	defer s.generationsLock.Unlock()
+
+	if appID == nil {
+		s.increaseGeneration(contentOrgScope(namespace, orgID))
+	} else {
+		s.increaseGeneration(contentScope(namespace, orgID, appID))
+	}
+}
+
+// InvalidateAllContent invalidates all the cached reads in the namespace, it is used when the changed content is not known
+func (s *CacheAdapter) InvalidateAllContent(namespace string) {
+	s.generationsLock.Lock()
+	defer s.generationsLock.Unlock()
+
+	s.increaseGeneration(namespace)
+}
+
+// generation gives the current generation of the content scope or tag, generationsLock must be held
+func (s *CacheAdapter) generation(key string) uint64 {
+	return s.generations[key].value
+}
+
+// increaseGeneration gives a new generation to the content scope or tag, generationsLock must be held
+func (s *CacheAdapter) increaseGeneration(key string) {
+	now := time.Now()
+	s.counter++
+	s.generations[key] = contentGeneration{value: s.counter, increased: now}
+
+	//the tags which have not got a new generation for longer than the expiration are dropped. A dropped tag has generation 0 again,
+	//which it has had only before it got its first generation, and nothing is cached longer than the expiration.
+	if s.expiration > 0 && now.Sub(s.pruned) > s.expiration {
+		for key, generation := range s.generations {
+			if now.Sub(generation.increased) > s.expiration {
+				delete(s.generations, key)
+			}
+		}
+		s.pruned = now
+	}
+}
+
+func contentOrgScope(namespace string, orgID string) string {
+	return fmt.Sprintf("%s.%q", namespace, orgID)
+}
+
+func contentScope(namespace string, orgID string, appID *string) string {
+	app := "-" //for all the apps in the organization
+	if appID != nil {
+		app = strconv.Quote(*appID)
+	}
+	return fmt.Sprintf("%s.%q.%s", namespace, orgID, app)
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheadapter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContentKeyInvalidation(t *testing.T) {
+	app, otherApp := "app", "other"
+	tests := []struct {
+		name        string
+		appID       *string
+		tags        []string
+		invalidate  func(s *CacheAdapter)
+		wantChanged bool
+	}{
+		{name: "same tag", appID: &app, tags: []string{"id:1", "category:news"},
+			invalidate: func(s *CacheAdapter) {
+				s.InvalidateContent(ContentItemsNamespace, "org", &app, []string{"category:news"})
+			}, wantChanged: true},
+		{name: "other tag", appID: &app, tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) { s.InvalidateContent(ContentItemsNamespace, "org", &app, []string{"id:2"}) }},
+		{name: "all tag", appID: &app, tags: []string{ContentAllTag},
+			invalidate: func(s *CacheAdapter) { s.InvalidateContent(ContentItemsNamespace, "org", &app, []string{"id:2"}) }, wantChanged: true},
+		{name: "other app", appID: &app, tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) { s.InvalidateContent(ContentItemsNamespace, "org", &otherApp, []string{"id:1"}) }},
+		{name: "other organization", appID: &app, tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) { s.InvalidateContent(ContentItemsNamespace, "org2", &app, []string{"id:1"}) }},
+		{name: "other namespace", appID: &app, tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) {
+				s.InvalidateContent(DataContentItemsNamespace, "org", &app, []string{"id:1"})
+			}},
+		{name: "app scope", appID: &app, tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) { s.InvalidateContentScope(ContentItemsNamespace, "org", &app) }, wantChanged: true},
+		{name: "other app scope", appID: &app, tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) { s.InvalidateContentScope(ContentItemsNamespace, "org", &otherApp) }},
+		{name: "organization scope", appID: &app, tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) { s.InvalidateContentScope(ContentItemsNamespace, "org", nil) }, wantChanged: true},
+		{name: "organization scope of all the apps content", tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) { s.InvalidateContentScope(ContentItemsNamespace, "org", nil) }, wantChanged: true},
+		{name: "all the content", appID: &app, tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) { s.InvalidateAllContent(ContentItemsNamespace) }, wantChanged: true},
+		{name: "all the content of other namespace", appID: &app, tags: []string{"id:1"},
+			invalidate: func(s *CacheAdapter) { s.InvalidateAllContent(DataContentItemsNamespace) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCacheAdapter("60")
+			before := s.ContentKey(ContentItemsNamespace, "org", tt.appID, tt.tags, "query")
+			tt.invalidate(s)
+			after := s.ContentKey(ContentItemsNamespace, "org", tt.appID, tt.tags, "query")
+			if (before != after) != tt.wantChanged {
+				t.Errorf("ContentKey() changed = %v, want %v (%s, %s)", before != after, tt.wantChanged, before, after)
+			}
+		})
+	}
+}
+
+func TestContentKeyTagsOrder(t *testing.T) {
+	s := NewCacheAdapter("60")
+	first := s.ContentKey(ContentItemsNamespace, "org", nil, []string{"id:1", "category:news"}, "query")
+	second := s.ContentKey(ContentItemsNamespace, "org", nil, []string{"category:news", "id:1"}, "query")
+	if first != second {
+		t.Errorf("ContentKey() = %s, want %s", second, first)
+	}
+	if other := s.ContentKey(ContentItemsNamespace, "org", nil, []string{"id:1", "category:news"}, "other"); other == first {
+		t.Errorf("ContentKey() = %s for another query", other)
+	}
+}
+
+func TestSetContent(t *testing.T) {
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Minute)
+	tests := []struct {
+		name       string
+		until      *time.Time
+		wantCached bool
+	}{
+		{name: "without until", wantCached: true},
+		{name: "until a later time", until: &future, wantCached: true},
+		{name: "until a past time", until: &past},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCacheAdapter("60")
+			key := s.ContentKey(ContentItemsNamespace, "org", nil, []string{"id:1"}, "query")
+			s.SetContent(key, "content", tt.until)
+			if cached := s.GetContent(key) != nil; cached != tt.wantCached {
+				t.Errorf("GetContent() cached = %v, want %v", cached, tt.wantCached)
+			}
+		})
+	}
+}
+
+func TestContentGenerationsPruning(t *testing.T) {
+	app := "app"
+	tests := []struct {
+		name       string
+		increased  time.Duration // how long ago the tag got its generation
+		wantPruned bool
+	}{
+		{name: "recent", increased: 30 * time.Second},
+		{name: "older than the expiration", increased: 2 * time.Minute, wantPruned: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCacheAdapter("60")
+			s.InvalidateContent(ContentItemsNamespace, "org", &app, []string{"id:1"})
+			before := s.ContentKey(ContentItemsNamespace, "org", &app, []string{"id:1"}, "query")
+			for key, generation := range s.generations {
+				generation.increased = generation.increased.Add(-tt.increased)
+				s.generations[key] = generation
+			}
+			s.pruned = time.Now().Add(-2 * time.Minute)
+
+			s.InvalidateContent(ContentItemsNamespace, "org", &app, []string{"id:2"})
+			_, kept := s.generations[contentScope(ContentItemsNamespace, "org", &app)+`."id:1"`]
+			if kept == tt.wantPruned {
+				t.Errorf("generation kept = %v, want %v", kept, !tt.wantPruned)
+			}
+			//the reads cached with the pruned generation are not given again
+			if after := s.ContentKey(ContentItemsNamespace, "org", &app, []string{"id:1"}, "query"); (after != before) != tt.wantPruned {
+				t.Errorf("ContentKey() = %s, before %s", after, before)
+			}
+		})
+	}
+}
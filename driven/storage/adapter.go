@@ -17,10 +17,16 @@ package storage
 import (
 	"content/core/interfaces"
 	"content/core/model"
+	"content/utils"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -269,6 +275,191 @@ func (sa *Adapter) DeleteHealthLocation(appID string, orgID string, id string) e
 
 // Content Items
 
+// contentItemsFilter gives the filter for the content items within the app/org, optionally limited to ids and categories
+func contentItemsFilter(appID *string, orgID string, ids []string, categoryList []string, dataQuery *model.ContentItemsDataQuery, publishedOnly bool, viewer *model.ContentItemsViewer) bson.D {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		notDeleted(),
+		notExpired(time.Now().UTC())}
+	if len(ids) > 0 {
+		filter = append(filter, primitive.E{Key: "_id", Value: bson.M{"$in": ids}})
+	}
+	if len(categoryList) > 0 {
+		filter = append(filter, primitive.E{Key: "category", Value: bson.M{"$in": categoryList}})
+	}
+	if publishedOnly {
+		filter = append(filter, publishedContentItemsFilter(time.Now().UTC())...)
+	}
+	if dataQuery != nil && len(dataQuery.Filters) > 0 {
+		conditions := bson.A{}
+		for _, dataFilter := range dataQuery.Filters {
+			conditions = append(conditions, dataFilterCondition(dataFilter))
+		}
+		filter = appendAndConditions(filter, conditions)
+	}
+	if viewer != nil {
+		filter = appendAndConditions(filter, contentItemsAudienceConditions(*viewer))
+	}
+	return filter
+}
+
+// appendAndConditions adds the conditions to the $and of the filter, so that they do not override the ones which are already there
+func appendAndConditions(filter bson.D, conditions bson.A) bson.D {
+	for i, e := range filter {
+		if e.Key != "$and" {
+			continue
+		}
+		if existing, ok := e.Value.(bson.A); ok {
+			filter[i].Value = append(existing, conditions...)
+			return filter
+		}
+	}
+	return append(filter, primitive.E{Key: "$and", Value: conditions})
+}
+
+// dataFilterCondition gives the mongo condition for a filter on the content items data.
+// The query values are always strings, so a value which looks like a number or a boolean matches the stored number or boolean too.
+func dataFilterCondition(dataFilter model.DataFilter) bson.M {
+	var condition interface{}
+	switch dataFilter.Operator {
+	case model.DataFilterOperatorEq, model.DataFilterOperatorIn:
+		condition = bson.M{"$in": dataFilterValues(dataFilter.Values)}
+	case model.DataFilterOperatorNe, model.DataFilterOperatorNin:
+		condition = bson.M{"$nin": dataFilterValues(dataFilter.Values)}
+	case model.DataFilterOperatorExists:
+		exists, _ := strconv.ParseBool(dataFilter.Values[0])
+		condition = bson.M{"$exists": exists}
+	default:
+		//gt, gte, lt and lte compare numbers as numbers and everything else as strings
+		var value interface{} = dataFilter.Values[0]
+		if number, err := strconv.ParseFloat(dataFilter.Values[0], 64); err == nil {
+			value = number
+		}
+		condition = bson.M{"$" + dataFilter.Operator: value}
+	}
+	return bson.M{dataFilter.Path: condition}
+}
+
+func dataFilterValues(values []string) bson.A {
+	result := bson.A{}
+	for _, value := range values {
+		result = append(result, value)
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			result = append(result, number)
+		}
+		if value == "true" || value == "false" {
+			result = append(result, value == "true")
+		}
+	}
+	return result
+}
+
+// contentItemsProjection gives the projection for the requested fields. id and date_created are always given as the pagination needs them.
+// The requested data fields are given for the locale variants too, so that the data could be localized.
+func contentItemsProjection(fields []string, locales []string) bson.D {
+	paths := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field == "id" || field == "_id" || field == "date_created" {
+			continue
+		}
+		paths = append(paths, field)
+	}
+	sort.Strings(paths)
+
+	projection := bson.D{primitive.E{Key: "_id", Value: 1}, primitive.E{Key: "date_created", Value: 1}}
+	included := []string{}
+	include := func(path string) {
+		//mongo does not allow a path together with a sub path of it
+		if containsParentPath(included, path) {
+			return
+		}
+		projection = append(projection, primitive.E{Key: path, Value: 1})
+		included = append(included, path)
+	}
+	for _, path := range paths {
+		include(path)
+	}
+
+	//the rendered markdown fields are given for the requested data fields, so that the clients could get them in the requested format
+	for _, path := range paths {
+		if path != "data" && !strings.HasPrefix(path, "data.") {
+			continue
+		}
+		subPath := strings.TrimPrefix(path, "data")
+		for _, locale := range locales {
+			include("locales." + locale + subPath)
+		}
+		for _, format := range []string{model.ContentItemFormatHTML, model.ContentItemFormatText} {
+			include("renderings." + format + "." + path)
+			for _, locale := range locales {
+				include("renderings." + format + ".locales." + locale + subPath)
+			}
+		}
+	}
+	return projection
+}
+
+func containsParentPath(paths []string, path string) bool {
+	for _, parent := range paths {
+		if path == parent || strings.HasPrefix(path, parent+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// notDeleted is the condition for the records which are not in the trash
+func notDeleted() primitive.E {
+	return primitive.E{Key: "date_deleted", Value: nil}
+}
+
+// notExpired is the condition for the records which have not expired yet.
+// The TTL monitor removes the expired records only periodically, so they are skipped until then.
+func notExpired(now time.Time) primitive.E {
+	return primitive.E{Key: "expires_at", Value: bson.M{"$not": bson.M{"$lte": now}}}
+}
+
+// publishedContentItemsFilter gives the conditions for the content items which are published and within their publishing window.
+// The items created before the status was introduced do not have it and they are treated as published.
+func publishedContentItemsFilter(now time.Time) bson.D {
+	return bson.D{
+		primitive.E{Key: "status", Value: bson.M{"$in": bson.A{model.ContentItemStatusPublished, nil}}},
+		primitive.E{Key: "$and", Value: bson.A{
+			bson.M{"$or": bson.A{bson.M{"publish_at": nil}, bson.M{"publish_at": bson.M{"$lte": now}}}},
+			bson.M{"$or": bson.A{bson.M{"expire_at": nil}, bson.M{"expire_at": bson.M{"$gt": now}}}},
+		}},
+	}
+}
+
+// contentItemsAudienceConditions gives the conditions for the content items which the viewer is within the audience of.
+// The items without audience are given to everybody, the items for specific app versions are not given when the viewer has not given its version.
+func contentItemsAudienceConditions(viewer model.ContentItemsViewer) bson.A {
+	anyOf := func(path string, values []string) bson.M {
+		given := bson.A{}
+		for _, value := range values {
+			given = append(given, value)
+		}
+		return bson.M{"$or": bson.A{bson.M{path + ".0": bson.M{"$exists": false}}, bson.M{path: bson.M{"$in": given}}}}
+	}
+	conditions := bson.A{
+		bson.M{"audience.anonymous": bson.M{"$in": bson.A{nil, viewer.Anonymous}}},
+		anyOf("audience.permissions", viewer.Permissions),
+	}
+
+	var appVersion *int64
+	if viewer.AppVersion != nil {
+		if number, err := utils.VersionNumber(*viewer.AppVersion); err == nil {
+			appVersion = &number
+		}
+	}
+	if appVersion == nil {
+		return append(conditions, bson.M{"audience.min_app_version_number": nil}, bson.M{"audience.max_app_version_number": nil})
+	}
+	return append(conditions,
+		bson.M{"$or": bson.A{bson.M{"audience.min_app_version_number": nil}, bson.M{"audience.min_app_version_number": bson.M{"$lte": *appVersion}}}},
+		bson.M{"$or": bson.A{bson.M{"audience.max_app_version_number": nil}, bson.M{"audience.max_app_version_number": bson.M{"$gte": *appVersion}}}})
+}
+
 type getContentItemsCategoriesData struct {
 	CategoryName string `json:"_id" bson:"_id"`
 }
@@ -276,7 +467,8 @@ type getContentItemsCategoriesData struct {
 // GetContentItemsCategories  retrieve all content item categories
 func (sa *Adapter) GetContentItemsCategories(appID *string, orgID string) ([]string, error) {
 	pipeline := primitive.A{
-		bson.M{"$match": bson.M{"app_id": appID, "org_id": orgID}},
+		bson.M{"$match": bson.M{"app_id": appID, "org_id": orgID, "date_deleted": nil,
+			"expires_at": bson.M{"$not": bson.M{"$lte": time.Now().UTC()}}}},
 		bson.M{"$group": bson.M{"_id": "$category"}},
 	}
 	var data []getContentItemsCategoriesData
@@ -296,15 +488,8 @@ func (sa *Adapter) GetContentItemsCategories(appID *string, orgID string) ([]str
 }
 
 // FindContentItems finds content items
-func (sa *Adapter) FindContentItems(appID *string, orgID string, ids []string, categoryList []string, offset *int64, limit *int64, order *string) ([]model.ContentItem, error) {
-	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
-		primitive.E{Key: "org_id", Value: orgID}}
-	if len(ids) > 0 {
-		filter = append(filter, primitive.E{Key: "_id", Value: bson.M{"$in": ids}})
-	}
-	if categoryList != nil && len(categoryList) > 0 {
-		filter = append(filter, primitive.E{Key: "category", Value: bson.M{"$in": categoryList}})
-	}
+func (sa *Adapter) FindContentItems(appID *string, orgID string, ids []string, categoryList []string, offset *int64, limit *int64, order *string, publishedOnly bool) ([]model.ContentItem, error) {
+	filter := contentItemsFilter(appID, orgID, ids, categoryList, nil, publishedOnly, nil)
 
 	findOptions := options.Find()
 	if order != nil && "desc" == *order {
@@ -327,16 +512,63 @@ func (sa *Adapter) FindContentItems(appID *string, orgID string, ids []string, c
 	return result, nil
 }
 
-// GetContentItems retrieves all content items
-func (sa *Adapter) GetContentItems(appID *string, orgID string, ids []string, categoryList []string, offset *int64, limit *int64, order *string) ([]model.ContentItemResponse, error) {
+// FindContentItemsCopies finds the content items which are copies of the items with the source ids
+func (sa *Adapter) FindContentItemsCopies(appID *string, orgID string, sourceIDs []string) ([]model.ContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		notDeleted(),
+		notExpired(time.Now().UTC()),
+		primitive.E{Key: "copied_from", Value: bson.M{"$in": sourceIDs}}}
+
+	var result []model.ContentItem
+	err := sa.db.contentItems.Find(sa.context, filter, &result, options.Find().SetSort(bson.M{"date_created": 1}))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindContentItemsReferencing finds the content items which reference the item with the id.
+// paths gives the data fields which hold the references for every category.
+func (sa *Adapter) FindContentItemsReferencing(appID *string, orgID string, paths map[string][]string, id string) ([]model.ContentItem, error) {
+	conditions := bson.A{}
+	for category, categoryPaths := range paths {
+		for _, path := range categoryPaths {
+			conditions = append(conditions, bson.M{"category": category, "data." + path: id})
+		}
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
 
 	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
-		primitive.E{Key: "org_id", Value: orgID}}
-	if len(ids) > 0 {
-		filter = append(filter, primitive.E{Key: "_id", Value: bson.M{"$in": ids}})
+		primitive.E{Key: "org_id", Value: orgID},
+		notDeleted(),
+		notExpired(time.Now().UTC()),
+		primitive.E{Key: "_id", Value: bson.M{"$ne": id}},
+		primitive.E{Key: "$or", Value: conditions}}
+
+	var result []model.ContentItem
+	err := sa.db.contentItems.Find(sa.context, filter, &result, options.Find().SetSort(bson.M{"date_created": 1}))
+	if err != nil {
+		return nil, err
 	}
-	if categoryList != nil && len(categoryList) > 0 {
-		filter = append(filter, primitive.E{Key: "category", Value: bson.M{"$in": categoryList}})
+	return result, nil
+}
+
+// GetContentItems retrieves all content items
+func (sa *Adapter) GetContentItems(appID *string, orgID string, ids []string, categoryList []string, dataQuery *model.ContentItemsDataQuery, offset *int64, limit *int64, order *string, publishedOnly bool, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, error) {
+	filter := contentItemsFilter(appID, orgID, ids, categoryList, dataQuery, publishedOnly, viewer)
+
+	if order != nil && *order == model.ContentItemsOrderPosition {
+		result, err := sa.findContentItemsByPosition(filter, nil, dataQuery, offset, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result {
+			contentItemPositionOrder(item)
+		}
+		return result, nil
 	}
 
 	findOptions := options.Find()
@@ -345,6 +577,178 @@ func (sa *Adapter) GetContentItems(appID *string, orgID string, ids []string, ca
 	} else {
 		findOptions.SetSort(bson.M{"date_created": 1})
 	}
+	if dataQuery != nil && len(dataQuery.Fields) > 0 {
+		findOptions.SetProjection(contentItemsProjection(dataQuery.Fields, dataQuery.Locales))
+	}
+	if limit != nil {
+		findOptions.SetLimit(*limit)
+	}
+	if offset != nil {
+		findOptions.SetSkip(*offset)
+	}
+
+	var result []model.ContentItemResponse
+	err := sa.db.contentItems.Find(sa.context, filter, &result, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetContentItemsPage retrieves a page of content items starting after the cursor. It also gives the cursor for the next page
+// and the count of all the items which match the filter.
+func (sa *Adapter) GetContentItemsPage(appID *string, orgID string, ids []string, categoryList []string, dataQuery *model.ContentItemsDataQuery, after *model.ContentItemsCursor, limit int64, order *string, publishedOnly bool, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, *model.ContentItemsCursor, int64, error) {
+	filter := contentItemsFilter(appID, orgID, ids, categoryList, dataQuery, publishedOnly, viewer)
+
+	total, err := sa.db.contentItems.CountDocuments(sa.context, filter)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if order != nil && *order == model.ContentItemsOrderPosition {
+		result, err := sa.findContentItemsByPosition(filter, after, dataQuery, nil, &limit)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		var next *model.ContentItemsCursor
+		for i, item := range result {
+			pinned, position := contentItemPositionOrder(item)
+
+			//there could be more items only if the page is full
+			if i == len(result)-1 && int64(len(result)) == limit && limit > 0 {
+				id, _ := item["_id"].(string)
+				dateCreated, _ := item["date_created"].(primitive.DateTime)
+				next = &model.ContentItemsCursor{DateCreated: dateCreated.Time().UTC(), ID: id, Pinned: pinned, Position: &position}
+			}
+		}
+		return result, next, total, nil
+	}
+
+	//date_created is not unique, so _id decides the order of the items created at the same time
+	direction := 1
+	operator := "$gt"
+	if order != nil && "desc" == *order {
+		direction = -1
+		operator = "$lt"
+	}
+	pageFilter := filter
+	if after != nil {
+		pageFilter = append(pageFilter, primitive.E{Key: "$or", Value: bson.A{
+			bson.M{"date_created": bson.M{operator: after.DateCreated}},
+			bson.M{"date_created": after.DateCreated, "_id": bson.M{operator: after.ID}},
+		}})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{primitive.E{Key: "date_created", Value: direction}, primitive.E{Key: "_id", Value: direction}})
+	findOptions.SetLimit(limit)
+	if dataQuery != nil && len(dataQuery.Fields) > 0 {
+		findOptions.SetProjection(contentItemsProjection(dataQuery.Fields, dataQuery.Locales))
+	}
+
+	var result []model.ContentItemResponse
+	err = sa.db.contentItems.Find(sa.context, pageFilter, &result, findOptions)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	//there could be more items only if the page is full
+	var next *model.ContentItemsCursor
+	if int64(len(result)) == limit && limit > 0 {
+		last := result[len(result)-1]
+		id, _ := last["_id"].(string)
+		dateCreated, _ := last["date_created"].(primitive.DateTime)
+		next = &model.ContentItemsCursor{DateCreated: dateCreated.Time().UTC(), ID: id}
+	}
+
+	return result, next, total, nil
+}
+
+// contentItemsPinnedForever is compared with the time when the items are pinned without pinned_until
+var contentItemsPinnedForever = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// findContentItemsByPosition finds the content items in the manual order - the pinned items first, then the items by their position
+// and at last the items without position by date_created. The items are pinned until their pinned_until time.
+// The fields of the order are given in the items as _pinned and _position, the items without position have the last one.
+func (sa *Adapter) findContentItemsByPosition(filter bson.D, after *model.ContentItemsCursor, dataQuery *model.ContentItemsDataQuery, offset *int64, limit *int64) ([]model.ContentItemResponse, error) {
+	pipeline := primitive.A{
+		bson.M{"$match": filter},
+		bson.M{"$addFields": bson.M{
+			"_pinned": bson.M{"$and": bson.A{
+				bson.M{"$eq": bson.A{"$pinned", true}},
+				bson.M{"$lt": bson.A{time.Now().UTC(), bson.M{"$ifNull": bson.A{"$pinned_until", contentItemsPinnedForever}}}},
+			}},
+			"_position": bson.M{"$ifNull": bson.A{"$position", int64(math.MaxInt64)}},
+		}},
+	}
+	if after != nil && after.Position != nil {
+		pipeline = append(pipeline, bson.M{"$match": bson.M{"$or": bson.A{
+			bson.M{"_pinned": bson.M{"$lt": after.Pinned}},
+			bson.M{"_pinned": after.Pinned, "_position": bson.M{"$gt": *after.Position}},
+			bson.M{"_pinned": after.Pinned, "_position": *after.Position, "date_created": bson.M{"$gt": after.DateCreated}},
+			bson.M{"_pinned": after.Pinned, "_position": *after.Position, "date_created": after.DateCreated, "_id": bson.M{"$gt": after.ID}},
+		}}})
+	}
+	pipeline = append(pipeline, bson.M{"$sort": bson.D{primitive.E{Key: "_pinned", Value: -1}, primitive.E{Key: "_position", Value: 1},
+		primitive.E{Key: "date_created", Value: 1}, primitive.E{Key: "_id", Value: 1}}})
+	if offset != nil {
+		pipeline = append(pipeline, bson.M{"$skip": *offset})
+	}
+	if limit != nil {
+		pipeline = append(pipeline, bson.M{"$limit": *limit})
+	}
+	if dataQuery != nil && len(dataQuery.Fields) > 0 {
+		projection := append(contentItemsProjection(dataQuery.Fields, dataQuery.Locales), primitive.E{Key: "_pinned", Value: 1}, primitive.E{Key: "_position", Value: 1})
+		pipeline = append(pipeline, bson.M{"$project": projection})
+	}
+
+	var result []model.ContentItemResponse
+	err := sa.db.contentItems.Aggregate(sa.context, pipeline, &result, &options.AggregateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// contentItemPositionOrder removes the fields of the manual order from the item and gives them
+func contentItemPositionOrder(item model.ContentItemResponse) (bool, int64) {
+	pinned, _ := item["_pinned"].(bool)
+	var position int64
+	switch value := item["_position"].(type) {
+	case int64:
+		position = value
+	case int32:
+		position = int64(value)
+	case float64:
+		position = int64(value)
+	}
+	delete(item, "_pinned")
+	delete(item, "_position")
+	return pinned, position
+}
+
+// SearchContentItems finds the content items which match the text, the most relevant first
+func (sa *Adapter) SearchContentItems(appID *string, orgID string, text string, categoryList []string, offset *int64, limit *int64, publishedOnly bool, viewer *model.ContentItemsViewer) ([]model.ContentItemResponse, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "$text", Value: bson.M{"$search": text}},
+		notDeleted(),
+		notExpired(time.Now().UTC())}
+	if len(categoryList) > 0 {
+		filter = append(filter, primitive.E{Key: "category", Value: bson.M{"$in": categoryList}})
+	}
+	if publishedOnly {
+		filter = append(filter, publishedContentItemsFilter(time.Now().UTC())...)
+	}
+	if viewer != nil {
+		filter = appendAndConditions(filter, contentItemsAudienceConditions(*viewer))
+	}
+
+	findOptions := options.Find()
+	findOptions.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+	findOptions.SetSort(bson.D{primitive.E{Key: "score", Value: bson.M{"$meta": "textScore"}},
+		primitive.E{Key: "date_created", Value: -1}})
 	if limit != nil {
 		findOptions.SetLimit(*limit)
 	}
@@ -360,6 +764,43 @@ func (sa *Adapter) GetContentItems(appID *string, orgID string, ids []string, ca
 	return result, nil
 }
 
+// FindContentItemsMissingLocales finds the content items which do not have a variant for some of the locales
+func (sa *Adapter) FindContentItemsMissingLocales(appID *string, orgID string, categoryList []string, locales []string) ([]model.MissingTranslation, error) {
+	match := bson.M{"app_id": appID, "org_id": orgID, "date_deleted": nil, "$or": missingLocalesConditions(locales),
+		"expires_at": bson.M{"$not": bson.M{"$lte": time.Now().UTC()}}}
+	if len(categoryList) > 0 {
+		match["category"] = bson.M{"$in": categoryList}
+	}
+
+	var result []model.MissingTranslation
+	err := sa.db.contentItems.Aggregate(sa.context, missingLocalesPipeline(match), &result, &options.AggregateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// missingLocalesConditions gives the conditions for an item which does not have a variant for any of the locales
+func missingLocalesConditions(locales []string) bson.A {
+	conditions := bson.A{}
+	for _, locale := range locales {
+		conditions = append(conditions, bson.M{"locales." + locale: bson.M{"$exists": false}})
+	}
+	return conditions
+}
+
+// missingLocalesPipeline gives the matched items with the locales they have, without loading the data
+func missingLocalesPipeline(match bson.M) bson.A {
+	return bson.A{
+		bson.M{"$match": match},
+		bson.M{"$project": bson.M{"category": 1, "key": 1,
+			"locales": bson.M{"$map": bson.M{
+				"input": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$locales", bson.M{}}}},
+				"in":    "$$this.k"}}}},
+		bson.M{"$sort": bson.D{primitive.E{Key: "category", Value: 1}, primitive.E{Key: "_id", Value: 1}}},
+	}
+}
+
 // CreateContentItem creates a new content item record
 func (sa *Adapter) CreateContentItem(item model.ContentItem) (*model.ContentItem, error) {
 	_, err := sa.db.contentItems.InsertOne(sa.context, &item)
@@ -371,11 +812,19 @@ func (sa *Adapter) CreateContentItem(item model.ContentItem) (*model.ContentItem
 }
 
 // GetContentItem retrieves a content item record by id
-func (sa *Adapter) GetContentItem(appID *string, orgID string, id string) (*model.ContentItemResponse, error) {
+func (sa *Adapter) GetContentItem(appID *string, orgID string, id string, publishedOnly bool, viewer *model.ContentItemsViewer) (*model.ContentItemResponse, error) {
 
 	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "_id", Value: id}}
+		primitive.E{Key: "_id", Value: id},
+		notDeleted(),
+		notExpired(time.Now().UTC())}
+	if publishedOnly {
+		filter = append(filter, publishedContentItemsFilter(time.Now().UTC())...)
+	}
+	if viewer != nil {
+		filter = appendAndConditions(filter, contentItemsAudienceConditions(*viewer))
+	}
 	var result []model.ContentItemResponse
 	err := sa.db.contentItems.Find(sa.context, filter, &result, nil)
 	if err != nil {
@@ -392,16 +841,32 @@ func (sa *Adapter) GetContentItem(appID *string, orgID string, id string) (*mode
 
 // UpdateContentItem updates a content item record
 func (sa *Adapter) UpdateContentItem(appID *string, orgID string, id string,
-	category string, data interface{}) (*model.ContentItem, error) {
+	category string, data interface{}, rawData interface{}, renderings map[string]model.ContentItemRendering) (*model.ContentItem, error) {
 	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "_id", Value: id}}
-	update := bson.D{
-		primitive.E{Key: "$set", Value: bson.D{
-			primitive.E{Key: "category", Value: category},
-			primitive.E{Key: "data", Value: data},
-			primitive.E{Key: "date_updated", Value: time.Now().UTC()},
-		}},
+		primitive.E{Key: "_id", Value: id},
+		notDeleted()}
+	set := bson.D{
+		primitive.E{Key: "category", Value: category},
+		primitive.E{Key: "data", Value: data},
+		primitive.E{Key: "date_updated", Value: time.Now().UTC()},
+	}
+	unset := bson.D{}
+	if rawData != nil {
+		set = append(set, primitive.E{Key: "raw_data", Value: rawData})
+	} else {
+		//there is no sanitized rich text
+		unset = append(unset, primitive.E{Key: "raw_data", Value: ""})
+	}
+	if renderings != nil {
+		set = append(set, primitive.E{Key: "renderings", Value: renderings})
+	} else {
+		//there is no rendered markdown
+		unset = append(unset, primitive.E{Key: "renderings", Value: ""})
+	}
+	update := bson.D{primitive.E{Key: "$set", Value: set}, primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}}}
+	if len(unset) > 0 {
+		update = append(update, primitive.E{Key: "$unset", Value: unset})
 	}
 	_, err := sa.db.contentItems.UpdateOne(sa.context, filter, update, nil)
 	if err != nil {
@@ -420,59 +885,735 @@ func (sa *Adapter) UpdateContentItem(appID *string, orgID string, id string,
 		log.Printf("content item with id: %s is not found", id)
 		return nil, fmt.Errorf("content item with id: %s is not found", id)
 	}
-	return &result[0], nil
-}
+	return &result[0], nil
+}
+
+// UpdateContentItemDataFields changes only the given fields of a content item
+func (sa *Adapter) UpdateContentItemDataFields(appID *string, orgID string, id string, dataUpdate model.DataUpdate) (*model.ContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		notDeleted()}
+
+	set := bson.D{}
+	for _, field := range dataUpdate.Set {
+		set = append(set, primitive.E{Key: field.Path, Value: field.Value})
+	}
+	set = append(set, primitive.E{Key: "date_updated", Value: time.Now().UTC()})
+	update := bson.D{
+		primitive.E{Key: "$set", Value: set},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	if len(dataUpdate.Unset) > 0 {
+		unset := bson.D{}
+		for _, path := range dataUpdate.Unset {
+			unset = append(unset, primitive.E{Key: path, Value: ""})
+		}
+		update = append(update, primitive.E{Key: "$unset", Value: unset})
+	}
+
+	result, err := sa.db.contentItems.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		log.Printf("error updating content item data fields: %s", err)
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+
+	//get it to return the updated object
+	var items []model.ContentItem
+	err = sa.db.contentItems.Find(sa.context, filter, &items, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+	return &items[0], nil
+}
+
+// UpdateContentItemStatus updates the status and the publishing window of a content item
+func (sa *Adapter) UpdateContentItemStatus(appID *string, orgID string, id string, status string, publishAt *time.Time, expireAt *time.Time) (*model.ContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		notDeleted()}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: status},
+			primitive.E{Key: "publish_at", Value: publishAt},
+			primitive.E{Key: "expire_at", Value: expireAt},
+			primitive.E{Key: "date_updated", Value: time.Now().UTC()},
+		}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	result, err := sa.db.contentItems.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		log.Printf("error updating content item status: %s", err)
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+
+	//get it to return the updated object
+	var items []model.ContentItem
+	err = sa.db.contentItems.Find(sa.context, filter, &items, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+	return &items[0], nil
+}
+
+// UpdateContentItemAudience updates the audience of a content item, nil removes it
+func (sa *Adapter) UpdateContentItemAudience(appID *string, orgID string, id string, audience *model.ContentItemAudience) (*model.ContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		notDeleted()}
+	set := bson.D{primitive.E{Key: "date_updated", Value: time.Now().UTC()}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: set},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	if audience != nil {
+		update[0].Value = append(set, primitive.E{Key: "audience", Value: audience})
+	} else {
+		update = append(update, primitive.E{Key: "$unset", Value: bson.D{primitive.E{Key: "audience", Value: ""}}})
+	}
+	result, err := sa.db.contentItems.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		log.Printf("error updating content item audience: %s", err)
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+
+	//get it to return the updated object
+	var items []model.ContentItem
+	err = sa.db.contentItems.Find(sa.context, filter, &items, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+	return &items[0], nil
+}
+
+// UpdateContentItemExpiry updates the time when a content item is removed, nil removes the expiry
+func (sa *Adapter) UpdateContentItemExpiry(appID *string, orgID string, id string, expiresAt *time.Time) (*model.ContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		notDeleted()}
+	set := bson.D{primitive.E{Key: "date_updated", Value: time.Now().UTC()}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: set},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	if expiresAt != nil {
+		update[0].Value = append(set, primitive.E{Key: "expires_at", Value: expiresAt.UTC()})
+	} else {
+		update = append(update, primitive.E{Key: "$unset", Value: bson.D{primitive.E{Key: "expires_at", Value: ""}}})
+	}
+	result, err := sa.db.contentItems.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		log.Printf("error updating content item expiry: %s", err)
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+
+	//get it to return the updated object
+	var items []model.ContentItem
+	err = sa.db.contentItems.Find(sa.context, filter, &items, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+	return &items[0], nil
+}
+
+// UpdateContentItemPin pins or unpins a content item
+func (sa *Adapter) UpdateContentItemPin(appID *string, orgID string, id string, pinned bool, pinnedUntil *time.Time) (*model.ContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		notDeleted()}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "pinned", Value: pinned},
+			primitive.E{Key: "pinned_until", Value: pinnedUntil},
+			primitive.E{Key: "date_updated", Value: time.Now().UTC()},
+		}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	result, err := sa.db.contentItems.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		log.Printf("error updating content item pin: %s", err)
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+
+	//get it to return the updated object
+	var items []model.ContentItem
+	err = sa.db.contentItems.Find(sa.context, filter, &items, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("content item with id: %s is not found", id)
+	}
+	return &items[0], nil
+}
+
+// UpdateContentItemPosition sets the position of a content item in the manual order, nil removes it
+func (sa *Adapter) UpdateContentItemPosition(appID *string, orgID string, id string, position *int64) error {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		notDeleted()}
+	set := bson.D{primitive.E{Key: "date_updated", Value: time.Now().UTC()}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: set},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	if position != nil {
+		update[0].Value = append(set, primitive.E{Key: "position", Value: *position})
+	} else {
+		update = append(update, primitive.E{Key: "$unset", Value: bson.D{primitive.E{Key: "position", Value: ""}}})
+	}
+	result, err := sa.db.contentItems.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		log.Printf("error updating content item position: %s", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("content item with id: %s is not found", id)
+	}
+	return nil
+}
+
+// DeleteContentItem moves a content item record with the desired id to the trash
+func (sa *Adapter) DeleteContentItem(appID *string, orgID string, id string) error {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		notDeleted()}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "date_deleted", Value: time.Now().UTC()}}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	result, err := sa.db.contentItems.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return fmt.Errorf("result is nil for resource item with id " + id)
+	}
+	if result.MatchedCount != 1 {
+		return fmt.Errorf("error occured while deleting a resource item with id " + id)
+	}
+	return nil
+}
+
+// FindDeletedContentItems finds the content items which are in the trash, the last deleted first
+func (sa *Adapter) FindDeletedContentItems(appID *string, orgID string, categoryList []string) ([]model.ContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "date_deleted", Value: bson.M{"$ne": nil}},
+		notExpired(time.Now().UTC())}
+	if len(categoryList) > 0 {
+		filter = append(filter, primitive.E{Key: "category", Value: bson.M{"$in": categoryList}})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.M{"date_deleted": -1})
+
+	var result []model.ContentItem
+	err := sa.db.contentItems.Find(sa.context, filter, &result, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindDeletedContentItem finds a content item within the trash by its id. It returns nil if there is no such item
+func (sa *Adapter) FindDeletedContentItem(appID *string, orgID string, id string) (*model.ContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "date_deleted", Value: bson.M{"$ne": nil}}}
+
+	var result []model.ContentItem
+	err := sa.db.contentItems.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
+// RestoreContentItem takes a content item out of the trash
+func (sa *Adapter) RestoreContentItem(appID *string, orgID string, id string) (*model.ContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "date_deleted", Value: bson.M{"$ne": nil}}}
+	update := bson.D{
+		primitive.E{Key: "$unset", Value: bson.D{primitive.E{Key: "date_deleted", Value: ""}}},
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "date_updated", Value: time.Now().UTC()}}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+
+	var result model.ContentItem
+	err := sa.db.contentItems.FindOneAndUpdate(sa.context, filter, update, &result, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("deleted content item with id: %s is not found", id)
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PurgeDeletedContentItems removes for good the content items which have been moved to the trash before the given time together with their revisions
+func (sa *Adapter) PurgeDeletedContentItems(before time.Time) (int64, error) {
+	filter := bson.D{primitive.E{Key: "date_deleted", Value: bson.M{"$lt": before}}}
+
+	findOptions := options.Find()
+	findOptions.SetProjection(bson.M{"_id": 1})
+	var items []bson.M
+	err := sa.db.contentItems.Find(sa.context, filter, &items, findOptions)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+	ids := make([]interface{}, len(items))
+	for i, item := range items {
+		ids[i] = item["_id"]
+	}
+
+	result, err := sa.db.contentItems.DeleteMany(sa.context, bson.D{primitive.E{Key: "_id", Value: bson.M{"$in": ids}},
+		primitive.E{Key: "date_deleted", Value: bson.M{"$lt": before}}}, nil)
+	if err != nil {
+		return 0, err
+	}
+	_, err = sa.db.contentItemRevisions.DeleteMany(sa.context, bson.D{primitive.E{Key: "content_item_id", Value: bson.M{"$in": ids}}}, nil)
+	if err != nil {
+		return result.DeletedCount, err
+	}
+	return result.DeletedCount, nil
+}
+
+// PurgeOrphanedContentItemRevisions removes the revisions of the content items which are not there any more,
+// the TTL monitor removes the expired items without their revisions
+func (sa *Adapter) PurgeOrphanedContentItemRevisions() (int64, error) {
+	pipeline := []bson.M{
+		{"$group": bson.M{"_id": "$content_item_id"}},
+		{"$lookup": bson.M{"from": "content_items", "localField": "_id", "foreignField": "_id", "as": "items"}},
+		{"$match": bson.M{"items": bson.M{"$size": 0}}},
+		{"$project": bson.M{"_id": 1}},
+	}
+	var orphans []bson.M
+	err := sa.db.contentItemRevisions.Aggregate(sa.context, pipeline, &orphans, &options.AggregateOptions{})
+	if err != nil {
+		return 0, err
+	}
+	if len(orphans) == 0 {
+		return 0, nil
+	}
+	ids := make([]interface{}, len(orphans))
+	for i, orphan := range orphans {
+		ids[i] = orphan["_id"]
+	}
+
+	result, err := sa.db.contentItemRevisions.DeleteMany(sa.context, bson.D{primitive.E{Key: "content_item_id", Value: bson.M{"$in": ids}}}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// SaveContentItem saves content item. The item for all the apps does not replace an item of a single app with the same id
+// and the items in the trash are not replaced.
+func (sa *Adapter) SaveContentItem(item model.ContentItem) error {
+	filter := bson.D{primitive.E{Key: "org_id", Value: item.OrgID},
+		primitive.E{Key: "app_id", Value: item.AppID},
+		primitive.E{Key: "_id", Value: item.ID},
+		notDeleted()}
+
+	opts := options.Replace().SetUpsert(true)
+	err := sa.db.contentItems.ReplaceOne(sa.context, filter, item, opts)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindAllContentItems finds all content items
+func (sa *Adapter) FindAllContentItems() ([]model.ContentItemResponse, error) {
+	filter := bson.D{}
+	var result []model.ContentItemResponse
+	err := sa.db.contentItems.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateContentItemRevision stores a previous version of a content item
+func (sa *Adapter) CreateContentItemRevision(item model.ContentItemRevision) error {
+	_, err := sa.db.contentItemRevisions.InsertOne(sa.context, &item)
+	if err != nil {
+		log.Printf("error create content item revision: %s", err)
+		return err
+	}
+	return nil
+}
+
+// CountContentItemRevisions counts the revisions of a content item
+func (sa *Adapter) CountContentItemRevisions(contentItemID string) (int64, error) {
+	filter := bson.D{primitive.E{Key: "content_item_id", Value: contentItemID}}
+	return sa.db.contentItemRevisions.CountDocuments(sa.context, filter)
+}
+
+// FindContentItemRevisions finds the revisions of a content item, the latest first
+func (sa *Adapter) FindContentItemRevisions(appID *string, orgID string, contentItemID string) ([]model.ContentItemRevision, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "content_item_id", Value: contentItemID}}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.M{"revision": -1})
+
+	var result []model.ContentItemRevision
+	err := sa.db.contentItemRevisions.Find(sa.context, filter, &result, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindContentItemRevision finds a revision of a content item. It returns nil if there is no such revision
+func (sa *Adapter) FindContentItemRevision(appID *string, orgID string, contentItemID string, revision int64) (*model.ContentItemRevision, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "content_item_id", Value: contentItemID},
+		primitive.E{Key: "revision", Value: revision}}
+
+	var result []model.ContentItemRevision
+	err := sa.db.contentItemRevisions.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		//not found
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
+// FindContentItemSchemas finds the content item schemas
+func (sa *Adapter) FindContentItemSchemas(appID *string, orgID string) ([]model.ContentItemSchema, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID}}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.M{"category": 1})
+
+	var result []model.ContentItemSchema
+	err := sa.db.contentItemSchemas.Find(sa.context, filter, &result, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindContentItemSchema finds the schema for a category. It returns nil if there is no schema for the category
+func (sa *Adapter) FindContentItemSchema(appID *string, orgID string, category string) (*model.ContentItemSchema, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "category", Value: category}}
+
+	var result []model.ContentItemSchema
+	err := sa.db.contentItemSchemas.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		//not found
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
+// CreateContentItemSchema creates a new content item schema record
+func (sa *Adapter) CreateContentItemSchema(item model.ContentItemSchema) (*model.ContentItemSchema, error) {
+	_, err := sa.db.contentItemSchemas.InsertOne(sa.context, &item)
+	if err != nil {
+		log.Printf("error create content item schema: %s", err)
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateContentItemSchema updates the schema for a category
+func (sa *Adapter) UpdateContentItemSchema(appID *string, orgID string, category string, schema json.RawMessage) (*model.ContentItemSchema, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "category", Value: category}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "schema", Value: schema},
+			primitive.E{Key: "date_updated", Value: time.Now().UTC()},
+		}},
+	}
+	result, err := sa.db.contentItemSchemas.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		log.Printf("error updating content item schema: %s", err)
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("schema for category %s is not found", category)
+	}
+
+	//get it to return the updated object
+	return sa.FindContentItemSchema(appID, orgID, category)
+}
+
+// DeleteContentItemSchema deletes the schema for a category
+func (sa *Adapter) DeleteContentItemSchema(appID *string, orgID string, category string) error {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "category", Value: category}}
+	result, err := sa.db.contentItemSchemas.DeleteOne(sa.context, filter, nil)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return fmt.Errorf("result is nil for content item schema with category " + category)
+	}
+	if result.DeletedCount != 1 {
+		return fmt.Errorf("error occured while deleting a content item schema with category " + category)
+	}
+	return nil
+}
+
+// FindContentItemCategories finds the registered content item categories
+func (sa *Adapter) FindContentItemCategories(appID *string, orgID string) ([]model.ContentItemCategory, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID}}
+
+	var result []model.ContentItemCategory
+	err := sa.db.contentItemCategories.Find(sa.context, filter, &result, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindContentItemCategory finds a registered content item category, it gives nil if it is not registered
+func (sa *Adapter) FindContentItemCategory(appID *string, orgID string, name string) (*model.ContentItemCategory, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "name", Value: name}}
+
+	var result []model.ContentItemCategory
+	err := sa.db.contentItemCategories.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		//not registered
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
+// CreateContentItemCategory registers a content item category
+func (sa *Adapter) CreateContentItemCategory(item model.ContentItemCategory) error {
+	_, err := sa.db.contentItemCategories.InsertOne(sa.context, &item)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("category %s is already registered", item.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateContentItemCategory replaces a registered content item category
+func (sa *Adapter) UpdateContentItemCategory(item model.ContentItemCategory) error {
+	filter := bson.D{primitive.E{Key: "app_id", Value: item.AppID},
+		primitive.E{Key: "org_id", Value: item.OrgID},
+		primitive.E{Key: "_id", Value: item.ID}}
+	return sa.db.contentItemCategories.ReplaceOne(sa.context, filter, item, nil)
+}
+
+// DeleteContentItemCategory removes a content item category from the registry, its content items are kept
+func (sa *Adapter) DeleteContentItemCategory(appID *string, orgID string, name string) error {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "name", Value: name}}
+	result, err := sa.db.contentItemCategories.DeleteOne(sa.context, filter, nil)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount != 1 {
+		return fmt.Errorf("category %s is not registered", name)
+	}
+	return nil
+}
+
+type contentItemsNextTransition struct {
+	Next *time.Time `bson:"next"`
+}
+
+// FindContentItemsNextTransition gives the nearest time after the given one when any of the content items is published, expires, is removed or is unpinned.
+// It gives nil when there is no such time.
+func (sa *Adapter) FindContentItemsNextTransition(appID *string, orgID string, ids []string, categoryList []string, after time.Time) (*time.Time, error) {
+	match := bson.M{"app_id": appID, "org_id": orgID}
+	if len(ids) > 0 {
+		match["_id"] = bson.M{"$in": ids}
+	}
+	if len(categoryList) > 0 {
+		match["category"] = bson.M{"$in": categoryList}
+	}
+	return sa.findContentItemsNextTransition(match, []string{"publish_at", "expire_at", "expires_at", "pinned_until"}, after)
+}
+
+// FindNextContentItemsVisibilityChange gives the nearest time after the given one when any of the content items in all the organizations is published or expires.
+// It gives nil when there is no such time.
+func (sa *Adapter) FindNextContentItemsVisibilityChange(after time.Time) (*time.Time, error) {
+	return sa.findContentItemsNextTransition(bson.M{}, contentItemsVisibilityFields, after)
+}
+
+// FindContentItemsVisibilityChanges gives the update events of the content items in all the organizations which are published or expire after from until to
+func (sa *Adapter) FindContentItemsVisibilityChanges(from time.Time, to time.Time) ([]model.ContentItemEvent, error) {
+	conditions := bson.A{}
+	for _, field := range contentItemsVisibilityFields {
+		conditions = append(conditions, bson.M{field: bson.M{"$gt": from, "$lte": to}})
+	}
+	filter := bson.M{"date_deleted": nil, "$or": conditions}
+
+	var documents []bson.Raw
+	err := sa.db.contentItems.Find(sa.context, filter, &documents, nil)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]model.ContentItemEvent, 0, len(documents))
+	for _, document := range documents {
+		event, err := decodeContentItemEvent(document)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	return events, nil
+}
+
+// contentItemsVisibilityFields are the times when the content items are shown to the clients or hidden from them
+var contentItemsVisibilityFields = []string{"publish_at", "expire_at", "expires_at"}
+
+// findContentItemsNextTransition gives the nearest time after the given one in any of the fields of the matching content items which are not in the trash
+func (sa *Adapter) findContentItemsNextTransition(match bson.M, fields []string, after time.Time) (*time.Time, error) {
+	conditions := bson.A{}
+	upcoming := bson.A{}
+	for _, field := range fields {
+		conditions = append(conditions, bson.M{field: bson.M{"$gt": after}})
+		upcoming = append(upcoming, bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$" + field, after}}, "$" + field, nil}})
+	}
+	match["date_deleted"] = nil
+	match["$or"] = conditions
+
+	pipeline := bson.A{
+		bson.M{"$match": match},
+		bson.M{"$group": bson.M{"_id": nil, "next": bson.M{"$min": bson.M{"$min": upcoming}}}},
+	}
 
-// DeleteContentItem deletes a content item record with the desired id
-func (sa *Adapter) DeleteContentItem(appID *string, orgID string, id string) error {
-	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
-		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "_id", Value: id}}
-	result, err := sa.db.contentItems.DeleteOne(sa.context, filter, nil)
+	var result []contentItemsNextTransition
+	err := sa.db.contentItems.Aggregate(sa.context, pipeline, &result, &options.AggregateOptions{})
 	if err != nil {
-		return err
-	}
-	if result == nil {
-		return fmt.Errorf("result is nil for resource item with id " + id)
+		return nil, err
 	}
-	deletedCount := result.DeletedCount
-	if deletedCount != 1 {
-		return fmt.Errorf("error occured while deleting a resource item with id " + id)
+	if len(result) == 0 {
+		return nil, nil
 	}
-	return nil
+	return result[0].Next, nil
 }
 
-// SaveContentItem saves content item
-func (sa *Adapter) SaveContentItem(item model.ContentItem) error {
-	filter := bson.D{primitive.E{Key: "org_id", Value: item.OrgID},
-		primitive.E{Key: "_id", Value: item.ID}}
-	if item.AppID != nil {
-		filter = append(filter, primitive.E{Key: "app_id", Value: item.AppID})
+// GetContentItemsCategoriesStats gives how many content items every category has and when they have been modified.
+// The deleted items are not counted.
+func (sa *Adapter) GetContentItemsCategoriesStats(appID *string, orgID string) ([]model.ContentItemsCategoryStats, error) {
+	pipeline := primitive.A{
+		bson.M{"$match": bson.M{"app_id": appID, "org_id": orgID, "date_deleted": nil,
+			"expires_at": bson.M{"$not": bson.M{"$lte": time.Now().UTC()}}}},
+		bson.M{"$group": bson.M{
+			"_id":           "$category",
+			"items_count":   bson.M{"$sum": 1},
+			"last_modified": bson.M{"$max": bson.M{"$ifNull": bson.A{"$date_updated", "$date_created"}}},
+		}},
 	}
 
-	opts := options.Replace().SetUpsert(true)
-	err := sa.db.contentItems.ReplaceOne(sa.context, filter, item, opts)
+	var result []model.ContentItemsCategoryStats
+	err := sa.db.contentItems.Aggregate(sa.context, pipeline, &result, &options.AggregateOptions{})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return result, nil
 }
 
-// FindAllContentItems finds all content items
-func (sa *Adapter) FindAllContentItems() ([]model.ContentItemResponse, error) {
-	filter := bson.D{}
-	var result []model.ContentItemResponse
-	err := sa.db.contentItems.Find(sa.context, filter, &result, nil)
+// FindOrgSettings finds the settings of an organization, it gives nil if they have not been set
+func (sa *Adapter) FindOrgSettings(orgID string) (*model.OrgSettings, error) {
+	filter := bson.D{primitive.E{Key: "org_id", Value: orgID}}
+
+	var result []model.OrgSettings
+	err := sa.db.orgSettings.Find(sa.context, filter, &result, nil)
 	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
+// SaveOrgSettings creates or replaces the settings of an organization
+func (sa *Adapter) SaveOrgSettings(item model.OrgSettings) error {
+	filter := bson.D{primitive.E{Key: "org_id", Value: item.OrgID}}
+	return sa.db.orgSettings.ReplaceOne(sa.context, filter, item, options.Replace().SetUpsert(true))
 }
 
 // CreateDataContentItem creates a data content item
 func (sa *Adapter) CreateDataContentItem(item *model.DataContentItem) (*model.DataContentItem, error) {
+	//an expired item which has not been removed by the TTL monitor yet would still hold the key
+	expiredFilter := bson.D{primitive.E{Key: "app_id", Value: item.AppID},
+		primitive.E{Key: "org_id", Value: item.OrgID},
+		primitive.E{Key: "key", Value: item.Key},
+		notDeleted(),
+		primitive.E{Key: "expires_at", Value: bson.M{"$lte": time.Now().UTC()}}}
+	_, err := sa.db.dataContentItems.DeleteMany(sa.context, expiredFilter, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	_, err := sa.db.dataContentItems.InsertOne(sa.context, &item)
+	_, err = sa.db.dataContentItems.InsertOne(sa.context, &item)
 	if err != nil {
 		return nil, err
 	}
@@ -484,7 +1625,9 @@ func (sa *Adapter) FindDataContentItem(appID *string, orgID string, key string)
 
 	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "key", Value: key}}
+		primitive.E{Key: "key", Value: key},
+		notDeleted(),
+		notExpired(time.Now().UTC())}
 
 	var result *model.DataContentItem
 	err := sa.db.dataContentItems.FindOne(sa.context, filter, &result, nil)
@@ -500,10 +1643,14 @@ func (sa *Adapter) FindDataContentItems(appID *string, orgID string, category st
 	if len(category) > 0 {
 		filter = bson.D{primitive.E{Key: "app_id", Value: appID},
 			primitive.E{Key: "org_id", Value: orgID},
-			primitive.E{Key: "category", Value: category}}
+			primitive.E{Key: "category", Value: category},
+			notDeleted(),
+			notExpired(time.Now().UTC())}
 	} else {
 		filter = bson.D{primitive.E{Key: "app_id", Value: appID},
-			primitive.E{Key: "org_id", Value: orgID}}
+			primitive.E{Key: "org_id", Value: orgID},
+			notDeleted(),
+			notExpired(time.Now().UTC())}
 	}
 
 	var result []*model.DataContentItem
@@ -514,19 +1661,40 @@ func (sa *Adapter) FindDataContentItems(appID *string, orgID string, category st
 	return result, nil
 }
 
+// FindDataContentItemsMissingLocales finds the data content items which do not have a variant for some of the locales
+func (sa *Adapter) FindDataContentItemsMissingLocales(appID *string, orgID string, category string, locales []string) ([]model.MissingTranslation, error) {
+	match := bson.M{"app_id": appID, "org_id": orgID, "date_deleted": nil, "$or": missingLocalesConditions(locales),
+		"expires_at": bson.M{"$not": bson.M{"$lte": time.Now().UTC()}}}
+	if len(category) > 0 {
+		match["category"] = category
+	}
+
+	var result []model.MissingTranslation
+	err := sa.db.dataContentItems.Aggregate(sa.context, missingLocalesPipeline(match), &result, &options.AggregateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // UpdateDataContentItem updates a data content item
 func (sa *Adapter) UpdateDataContentItem(appID *string, orgID string, item *model.DataContentItem) (*model.DataContentItem, error) {
 
 	filter := bson.D{
 		primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "key", Value: item.Key}}
+		primitive.E{Key: "key", Value: item.Key},
+		notDeleted(),
+		notExpired(time.Now().UTC())}
 	update := bson.D{
 		primitive.E{Key: "$set", Value: bson.D{
 			primitive.E{Key: "category", Value: item.Category},
 			primitive.E{Key: "data", Value: item.Data},
+			primitive.E{Key: "locales", Value: item.Locales},
+			primitive.E{Key: "expires_at", Value: item.ExpiresAt},
 			primitive.E{Key: "date_updated", Value: time.Now().UTC()},
 		}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
 	}
 	_, err := sa.db.dataContentItems.UpdateOne(sa.context, filter, update, nil)
 	if err != nil {
@@ -537,27 +1705,107 @@ func (sa *Adapter) UpdateDataContentItem(appID *string, orgID string, item *mode
 	return item, nil
 }
 
-// DeleteDataContentItem deletes a data content item
+// DeleteDataContentItem moves a data content item to the trash
 func (sa *Adapter) DeleteDataContentItem(appID *string, orgID string, key string) error {
 
 	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "key", Value: key}}
+		primitive.E{Key: "key", Value: key},
+		notDeleted()}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "date_deleted", Value: time.Now().UTC()}}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
 
-	result, err := sa.db.dataContentItems.DeleteOne(sa.context, filter, nil)
+	result, err := sa.db.dataContentItems.UpdateOne(sa.context, filter, update, nil)
 	if err != nil {
 		return err
 	}
 	if result == nil {
 		return fmt.Errorf("result is nil for data content item with key " + key)
 	}
-	deletedCount := result.DeletedCount
-	if deletedCount != 1 {
+	if result.MatchedCount != 1 {
 		return fmt.Errorf("error occured while deleting a data content item with key " + key)
 	}
 	return nil
 }
 
+// FindDeletedDataContentItems finds the data content items which are in the trash, the last deleted first
+func (sa *Adapter) FindDeletedDataContentItems(appID *string, orgID string, category string) ([]model.DataContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "date_deleted", Value: bson.M{"$ne": nil}},
+		notExpired(time.Now().UTC())}
+	if len(category) > 0 {
+		filter = append(filter, primitive.E{Key: "category", Value: category})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.M{"date_deleted": -1})
+
+	var result []model.DataContentItem
+	err := sa.db.dataContentItems.Find(sa.context, filter, &result, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindDeletedDataContentItem finds a data content item within the trash by its id. It returns nil if there is no such item
+func (sa *Adapter) FindDeletedDataContentItem(appID *string, orgID string, id string) (*model.DataContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "date_deleted", Value: bson.M{"$ne": nil}},
+		notExpired(time.Now().UTC())}
+
+	var result []model.DataContentItem
+	err := sa.db.dataContentItems.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
+// RestoreDataContentItem takes a data content item out of the trash. It fails if there is another item with the same key.
+func (sa *Adapter) RestoreDataContentItem(appID *string, orgID string, id string) (*model.DataContentItem, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "date_deleted", Value: bson.M{"$ne": nil}}}
+	update := bson.D{
+		primitive.E{Key: "$unset", Value: bson.D{primitive.E{Key: "date_deleted", Value: ""}}},
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "date_updated", Value: time.Now().UTC()}}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+
+	var result model.DataContentItem
+	err := sa.db.dataContentItems.FindOneAndUpdate(sa.context, filter, update, &result, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("deleted data content item with id: %s is not found", id)
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("there is another data content item with the key of the deleted one with id: %s", id)
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PurgeDeletedDataContentItems removes for good the data content items which have been moved to the trash before the given time
+func (sa *Adapter) PurgeDeletedDataContentItems(before time.Time) (int64, error) {
+	filter := bson.D{primitive.E{Key: "date_deleted", Value: bson.M{"$lt": before}}}
+	result, err := sa.db.dataContentItems.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
 // CreateCategory created a new category
 func (sa *Adapter) CreateCategory(item *model.Category) (*model.Category, error) {
 
@@ -572,7 +1820,8 @@ func (sa *Adapter) CreateCategory(item *model.Category) (*model.Category, error)
 func (sa *Adapter) FindCategory(appID *string, orgID string, name string) (*model.Category, error) {
 	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "name", Value: name}}
+		primitive.E{Key: "name", Value: name},
+		notDeleted()}
 
 	var result *model.Category
 	err := sa.db.categories.FindOne(sa.context, filter, &result, nil)
@@ -582,18 +1831,39 @@ func (sa *Adapter) FindCategory(appID *string, orgID string, name string) (*mode
 	return result, nil
 }
 
+// FindCategoryByID finds a category by its id
+func (sa *Adapter) FindCategoryByID(appID *string, orgID string, id string) (*model.Category, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		notDeleted()}
+
+	var result []model.Category
+	err := sa.db.categories.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
 // UpdateCategory updates a  category
 func (sa *Adapter) UpdateCategory(appID *string, orgID string, item *model.Category) (*model.Category, error) {
 	filter := bson.D{
 		primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "_id", Value: item.ID}}
+		primitive.E{Key: "_id", Value: item.ID},
+		notDeleted()}
 	update := bson.D{
 		primitive.E{Key: "$set", Value: bson.D{
 			primitive.E{Key: "name", Value: item.Name},
 			primitive.E{Key: "permissions", Value: item.Permissions},
+			primitive.E{Key: "read_access", Value: item.ReadAccess},
 			primitive.E{Key: "date_updated", Value: time.Now().UTC()},
 		}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
 	}
 	_, err := sa.db.categories.UpdateOne(sa.context, filter, update, nil)
 	if err != nil {
@@ -604,26 +1874,283 @@ func (sa *Adapter) UpdateCategory(appID *string, orgID string, item *model.Categ
 	return item, nil
 }
 
-// DeleteCategory deletes a category
+// DeleteCategory moves a category to the trash
 func (sa *Adapter) DeleteCategory(appID *string, orgID string, name string) error {
 	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
 		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "name", Value: name}}
+		primitive.E{Key: "name", Value: name},
+		notDeleted()}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "date_deleted", Value: time.Now().UTC()}}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
 
-	result, err := sa.db.categories.DeleteOne(sa.context, filter, nil)
+	result, err := sa.db.categories.UpdateOne(sa.context, filter, update, nil)
 	if err != nil {
 		return err
 	}
 	if result == nil {
 		return fmt.Errorf("result is nil for cateogry with id " + name)
 	}
-	deletedCount := result.DeletedCount
-	if deletedCount != 1 {
+	if result.MatchedCount != 1 {
 		return fmt.Errorf("error occured while deleting a category with id " + name)
 	}
 	return nil
 }
 
+// FindDeletedCategories finds the categories which are in the trash, the last deleted first
+func (sa *Adapter) FindDeletedCategories(appID *string, orgID string) ([]model.Category, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "date_deleted", Value: bson.M{"$ne": nil}}}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.M{"date_deleted": -1})
+
+	var result []model.Category
+	err := sa.db.categories.Find(sa.context, filter, &result, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RestoreCategory takes a category out of the trash. It fails if there is another category with the same name.
+func (sa *Adapter) RestoreCategory(appID *string, orgID string, id string) (*model.Category, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "date_deleted", Value: bson.M{"$ne": nil}}}
+	update := bson.D{
+		primitive.E{Key: "$unset", Value: bson.D{primitive.E{Key: "date_deleted", Value: ""}}},
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "date_updated", Value: time.Now().UTC()}}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+
+	var result model.Category
+	err := sa.db.categories.FindOneAndUpdate(sa.context, filter, update, &result, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("deleted category with id: %s is not found", id)
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("there is another category with the name of the deleted one with id: %s", id)
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PurgeDeletedCategories removes for good the categories which have been moved to the trash before the given time
+func (sa *Adapter) PurgeDeletedCategories(before time.Time) (int64, error) {
+	filter := bson.D{primitive.E{Key: "date_deleted", Value: bson.M{"$lt": before}}}
+	result, err := sa.db.categories.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// RegisterStorageListener registers a listener for the changes of the stored data
+func (sa *Adapter) RegisterStorageListener(listener interfaces.StorageListener) {
+	sa.db.registerListener(listener)
+}
+
+// CreateAuditLogEntry stores an audit log entry
+func (sa *Adapter) CreateAuditLogEntry(entry model.AuditLogEntry) error {
+	_, err := sa.db.auditLogs.InsertOne(sa.context, &entry)
+	if err != nil {
+		log.Printf("error create audit log entry: %s", err)
+		return err
+	}
+	return nil
+}
+
+// FindAuditLogEntries finds the audit log entries which match the filter, the latest first
+func (sa *Adapter) FindAuditLogEntries(appID *string, orgID string, auditFilter model.AuditLogFilter, offset *int64, limit *int64) ([]model.AuditLogEntry, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID}}
+	if auditFilter.ActorSubject != nil {
+		filter = append(filter, primitive.E{Key: "actor.subject", Value: *auditFilter.ActorSubject})
+	}
+	if auditFilter.ResourceType != nil {
+		filter = append(filter, primitive.E{Key: "resource_type", Value: *auditFilter.ResourceType})
+	}
+	if auditFilter.ResourceID != nil {
+		filter = append(filter, primitive.E{Key: "resource_id", Value: *auditFilter.ResourceID})
+	}
+	if auditFilter.From != nil || auditFilter.To != nil {
+		dateFilter := bson.M{}
+		if auditFilter.From != nil {
+			dateFilter["$gte"] = *auditFilter.From
+		}
+		if auditFilter.To != nil {
+			dateFilter["$lt"] = *auditFilter.To
+		}
+		filter = append(filter, primitive.E{Key: "date_created", Value: dateFilter})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{primitive.E{Key: "date_created", Value: -1}, primitive.E{Key: "_id", Value: -1}})
+	if limit != nil {
+		findOptions.SetLimit(*limit)
+	}
+	if offset != nil {
+		findOptions.SetSkip(*offset)
+	}
+
+	var result []model.AuditLogEntry
+	err := sa.db.auditLogs.Find(sa.context, filter, &result, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateWebhook creates a webhook
+func (sa *Adapter) CreateWebhook(item model.Webhook) error {
+	_, err := sa.db.webhooks.InsertOne(sa.context, &item)
+	return err
+}
+
+// FindWebhooks finds the webhooks
+func (sa *Adapter) FindWebhooks(appID *string, orgID string) ([]model.Webhook, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID}}
+
+	var result []model.Webhook
+	err := sa.db.webhooks.Find(sa.context, filter, &result, options.Find().SetSort(bson.M{"date_created": 1}))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindWebhook finds a webhook, it gives nil if there is no such
+func (sa *Adapter) FindWebhook(appID *string, orgID string, id string) (*model.Webhook, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id}}
+
+	var result []model.Webhook
+	err := sa.db.webhooks.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
+// UpdateWebhook replaces a webhook
+func (sa *Adapter) UpdateWebhook(item model.Webhook) error {
+	filter := bson.D{primitive.E{Key: "app_id", Value: item.AppID},
+		primitive.E{Key: "org_id", Value: item.OrgID},
+		primitive.E{Key: "_id", Value: item.ID}}
+	return sa.db.webhooks.ReplaceOne(sa.context, filter, item, nil)
+}
+
+// DeleteWebhook deletes a webhook and its deliveries
+func (sa *Adapter) DeleteWebhook(appID *string, orgID string, id string) error {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: id}}
+	result, err := sa.db.webhooks.DeleteOne(sa.context, filter, nil)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount != 1 {
+		return fmt.Errorf("webhook with id: %s is not found", id)
+	}
+
+	deliveriesFilter := bson.D{primitive.E{Key: "webhook_id", Value: id}}
+	_, err = sa.db.webhookDeliveries.DeleteMany(sa.context, deliveriesFilter, nil)
+	return err
+}
+
+// CreateWebhookDeliveries stores deliveries which are about to be sent
+func (sa *Adapter) CreateWebhookDeliveries(items []model.WebhookDelivery) error {
+	if len(items) == 0 {
+		return nil
+	}
+	documents := make([]interface{}, len(items))
+	for i, item := range items {
+		documents[i] = item
+	}
+	_, err := sa.db.webhookDeliveries.InsertMany(sa.context, documents, nil)
+	return err
+}
+
+// ClaimWebhookDelivery gives a pending delivery which is due and puts off its next attempt by the lease,
+// so that it is not sent by anyone else in the meantime. It gives nil if there is no such.
+func (sa *Adapter) ClaimWebhookDelivery(now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	filter := bson.D{primitive.E{Key: "status", Value: model.WebhookDeliveryStatusPending},
+		primitive.E{Key: "next_attempt", Value: bson.M{"$lte": now}}}
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "next_attempt", Value: now.Add(lease)}}}}
+	opts := options.FindOneAndUpdate().SetSort(bson.M{"next_attempt": 1}).SetReturnDocument(options.After)
+
+	var result model.WebhookDelivery
+	err := sa.db.webhookDeliveries.FindOneAndUpdate(sa.context, filter, update, &result, opts)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SaveWebhookDelivery replaces a delivery
+func (sa *Adapter) SaveWebhookDelivery(item model.WebhookDelivery) error {
+	filter := bson.D{primitive.E{Key: "_id", Value: item.ID}}
+	return sa.db.webhookDeliveries.ReplaceOne(sa.context, filter, item, nil)
+}
+
+// FindWebhookDeliveries finds the deliveries of a webhook, the latest first
+func (sa *Adapter) FindWebhookDeliveries(appID *string, orgID string, webhookID string, status *string, offset *int64, limit *int64) ([]model.WebhookDelivery, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "webhook_id", Value: webhookID}}
+	if status != nil {
+		filter = append(filter, primitive.E{Key: "status", Value: *status})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{primitive.E{Key: "date_created", Value: -1}, primitive.E{Key: "_id", Value: -1}})
+	if limit != nil {
+		findOptions.SetLimit(*limit)
+	}
+	if offset != nil {
+		findOptions.SetSkip(*offset)
+	}
+
+	var result []model.WebhookDelivery
+	err := sa.db.webhookDeliveries.Find(sa.context, filter, &result, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindWebhookDelivery finds a delivery of a webhook, it gives nil if there is no such
+func (sa *Adapter) FindWebhookDelivery(appID *string, orgID string, webhookID string, id string) (*model.WebhookDelivery, error) {
+	filter := bson.D{primitive.E{Key: "app_id", Value: appID},
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "webhook_id", Value: webhookID},
+		primitive.E{Key: "_id", Value: id}}
+
+	var result []model.WebhookDelivery
+	err := sa.db.webhookDeliveries.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
 // StoreMultiTenancyData stores multi-tenancy to already exisiting data in the collections
 func (sa *Adapter) StoreMultiTenancyData(appID string, orgID string) error {
 
@@ -661,7 +2188,7 @@ func (sa *Adapter) abortTransaction(sessionContext mongo.SessionContext) {
 }
 
 // NewStorageAdapter creates a new storage adapter instance
-func NewStorageAdapter(mongoDBAuth string, mongoDBName string, mongoTimeout string, logger *logs.Logger) *Adapter {
+func NewStorageAdapter(mongoDBAuth string, mongoDBName string, mongoTimeout string, searchDataPaths []string, logger *logs.Logger) *Adapter {
 	timeout, err := strconv.Atoi(mongoTimeout)
 	if err != nil {
 		log.Println("Set default timeout - 500")
@@ -669,7 +2196,8 @@ func NewStorageAdapter(mongoDBAuth string, mongoDBName string, mongoTimeout stri
 	}
 	timeoutMS := time.Millisecond * time.Duration(timeout)
 
-	db := &database{mongoDBAuth: mongoDBAuth, mongoDBName: mongoDBName, mongoTimeout: timeoutMS, logger: logger}
+	db := &database{mongoDBAuth: mongoDBAuth, mongoDBName: mongoDBName, mongoTimeout: timeoutMS,
+		searchDataPaths: searchDataPaths, listenersLock: &sync.RWMutex{}, logger: logger}
 	return &Adapter{db: db}
 }
 
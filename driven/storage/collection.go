@@ -249,6 +249,7 @@ func (collWrapper *collectionWrapper) watch(pipeline interface{}, resumeToken bs
 
 	opts := options.ChangeStream()
 	opts.SetFullDocument(options.UpdateLookup)
+	opts.SetFullDocumentBeforeChange(options.WhenAvailable)
 	if resumeToken != nil {
 		opts.SetResumeAfter(resumeToken)
 	}
@@ -261,11 +262,12 @@ func (collWrapper *collectionWrapper) watch(pipeline interface{}, resumeToken bs
 	}
 	defer cur.Close(ctx)
 
-	var changeDoc map[string]interface{}
 	l.Infof("%s: waiting for changes\n", collWrapper.coll.Name())
 	for cur.Next(ctx) {
+		var changeDoc map[string]interface{}
 		if e := cur.Decode(&changeDoc); e != nil {
 			l.Errorf("error decoding: %s\n", e)
+			continue
 		}
 		collWrapper.database.onDataChanged(changeDoc)
 	}
@@ -277,6 +279,16 @@ func (collWrapper *collectionWrapper) watch(pipeline interface{}, resumeToken bs
 	return cur.ResumeToken(), errors.New("unknown error occurred")
 }
 
+// EnablePreImages keeps the documents as they are before the changes, so that the change stream could give the deleted documents
+func (collWrapper *collectionWrapper) EnablePreImages() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*15000)
+	defer cancel()
+
+	command := bson.D{bson.E{Key: "collMod", Value: collWrapper.coll.Name()},
+		bson.E{Key: "changeStreamPreAndPostImages", Value: bson.M{"enabled": true}}}
+	return collWrapper.coll.Database().RunCommand(ctx, command).Err()
+}
+
 func (collWrapper *collectionWrapper) ListIndexes() ([]bson.M, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*15000)
 	defer cancel()
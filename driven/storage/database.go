@@ -15,8 +15,13 @@
 package storage
 
 import (
+	"content/core/interfaces"
+	"content/core/model"
 	"context"
 	"log"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/rokwire/logging-library-go/v2/logs"
@@ -27,11 +32,15 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const contentItemsSearchIndex string = "content_items_search"
+
 type database struct {
 	mongoDBAuth  string
 	mongoDBName  string
 	mongoTimeout time.Duration
 
+	searchDataPaths []string
+
 	db       *mongo.Database
 	dbClient *mongo.Client
 
@@ -41,6 +50,18 @@ type database struct {
 	dataContentItems *collectionWrapper
 	categories       *collectionWrapper
 
+	contentItemSchemas    *collectionWrapper
+	contentItemRevisions  *collectionWrapper
+	contentItemCategories *collectionWrapper
+	auditLogs             *collectionWrapper
+	orgSettings           *collectionWrapper
+
+	webhooks          *collectionWrapper
+	webhookDeliveries *collectionWrapper
+
+	listeners     []interfaces.StorageListener
+	listenersLock *sync.RWMutex
+
 	logger *logs.Logger
 }
 
@@ -98,6 +119,48 @@ func (m *database) start() error {
 		return err
 	}
 
+	contentItemSchemas := &collectionWrapper{database: m, coll: db.Collection("content_item_schemas")}
+	err = m.applyContentItemSchemasChecks(contentItemSchemas)
+	if err != nil {
+		return err
+	}
+
+	contentItemRevisions := &collectionWrapper{database: m, coll: db.Collection("content_item_revisions")}
+	err = m.applyContentItemRevisionsChecks(contentItemRevisions)
+	if err != nil {
+		return err
+	}
+
+	contentItemCategories := &collectionWrapper{database: m, coll: db.Collection("content_item_categories")}
+	err = m.applyContentItemCategoriesChecks(contentItemCategories)
+	if err != nil {
+		return err
+	}
+
+	auditLogs := &collectionWrapper{database: m, coll: db.Collection("audit_logs")}
+	err = m.applyAuditLogsChecks(auditLogs)
+	if err != nil {
+		return err
+	}
+
+	orgSettings := &collectionWrapper{database: m, coll: db.Collection("org_settings")}
+	err = m.applyOrgSettingsChecks(orgSettings)
+	if err != nil {
+		return err
+	}
+
+	webhooks := &collectionWrapper{database: m, coll: db.Collection("webhooks")}
+	err = m.applyWebhooksChecks(webhooks)
+	if err != nil {
+		return err
+	}
+
+	webhookDeliveries := &collectionWrapper{database: m, coll: db.Collection("webhook_deliveries")}
+	err = m.applyWebhookDeliveriesChecks(webhookDeliveries)
+	if err != nil {
+		return err
+	}
+
 	//asign the db, db client and the collections
 	m.db = db
 	m.dbClient = client
@@ -107,6 +170,18 @@ func (m *database) start() error {
 	m.contentItems = contentItems
 	m.dataContentItems = dataContentItems
 	m.categories = categories
+	m.contentItemSchemas = contentItemSchemas
+	m.contentItemRevisions = contentItemRevisions
+	m.contentItemCategories = contentItemCategories
+	m.auditLogs = auditLogs
+	m.orgSettings = orgSettings
+	m.webhooks = webhooks
+	m.webhookDeliveries = webhookDeliveries
+
+	//push the content changes to the listeners
+	for _, collection := range []*collectionWrapper{m.contentItems, m.dataContentItems, m.categories, m.contentItemSchemas, m.contentItemCategories} {
+		go collection.Watch(contentChangesPipeline, m.logger)
+	}
 
 	return nil
 }
@@ -161,19 +236,146 @@ func (m *database) applyContentItemsChecks(contentItems *collectionWrapper) erro
 		return err
 	}
 
+	// Add status index
+	err = contentItems.AddIndex(bson.D{primitive.E{Key: "status", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// Add date_deleted index
+	err = contentItems.AddIndex(bson.D{primitive.E{Key: "date_deleted", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// Add copied_from index
+	err = contentItems.AddIndex(bson.D{primitive.E{Key: "copied_from", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// Add publish_at and expire_at indexes, the items which are published or expire at their scheduled times are looked for by them
+	err = contentItems.AddIndex(bson.D{primitive.E{Key: "publish_at", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+	err = contentItems.AddIndex(bson.D{primitive.E{Key: "expire_at", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//the removed items are given to the listeners from their pre-images, they are not available before MongoDB 6.0
+	err = contentItems.EnablePreImages()
+	if err != nil {
+		log.Printf("error enabling the pre-images of the content items, their removal is not given to the listeners - %s", err)
+	}
+
+	// Add expires_at TTL index, the items are removed once the time passes
+	err = contentItems.AddIndexWithOptions(bson.D{primitive.E{Key: "expires_at", Value: 1}}, options.Index().SetExpireAfterSeconds(0))
+	if err != nil {
+		return err
+	}
+
+	// Add search text index
+	err = m.applyContentItemsSearchIndex(contentItems)
+	if err != nil {
+		return err
+	}
+
 	log.Println("content_items checks passed")
 	return nil
 }
 
+// applyContentItemsSearchIndex creates the text index over the configured data paths.
+// A collection could have only one text index, so the existing one is dropped if the paths have been changed.
+func (m *database) applyContentItemsSearchIndex(contentItems *collectionWrapper) error {
+	weights := bson.M{}
+	keys := bson.D{}
+	for _, path := range m.searchDataPaths {
+		path = strings.TrimSpace(path)
+		if len(path) == 0 {
+			continue
+		}
+		keys = append(keys, primitive.E{Key: "data." + path, Value: "text"})
+		weights["data."+path] = int32(1)
+	}
+
+	indexes, err := contentItems.ListIndexes()
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		key := indexDocument(index["key"])
+		if key["_fts"] != "text" {
+			continue
+		}
+		if index["name"] == contentItemsSearchIndex && reflect.DeepEqual(normalizeIndexWeights(index["weights"]), weights) {
+			//already there
+			return nil
+		}
+		log.Printf("dropping the content_items text index %s as the search paths have been changed", index["name"])
+		name, _ := index["name"].(string)
+		err = contentItems.DropIndex(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+	return contentItems.AddIndexWithOptions(keys, options.Index().SetName(contentItemsSearchIndex))
+}
+
+func normalizeIndexWeights(weights interface{}) bson.M {
+	result := bson.M{}
+	for key, value := range indexDocument(weights) {
+		switch v := value.(type) {
+		case int32:
+			result[key] = v
+		case int64:
+			result[key] = int32(v)
+		case float64:
+			result[key] = int32(v)
+		}
+	}
+	return result
+}
+
 func (m *database) applyDataContentItemsChecks(dataContentItems *collectionWrapper) error {
 	log.Println("apply data_content_items checks.....")
 
-	//Add org_id + app_id index
-	err := dataContentItems.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "key", Value: 1}}, true)
+	//the key is unique among the items which are not in the trash, so the index which does not have date_deleted is not needed anymore
+	err := dropIndexIfExists(dataContentItems, "org_id_1_app_id_1_key_1")
+	if err != nil {
+		return err
+	}
+
+	//Add org_id + app_id + key + date_deleted index
+	err = dataContentItems.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "key", Value: 1},
+		primitive.E{Key: "date_deleted", Value: 1}}, true)
 	if err != nil {
 		return err
 	}
 
+	// Add date_deleted index
+	err = dataContentItems.AddIndex(bson.D{primitive.E{Key: "date_deleted", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// Add expires_at TTL index, the items are removed once the time passes
+	err = dataContentItems.AddIndexWithOptions(bson.D{primitive.E{Key: "expires_at", Value: 1}}, options.Index().SetExpireAfterSeconds(0))
+	if err != nil {
+		return err
+	}
+
+	//the removed documents are given to the listeners from their pre-images, they are not available before MongoDB 6.0
+	err = dataContentItems.EnablePreImages()
+	if err != nil {
+		log.Printf("error enabling the pre-images of the data content items, the listeners do not know what has been removed - %s", err)
+	}
+
 	log.Println("data_content_items checks passed")
 	return nil
 }
@@ -181,33 +383,371 @@ func (m *database) applyDataContentItemsChecks(dataContentItems *collectionWrapp
 func (m *database) applyCategoriesChecks(categories *collectionWrapper) error {
 	log.Println("apply categories checks.....")
 
-	//Add org_id + app_id index
-	err := categories.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "name", Value: 1}}, true)
+	//the name is unique among the categories which are not in the trash, so the index which does not have date_deleted is not needed anymore
+	err := dropIndexIfExists(categories, "org_id_1_app_id_1_name_1")
+	if err != nil {
+		return err
+	}
+
+	//Add org_id + app_id + name + date_deleted index
+	err = categories.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "name", Value: 1},
+		primitive.E{Key: "date_deleted", Value: 1}}, true)
 	if err != nil {
 		return err
 	}
 
+	// Add date_deleted index
+	err = categories.AddIndex(bson.D{primitive.E{Key: "date_deleted", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//the removed documents are given to the listeners from their pre-images, they are not available before MongoDB 6.0
+	err = categories.EnablePreImages()
+	if err != nil {
+		log.Printf("error enabling the pre-images of the categories, the listeners do not know what has been removed - %s", err)
+	}
+
 	log.Println("categories checks passed")
 	return nil
 }
 
+func (m *database) applyContentItemSchemasChecks(contentItemSchemas *collectionWrapper) error {
+	log.Println("apply content_item_schemas checks.....")
+
+	//Add org_id + app_id + category index
+	err := contentItemSchemas.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "category", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	//the removed documents are given to the listeners from their pre-images, they are not available before MongoDB 6.0
+	err = contentItemSchemas.EnablePreImages()
+	if err != nil {
+		log.Printf("error enabling the pre-images of the content item schemas, the listeners do not know what has been removed - %s", err)
+	}
+
+	log.Println("content_item_schemas checks passed")
+	return nil
+}
+
+func (m *database) applyContentItemCategoriesChecks(contentItemCategories *collectionWrapper) error {
+	log.Println("apply content_item_categories checks.....")
+
+	//Add org_id + app_id + name index
+	err := contentItemCategories.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "name", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	//the removed documents are given to the listeners from their pre-images, they are not available before MongoDB 6.0
+	err = contentItemCategories.EnablePreImages()
+	if err != nil {
+		log.Printf("error enabling the pre-images of the content item categories, the listeners do not know what has been removed - %s", err)
+	}
+
+	log.Println("content_item_categories checks passed")
+	return nil
+}
+
+func (m *database) applyOrgSettingsChecks(orgSettings *collectionWrapper) error {
+	log.Println("apply org_settings checks.....")
+
+	//Add org_id index
+	err := orgSettings.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	log.Println("org_settings checks passed")
+	return nil
+}
+
+func (m *database) applyContentItemRevisionsChecks(contentItemRevisions *collectionWrapper) error {
+	log.Println("apply content_item_revisions checks.....")
+
+	//Add content_item_id + revision index
+	err := contentItemRevisions.AddIndex(bson.D{primitive.E{Key: "content_item_id", Value: 1}, primitive.E{Key: "revision", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	//Add org_id + app_id index
+	err = contentItemRevisions.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("content_item_revisions checks passed")
+	return nil
+}
+
+func (m *database) applyAuditLogsChecks(auditLogs *collectionWrapper) error {
+	log.Println("apply audit_logs checks.....")
+
+	//Add org_id + app_id + date_created index
+	err := auditLogs.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "date_created", Value: -1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//Add actor.subject index
+	err = auditLogs.AddIndex(bson.D{primitive.E{Key: "actor.subject", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//Add resource_type + resource_id index
+	err = auditLogs.AddIndex(bson.D{primitive.E{Key: "resource_type", Value: 1}, primitive.E{Key: "resource_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("audit_logs checks passed")
+	return nil
+}
+
+func (m *database) applyWebhooksChecks(webhooks *collectionWrapper) error {
+	log.Println("apply webhooks checks.....")
+
+	//Add org_id + app_id index
+	err := webhooks.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "app_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("webhooks checks passed")
+	return nil
+}
+
+func (m *database) applyWebhookDeliveriesChecks(webhookDeliveries *collectionWrapper) error {
+	log.Println("apply webhook_deliveries checks.....")
+
+	//Add status + next_attempt index
+	err := webhookDeliveries.AddIndex(bson.D{primitive.E{Key: "status", Value: 1}, primitive.E{Key: "next_attempt", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	//Add webhook_id + date_created index
+	err = webhookDeliveries.AddIndex(bson.D{primitive.E{Key: "webhook_id", Value: 1}, primitive.E{Key: "date_created", Value: -1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("webhook_deliveries checks passed")
+	return nil
+}
+
+// dropIndexIfExists drops the index with the given name if the collection has it
+func dropIndexIfExists(coll *collectionWrapper, name string) error {
+	indexes, err := coll.ListIndexes()
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if index["name"] == name {
+			log.Printf("dropping the %s index", name)
+			return coll.DropIndex(name)
+		}
+	}
+	return nil
+}
+
+// indexDocument gives the nested document from the index description as a map
+func indexDocument(value interface{}) bson.M {
+	switch v := value.(type) {
+	case bson.M:
+		return v
+	case bson.D:
+		result := bson.M{}
+		for _, element := range v {
+			result[element.Key] = element.Value
+		}
+		return result
+	}
+	return bson.M{}
+}
+
 // Event
 
+// contentChangesPipeline gives the changes of the stored content, the removed documents are given from their pre-images
+var contentChangesPipeline = bson.A{
+	bson.M{"$match": bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}}},
+}
+
+type changeEvent struct {
+	ID struct {
+		Data string `bson:"_data"`
+	} `bson:"_id"`
+	Ns struct {
+		Coll string `bson:"coll"`
+	} `bson:"ns"`
+	DocumentKey struct {
+		ID string `bson:"_id"`
+	} `bson:"documentKey"`
+	OperationType            string   `bson:"operationType"`
+	FullDocument             bson.Raw `bson:"fullDocument"`
+	FullDocumentBeforeChange bson.Raw `bson:"fullDocumentBeforeChange"`
+	UpdateDescription        *struct {
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+}
+
+func (m *database) registerListener(listener interfaces.StorageListener) {
+	m.listenersLock.Lock()
+	defer m.listenersLock.Unlock()
+
+	m.listeners = append(m.listeners, listener)
+}
+
 func (m *database) onDataChanged(changeDoc map[string]interface{}) {
 	if changeDoc == nil {
 		return
 	}
-	log.Printf("onDataChanged: %+v\n", changeDoc)
-	ns := changeDoc["ns"]
-	if ns == nil {
+	data, err := bson.Marshal(changeDoc)
+	if err != nil {
+		log.Printf("error on encoding change - %s", err)
+		return
+	}
+	var change changeEvent
+	err = bson.Unmarshal(data, &change)
+	if err != nil {
+		log.Printf("error on decoding change - %s", err)
+		return
+	}
+
+	switch change.Ns.Coll {
+	case "content_items":
+		m.onContentItemChanged(change)
+	case "data_content_items":
+		m.onDataContentItemChanged(change)
+	case model.ContentSettingsCategories, model.ContentSettingsContentItemCategories, model.ContentSettingsContentItemSchemas:
+		m.onContentSettingsChanged(change)
+	default:
+		log.Printf("%s collection changed", change.Ns.Coll)
+	}
+}
+
+func (m *database) onContentItemChanged(change changeEvent) {
+	document := change.FullDocument
+	if change.OperationType == "delete" {
+		//the item has been purged or removed by the TTL index, it is known only from its pre-image
+		document = change.FullDocumentBeforeChange
+	}
+	if len(document) == 0 {
+		//the item has been removed before its change could be looked up or there is no pre-image, the listeners know only its id
+		m.notifyContentItemChanged(model.ContentItemEvent{ID: change.ID.Data, Type: model.ContentItemEventDeleted, ItemID: change.DocumentKey.ID})
+		return
+	}
+
+	event, err := decodeContentItemEvent(document)
+	if err != nil {
+		log.Printf("error on decoding changed content item - %s", err)
 		return
 	}
-	nsMap := ns.(map[string]interface{})
-	coll := nsMap["coll"]
+	event.ID = change.ID.Data
+	if change.OperationType != "delete" && len(change.FullDocumentBeforeChange) > 0 {
+		var previous model.ContentItem
+		err = bson.Unmarshal(change.FullDocumentBeforeChange, &previous)
+		if err != nil {
+			log.Printf("error on decoding the previous content item - %s", err)
+		} else {
+			event.Previous = &previous
+		}
+	}
+	switch {
+	case change.OperationType == "delete" || event.Content.DateDeleted != nil:
+		event.Type = model.ContentItemEventDeleted
+	case change.OperationType == "insert":
+		event.Type = model.ContentItemEventCreated
+	case change.UpdateDescription != nil:
+		for _, field := range change.UpdateDescription.RemovedFields {
+			if field == "date_deleted" {
+				//restored from the trash
+				event.Type = model.ContentItemEventCreated
+			}
+		}
+	}
+
+	m.notifyContentItemChanged(*event)
+}
+
+func (m *database) notifyContentItemChanged(event model.ContentItemEvent) {
+	m.listenersLock.RLock()
+	defer m.listenersLock.RUnlock()
+
+	for _, listener := range m.listeners {
+		listener.OnContentItemChanged(event)
+	}
+}
+
+func (m *database) onDataContentItemChanged(change changeEvent) {
+	var event model.DataContentItemEvent
+	if change.OperationType != "delete" && len(change.FullDocument) > 0 {
+		event.Current = &model.DataContentItem{}
+		err := bson.Unmarshal(change.FullDocument, event.Current)
+		if err != nil {
+			log.Printf("error on decoding changed data content item - %s", err)
+			event.Current = nil
+		}
+	}
+	if change.OperationType != "insert" && len(change.FullDocumentBeforeChange) > 0 {
+		event.Previous = &model.DataContentItem{}
+		err := bson.Unmarshal(change.FullDocumentBeforeChange, event.Previous)
+		if err != nil {
+			log.Printf("error on decoding the previous data content item - %s", err)
+			event.Previous = nil
+		}
+	}
+
+	m.listenersLock.RLock()
+	defer m.listenersLock.RUnlock()
+
+	for _, listener := range m.listeners {
+		listener.OnDataContentItemChanged(event)
+	}
+}
+
+func (m *database) onContentSettingsChanged(change changeEvent) {
+	settingsChange := model.ContentSettingsChange{Settings: change.Ns.Coll}
+	document := change.FullDocument
+	if len(document) == 0 {
+		document = change.FullDocumentBeforeChange
+	}
+	if len(document) > 0 {
+		var scope struct {
+			OrgID string  `bson:"org_id"`
+			AppID *string `bson:"app_id"`
+		}
+		err := bson.Unmarshal(document, &scope)
+		if err != nil {
+			log.Printf("error on decoding changed %s - %s", change.Ns.Coll, err)
+		} else {
+			settingsChange.OrgID = &scope.OrgID
+			settingsChange.AppID = scope.AppID
+		}
+	}
 
-	if "configs" == coll {
-		log.Println("configs collection changed")
-	} else {
-		log.Println("other collection changed")
+	m.listenersLock.RLock()
+	defer m.listenersLock.RUnlock()
+
+	for _, listener := range m.listeners {
+		listener.OnContentSettingsChanged(settingsChange)
+	}
+}
+
+// decodeContentItemEvent gives the update event of the stored content item
+func decodeContentItemEvent(document bson.Raw) (*model.ContentItemEvent, error) {
+	var item model.ContentItem
+	err := bson.Unmarshal(document, &item)
+	if err != nil {
+		return nil, err
+	}
+	var response model.ContentItemResponse
+	err = bson.Unmarshal(document, &response)
+	if err != nil {
+		return nil, err
 	}
+	return &model.ContentItemEvent{Type: model.ContentItemEventUpdated, ItemID: item.ID, Category: item.Category, Item: response, Content: &item}, nil
 }
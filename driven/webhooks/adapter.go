@@ -0,0 +1,77 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"bytes"
+	"content/utils"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// maxResponseBody is how much of the response body is kept for the delivery attempts log
+const maxResponseBody = 1024
+
+// Adapter sends the webhook deliveries
+type Adapter struct {
+	client *http.Client
+}
+
+// NewWebhooksAdapter creates new instance
+func NewWebhooksAdapter(timeout time.Duration) *Adapter {
+	//the deliveries are sent directly and only to the public addresses, so that the webhooks cannot reach the internal network
+	dialer := &net.Dialer{Timeout: timeout, Control: publicAddressOnly}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &Adapter{client: &http.Client{Timeout: timeout, Transport: transport}}
+}
+
+// publicAddressOnly does not allow connecting to the addresses which are not public. It is called with the resolved address for every connection, the redirected ones too.
+func publicAddressOnly(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !utils.IsPublicIP(net.ParseIP(host)) {
+		return fmt.Errorf("the address %s is not public", host)
+	}
+	return nil
+}
+
+// Send posts the body to the url. It gives the response status code and the beginning of the response body.
+func (a *Adapter) Send(url string, headers map[string]string, body []byte) (int, string, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, string(responseBody), nil
+}
@@ -107,6 +107,8 @@ func (we Adapter) Start() {
 	contentRouter.HandleFunc("/health_locations", we.coreAuthWrapFunc(we.apisHandler.GetHealthLocations, we.auth.coreAuth.standardAuth)).Methods("GET")
 	contentRouter.HandleFunc("/health_locations/{id}", we.coreAuthWrapFunc(we.apisHandler.GetHealthLocation, we.auth.coreAuth.standardAuth)).Methods("GET")
 	contentRouter.HandleFunc("/content_items", we.coreAuthWrapFunc(we.apisHandler.GetContentItems, we.auth.coreAuth.standardAuth)).Methods("GET", "POST")
+	contentRouter.HandleFunc("/content_items/search", we.coreAuthWrapFunc(we.apisHandler.SearchContentItems, we.auth.coreAuth.standardAuth)).Methods("GET")
+	contentRouter.HandleFunc("/content_items/stream", we.coreAuthWrapFunc(we.apisHandler.GetContentItemsStream, we.auth.coreAuth.standardAuth)).Methods("GET")
 	contentRouter.HandleFunc("/content_items/{id}", we.coreAuthWrapFunc(we.apisHandler.GetContentItem, we.auth.coreAuth.standardAuth)).Methods("GET")
 	contentRouter.HandleFunc("/content_item/categories", we.coreAuthWrapFunc(we.apisHandler.GetContentItemsCategories, we.auth.coreAuth.standardAuth)).Methods("GET")
 	contentRouter.HandleFunc("/image", we.coreAuthWrapFunc(we.apisHandler.UploadImage, we.auth.coreAuth.userAuth)).Methods("POST")
@@ -123,6 +125,9 @@ func (we Adapter) Start() {
 	adminSubRouter := contentRouter.PathPrefix("/admin").Subrouter()
 
 	adminSubRouter.HandleFunc("/data", we.coreAuthWrapFunc(we.adminApisHandler.CreateDataContentItem, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/data/missing_translations", we.coreAuthWrapFunc(we.adminApisHandler.GetDataContentItemsMissingTranslations, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/data/trash", we.coreAuthWrapFunc(we.adminApisHandler.GetDeletedDataContentItems, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/data/trash/{id}/restore", we.coreAuthWrapFunc(we.adminApisHandler.RestoreDataContentItem, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/data/{key}", we.coreAuthWrapFunc(we.adminApisHandler.GetDataContentItem, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/data", we.coreAuthWrapFunc(we.adminApisHandler.GetDataContentItems, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/data", we.coreAuthWrapFunc(we.adminApisHandler.UpdateDataContentItem, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
@@ -133,6 +138,8 @@ func (we Adapter) Start() {
 	adminSubRouter.HandleFunc("/files", we.coreAuthWrapFunc(we.adminApisHandler.DeleteFileContentItem, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
 
 	adminSubRouter.HandleFunc("/categories", we.coreAuthWrapFunc(we.adminApisHandler.CreateCategory, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/categories/trash", we.coreAuthWrapFunc(we.adminApisHandler.GetDeletedCategories, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/categories/trash/{id}/restore", we.coreAuthWrapFunc(we.adminApisHandler.RestoreCategory, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/categories/{name}", we.coreAuthWrapFunc(we.adminApisHandler.GetCategory, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/categories", we.coreAuthWrapFunc(we.adminApisHandler.UpdateCategory, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
 	adminSubRouter.HandleFunc("/categories/{name}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteCategory, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
@@ -156,47 +163,99 @@ func (we Adapter) Start() {
 	adminSubRouter.HandleFunc("/v2/health_locations", we.coreAuthWrapFunc(we.adminApisHandler.GetHealthLocationsV2, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/v2/health_locations", we.coreAuthWrapFunc(we.adminApisHandler.CreateHealthLocationV2, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/v2/health_locations/{id}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateHealthLocationV2, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/v2/health_locations/{id}", we.coreAuthWrapFunc(we.adminApisHandler.PatchHealthLocationV2, we.auth.coreAuth.permissionsAuth)).Methods("PATCH")
 	adminSubRouter.HandleFunc("/v2/health_locations/{id}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteHealthLocationV2, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
 
 	adminSubRouter.HandleFunc("/v2/student_guides", we.coreAuthWrapFunc(we.adminApisHandler.GetStudentGuidesV2, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/v2/student_guides", we.coreAuthWrapFunc(we.adminApisHandler.CreateStudentGuidesV2, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/v2/student_guides/{id}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateStudentGuidesV2, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/v2/student_guides/{id}", we.coreAuthWrapFunc(we.adminApisHandler.PatchStudentGuidesV2, we.auth.coreAuth.permissionsAuth)).Methods("PATCH")
 	adminSubRouter.HandleFunc("/v2/student_guides/{id}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteStudentGuidesV2, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
 
 	adminSubRouter.HandleFunc("/wellness_tips", we.coreAuthWrapFunc(we.adminApisHandler.GetWellnessTips, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/wellness_tips", we.coreAuthWrapFunc(we.adminApisHandler.CreateWellnessTips, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/wellness_tips/{id}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateWellnessTips, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/wellness_tips/{id}", we.coreAuthWrapFunc(we.adminApisHandler.PatchWellnessTips, we.auth.coreAuth.permissionsAuth)).Methods("PATCH")
 	adminSubRouter.HandleFunc("/wellness_tips/{id}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteWellnessTips, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
 
 	adminSubRouter.HandleFunc("/campus_reminders", we.coreAuthWrapFunc(we.adminApisHandler.GetCampusReminders, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/campus_reminders", we.coreAuthWrapFunc(we.adminApisHandler.CreateCampusReminder, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/campus_reminders/{id}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateCampusReminder, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/campus_reminders/{id}", we.coreAuthWrapFunc(we.adminApisHandler.PatchCampusReminder, we.auth.coreAuth.permissionsAuth)).Methods("PATCH")
 	adminSubRouter.HandleFunc("/campus_reminders/{id}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteCampusReminder, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
 
 	adminSubRouter.HandleFunc("/gies_onboarding_checklists", we.coreAuthWrapFunc(we.adminApisHandler.GetGiesOnboardingChecklists, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/gies_onboarding_checklists", we.coreAuthWrapFunc(we.adminApisHandler.CreateGiesOnboardingChecklist, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/gies_onboarding_checklists/{id}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateGiesOnboardingChecklist, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/gies_onboarding_checklists/{id}", we.coreAuthWrapFunc(we.adminApisHandler.PatchGiesOnboardingChecklist, we.auth.coreAuth.permissionsAuth)).Methods("PATCH")
 	adminSubRouter.HandleFunc("/gies_onboarding_checklists/{id}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteGiesOnboardingChecklist, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
 
 	adminSubRouter.HandleFunc("/uiuc_onboarding_checklists", we.coreAuthWrapFunc(we.adminApisHandler.GetUIUCOnboardingChecklists, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/uiuc_onboarding_checklists", we.coreAuthWrapFunc(we.adminApisHandler.CreateUIUCOnboardingChecklist, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/uiuc_onboarding_checklists/{id}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateUIUCOnboardingChecklist, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/uiuc_onboarding_checklists/{id}", we.coreAuthWrapFunc(we.adminApisHandler.PatchUIUCOnboardingChecklist, we.auth.coreAuth.permissionsAuth)).Methods("PATCH")
 	adminSubRouter.HandleFunc("/uiuc_onboarding_checklists/{id}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteUIUCOnboardingChecklist, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
 
 	adminSubRouter.HandleFunc("/gies_post_templates", we.coreAuthWrapFunc(we.adminApisHandler.GetGiesPostTemplates, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/gies_post_templates", we.coreAuthWrapFunc(we.adminApisHandler.CreateGiesPostTemplate, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/gies_post_templates/{id}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateGiesPostTemplate, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/gies_post_templates/{id}", we.coreAuthWrapFunc(we.adminApisHandler.PatchGiesPostTemplate, we.auth.coreAuth.permissionsAuth)).Methods("PATCH")
 	adminSubRouter.HandleFunc("/gies_post_templates/{id}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteGiesPostTemplate, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
 
 	adminSubRouter.HandleFunc("/content_items", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItems, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/content_items", we.coreAuthWrapFunc(we.adminApisHandler.CreateContentItem, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/content_items/search", we.coreAuthWrapFunc(we.adminApisHandler.SearchContentItems, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_items/missing_translations", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItemsMissingTranslations, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_items/batch", we.coreAuthWrapFunc(we.adminApisHandler.BatchContentItems, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/content_items/export", we.coreAuthWrapFunc(we.adminApisHandler.ExportContentItems, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_items/import", we.coreAuthWrapFunc(we.adminApisHandler.ImportContentItems, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/content_items/copy", we.coreAuthWrapFunc(we.adminApisHandler.CopyContentItems, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/content_items/reorder", we.coreAuthWrapFunc(we.adminApisHandler.ReorderContentItems, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/content_items/trash", we.coreAuthWrapFunc(we.adminApisHandler.GetDeletedContentItems, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_items/trash/{id}/restore", we.coreAuthWrapFunc(we.adminApisHandler.RestoreContentItem, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/content_items/{id}", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItem, we.auth.coreAuth.permissionsAuth)).Methods("GET")
 	adminSubRouter.HandleFunc("/content_items/{id}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateContentItem, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/content_items/{id}", we.coreAuthWrapFunc(we.adminApisHandler.PatchContentItem, we.auth.coreAuth.permissionsAuth)).Methods("PATCH")
 	adminSubRouter.HandleFunc("/content_items/{id}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteContentItem, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
+	adminSubRouter.HandleFunc("/content_items/{id}/status", we.coreAuthWrapFunc(we.adminApisHandler.UpdateContentItemStatus, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/content_items/{id}/audience", we.coreAuthWrapFunc(we.adminApisHandler.UpdateContentItemAudience, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/content_items/{id}/expiry", we.coreAuthWrapFunc(we.adminApisHandler.UpdateContentItemExpiry, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/content_items/{id}/pin", we.coreAuthWrapFunc(we.adminApisHandler.UpdateContentItemPin, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/content_items/{id}/locales/{locale}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateContentItemLocale, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/content_items/{id}/locales/{locale}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteContentItemLocale, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
+	adminSubRouter.HandleFunc("/content_items/{id}/references", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItemReferences, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_items/{id}/revisions", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItemRevisions, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_items/{id}/revisions/diff", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItemRevisionsDiff, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_items/{id}/revisions/{revision}/restore", we.coreAuthWrapFunc(we.adminApisHandler.RestoreContentItemRevision, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 	adminSubRouter.HandleFunc("/content_item/categories", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItemsCategories, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_item/schemas", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItemSchemas, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_item/schemas", we.coreAuthWrapFunc(we.adminApisHandler.CreateContentItemSchema, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/content_item/schemas/{category}", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItemSchema, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_item/schemas/{category}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateContentItemSchema, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/content_item/schemas/{category}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteContentItemSchema, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
+	adminSubRouter.HandleFunc("/content_item/category_registry", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItemCategories, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_item/category_registry", we.coreAuthWrapFunc(we.adminApisHandler.CreateContentItemCategory, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/content_item/category_registry/{name}", we.coreAuthWrapFunc(we.adminApisHandler.GetContentItemCategory, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/content_item/category_registry/{name}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateContentItemCategory, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/content_item/category_registry/{name}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteContentItemCategory, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
 
 	adminSubRouter.HandleFunc("/image", we.coreAuthWrapFunc(we.adminApisHandler.UploadImage, we.auth.coreAuth.permissionsAuth)).Methods("POST")
 
+	adminSubRouter.HandleFunc("/audit_logs", we.coreAuthWrapFunc(we.adminApisHandler.GetAuditLogEntries, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+
+	adminSubRouter.HandleFunc("/settings", we.coreAuthWrapFunc(we.adminApisHandler.GetOrgSettings, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/settings", we.coreAuthWrapFunc(we.adminApisHandler.UpdateOrgSettings, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+
+	adminSubRouter.HandleFunc("/webhooks", we.coreAuthWrapFunc(we.adminApisHandler.GetWebhooks, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/webhooks", we.coreAuthWrapFunc(we.adminApisHandler.CreateWebhook, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/webhooks/{id}", we.coreAuthWrapFunc(we.adminApisHandler.GetWebhook, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/webhooks/{id}", we.coreAuthWrapFunc(we.adminApisHandler.UpdateWebhook, we.auth.coreAuth.permissionsAuth)).Methods("PUT")
+	adminSubRouter.HandleFunc("/webhooks/{id}", we.coreAuthWrapFunc(we.adminApisHandler.DeleteWebhook, we.auth.coreAuth.permissionsAuth)).Methods("DELETE")
+	adminSubRouter.HandleFunc("/webhooks/{id}/secret", we.coreAuthWrapFunc(we.adminApisHandler.RotateWebhookSecret, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+	adminSubRouter.HandleFunc("/webhooks/{id}/deliveries", we.coreAuthWrapFunc(we.adminApisHandler.GetWebhookDeliveries, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/webhooks/{id}/deliveries/{delivery-id}", we.coreAuthWrapFunc(we.adminApisHandler.GetWebhookDelivery, we.auth.coreAuth.permissionsAuth)).Methods("GET")
+	adminSubRouter.HandleFunc("/webhooks/{id}/deliveries/{delivery-id}/replay", we.coreAuthWrapFunc(we.adminApisHandler.ReplayWebhookDelivery, we.auth.coreAuth.permissionsAuth)).Methods("POST")
+
 	// handle bbs apis
 	bbsSubRouter := contentRouter.PathPrefix("/bbs").Subrouter()
 	bbsSubRouter.HandleFunc("/image", we.authWrapFunc(we.bbsApisHandler.UploadImage, we.auth.bbs.Permissions)).Methods("POST")
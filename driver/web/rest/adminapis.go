@@ -15,6 +15,7 @@
 package rest
 
 import (
+	"bufio"
 	"content/core"
 	"content/core/model"
 	"encoding/json"
@@ -25,6 +26,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
@@ -396,7 +398,10 @@ func (h AdminApisHandler) DeleteHealthLocation(claims *tokenauth.Claims, w http.
 // @Param ids query string false "Comma separated IDs of the desired records"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit - limit the result"
-// @Param order query string false "order - Possible values: asc, desc. Default: desc"
+// @Param order query string false "order - Possible values: asc, desc, position. Default: desc. position is the manual order - the pinned items first, then the items by their position and at last the items without position."
+// @Param cursor query string false "cursor - Pass it empty for the first page and then the next_cursor from the previous page. When it is passed the response is a page of items with the total count and offset is ignored."
+// @Param data.{path} query string false "Filter on a data field - data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu. Operators: eq (default), ne, in, nin, gt, gte, lt, lte, exists. The values of in and nin are coma separated."
+// @Param fields query string false "fields - Coma separated fields to give back, for example id,data.title. id and date_created are always given. All the fields are given by default."
 // @Accept json
 // @Success 200 {array} model.ContentItem
 // @Security AdminUserAuth
@@ -432,6 +437,21 @@ func (h AdminApisHandler) UpdateHealthLocationV2(claims *tokenauth.Claims, w htt
 	h.updateContentItemByCategory(claims, w, r, "health_locations")
 }
 
+// PatchHealthLocationV2 Changes some of the data fields of a health location with the specified id
+// @Description Changes some of the data fields of a health location with the specified id. The body is either a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) - it is told by the Content-Type or, for application/json, by whether the body is an object or a list.
+// @Tags Admin
+// @ID AdminPatchHealthLocationV2
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Accept application/merge-patch+json,application/json-patch+json,json
+// @Produce json
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/v2/health_locations/{id} [patch]
+func (h AdminApisHandler) PatchHealthLocationV2(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	h.patchContentItemByCategory(claims, w, r, "health_locations")
+}
+
 // DeleteHealthLocationV2 Deletes a health location with the specified id
 // @Description Deletes a health location with the specified id
 // @Tags Admin
@@ -452,7 +472,10 @@ func (h AdminApisHandler) DeleteHealthLocationV2(claims *tokenauth.Claims, w htt
 // @Param ids query string false "Comma separated IDs of the desired records"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit - limit the result"
-// @Param order query string false "order - Possible values: asc, desc. Default: desc"
+// @Param order query string false "order - Possible values: asc, desc, position. Default: desc. position is the manual order - the pinned items first, then the items by their position and at last the items without position."
+// @Param cursor query string false "cursor - Pass it empty for the first page and then the next_cursor from the previous page. When it is passed the response is a page of items with the total count and offset is ignored."
+// @Param data.{path} query string false "Filter on a data field - data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu. Operators: eq (default), ne, in, nin, gt, gte, lt, lte, exists. The values of in and nin are coma separated."
+// @Param fields query string false "fields - Coma separated fields to give back, for example id,data.title. id and date_created are always given. All the fields are given by default."
 // @Accept json
 // @Success 200 {array} model.ContentItem
 // @Security AdminUserAuth
@@ -488,6 +511,21 @@ func (h AdminApisHandler) UpdateStudentGuidesV2(claims *tokenauth.Claims, w http
 	h.updateContentItemByCategory(claims, w, r, "student_guides")
 }
 
+// PatchStudentGuidesV2 Changes some of the data fields of a student guide with the specified id
+// @Description Changes some of the data fields of a student guide with the specified id. The body is either a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) - it is told by the Content-Type or, for application/json, by whether the body is an object or a list.
+// @Tags Admin
+// @ID AdminPatchStudentGuidesV2
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Accept application/merge-patch+json,application/json-patch+json,json
+// @Produce json
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/v2/student_guides/{id} [patch]
+func (h AdminApisHandler) PatchStudentGuidesV2(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	h.patchContentItemByCategory(claims, w, r, "student_guides")
+}
+
 // DeleteStudentGuidesV2 Deletes a student guide with the specified id
 // @Description Deletes a student guide with the specified id
 // @Tags Admin
@@ -508,7 +546,10 @@ func (h AdminApisHandler) DeleteStudentGuidesV2(claims *tokenauth.Claims, w http
 // @Param ids query string false "Comma separated IDs of the desired records"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit - limit the result"
-// @Param order query string false "order - Possible values: asc, desc. Default: desc"
+// @Param order query string false "order - Possible values: asc, desc, position. Default: desc. position is the manual order - the pinned items first, then the items by their position and at last the items without position."
+// @Param cursor query string false "cursor - Pass it empty for the first page and then the next_cursor from the previous page. When it is passed the response is a page of items with the total count and offset is ignored."
+// @Param data.{path} query string false "Filter on a data field - data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu. Operators: eq (default), ne, in, nin, gt, gte, lt, lte, exists. The values of in and nin are coma separated."
+// @Param fields query string false "fields - Coma separated fields to give back, for example id,data.title. id and date_created are always given. All the fields are given by default."
 // @Accept json
 // @Success 200 {array} model.ContentItem
 // @Security AdminUserAuth
@@ -544,6 +585,21 @@ func (h AdminApisHandler) UpdateWellnessTips(claims *tokenauth.Claims, w http.Re
 	h.updateContentItemByCategory(claims, w, r, "wellness_tips")
 }
 
+// PatchWellnessTips Changes some of the data fields of a wellness tip with the specified id
+// @Description Changes some of the data fields of a wellness tip with the specified id. The body is either a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) - it is told by the Content-Type or, for application/json, by whether the body is an object or a list.
+// @Tags Admin
+// @ID AdminPatchWellnessTip
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Accept application/merge-patch+json,application/json-patch+json,json
+// @Produce json
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/wellness_tips/{id} [patch]
+func (h AdminApisHandler) PatchWellnessTips(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	h.patchContentItemByCategory(claims, w, r, "wellness_tips")
+}
+
 // DeleteWellnessTips Deletes a wellness tip with the specified id
 // @Description Deletes a wellness tip with the specified id
 // @Tags Admin
@@ -564,7 +620,10 @@ func (h AdminApisHandler) DeleteWellnessTips(claims *tokenauth.Claims, w http.Re
 // @Param ids query string false "Comma separated IDs of the desired records"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit - limit the result"
-// @Param order query string false "order - Possible values: asc, desc. Default: desc"
+// @Param order query string false "order - Possible values: asc, desc, position. Default: desc. position is the manual order - the pinned items first, then the items by their position and at last the items without position."
+// @Param cursor query string false "cursor - Pass it empty for the first page and then the next_cursor from the previous page. When it is passed the response is a page of items with the total count and offset is ignored."
+// @Param data.{path} query string false "Filter on a data field - data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu. Operators: eq (default), ne, in, nin, gt, gte, lt, lte, exists. The values of in and nin are coma separated."
+// @Param fields query string false "fields - Coma separated fields to give back, for example id,data.title. id and date_created are always given. All the fields are given by default."
 // @Accept json
 // @Success 200 {array} model.ContentItem
 // @Security AdminUserAuth
@@ -600,6 +659,21 @@ func (h AdminApisHandler) UpdateCampusReminder(claims *tokenauth.Claims, w http.
 	h.updateContentItemByCategory(claims, w, r, "campus_reminders")
 }
 
+// PatchCampusReminder Changes some of the data fields of a campus reminder with the specified id
+// @Description Changes some of the data fields of a campus reminder with the specified id. The body is either a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) - it is told by the Content-Type or, for application/json, by whether the body is an object or a list.
+// @Tags Admin
+// @ID AdminPatchCampusReminder
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Accept application/merge-patch+json,application/json-patch+json,json
+// @Produce json
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/campus_reminders/{id} [patch]
+func (h AdminApisHandler) PatchCampusReminder(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	h.patchContentItemByCategory(claims, w, r, "campus_reminders")
+}
+
 // DeleteCampusReminder Deletes a campus reminder with the specified id
 // @Description Deletes a campus reminder with the specified id
 // @Tags Admin
@@ -620,7 +694,10 @@ func (h AdminApisHandler) DeleteCampusReminder(claims *tokenauth.Claims, w http.
 // @Param ids query string false "Comma separated IDs of the desired records"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit - limit the result"
-// @Param order query string false "order - Possible values: asc, desc. Default: desc"
+// @Param order query string false "order - Possible values: asc, desc, position. Default: desc. position is the manual order - the pinned items first, then the items by their position and at last the items without position."
+// @Param cursor query string false "cursor - Pass it empty for the first page and then the next_cursor from the previous page. When it is passed the response is a page of items with the total count and offset is ignored."
+// @Param data.{path} query string false "Filter on a data field - data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu. Operators: eq (default), ne, in, nin, gt, gte, lt, lte, exists. The values of in and nin are coma separated."
+// @Param fields query string false "fields - Coma separated fields to give back, for example id,data.title. id and date_created are always given. All the fields are given by default."
 // @Accept json
 // @Success 200 {array} model.ContentItem
 // @Security AdminUserAuth
@@ -656,6 +733,21 @@ func (h AdminApisHandler) UpdateGiesOnboardingChecklist(claims *tokenauth.Claims
 	h.updateContentItemByCategory(claims, w, r, "gies_onboarding_checklists")
 }
 
+// PatchGiesOnboardingChecklist Changes some of the data fields of a gies onboarding checklist with the specified id
+// @Description Changes some of the data fields of a gies onboarding checklist with the specified id. The body is either a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) - it is told by the Content-Type or, for application/json, by whether the body is an object or a list.
+// @Tags Admin
+// @ID AdminPatchGiesOnboardingChecklist
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Accept application/merge-patch+json,application/json-patch+json,json
+// @Produce json
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/gies_onboarding_checklists/{id} [patch]
+func (h AdminApisHandler) PatchGiesOnboardingChecklist(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	h.patchContentItemByCategory(claims, w, r, "gies_onboarding_checklists")
+}
+
 // DeleteGiesOnboardingChecklist Deletes a gies onboarding checklist with the specified id
 // @Description Deletes a gies onboarding checklist with the specified id
 // @Tags Admin
@@ -676,7 +768,10 @@ func (h AdminApisHandler) DeleteGiesOnboardingChecklist(claims *tokenauth.Claims
 // @Param ids query string false "Comma separated IDs of the desired records"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit - limit the result"
-// @Param order query string false "order - Possible values: asc, desc. Default: desc"
+// @Param order query string false "order - Possible values: asc, desc, position. Default: desc. position is the manual order - the pinned items first, then the items by their position and at last the items without position."
+// @Param cursor query string false "cursor - Pass it empty for the first page and then the next_cursor from the previous page. When it is passed the response is a page of items with the total count and offset is ignored."
+// @Param data.{path} query string false "Filter on a data field - data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu. Operators: eq (default), ne, in, nin, gt, gte, lt, lte, exists. The values of in and nin are coma separated."
+// @Param fields query string false "fields - Coma separated fields to give back, for example id,data.title. id and date_created are always given. All the fields are given by default."
 // @Accept json
 // @Success 200 {array} model.ContentItem
 // @Security AdminUserAuth
@@ -712,6 +807,21 @@ func (h AdminApisHandler) UpdateUIUCOnboardingChecklist(claims *tokenauth.Claims
 	h.updateContentItemByCategory(claims, w, r, "uiuc_onboarding_checklists")
 }
 
+// PatchUIUCOnboardingChecklist Changes some of the data fields of an uiuc onboarding checklist with the specified id
+// @Description Changes some of the data fields of an uiuc onboarding checklist with the specified id. The body is either a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) - it is told by the Content-Type or, for application/json, by whether the body is an object or a list.
+// @Tags Admin
+// @ID AdminPatchUIUCOnboardingChecklist
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Accept application/merge-patch+json,application/json-patch+json,json
+// @Produce json
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/uiuc_onboarding_checklists/{id} [patch]
+func (h AdminApisHandler) PatchUIUCOnboardingChecklist(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	h.patchContentItemByCategory(claims, w, r, "uiuc_onboarding_checklists")
+}
+
 // DeleteUIUCOnboardingChecklist Deletes a uiuc onboarding checklist with the specified id
 // @Description Deletes a uiuc onboarding checklist with the specified id
 // @Tags Admin
@@ -732,7 +842,10 @@ func (h AdminApisHandler) DeleteUIUCOnboardingChecklist(claims *tokenauth.Claims
 // @Param ids query string false "Comma separated IDs of the desired records"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit - limit the result"
-// @Param order query string false "order - Possible values: asc, desc. Default: desc"
+// @Param order query string false "order - Possible values: asc, desc, position. Default: desc. position is the manual order - the pinned items first, then the items by their position and at last the items without position."
+// @Param cursor query string false "cursor - Pass it empty for the first page and then the next_cursor from the previous page. When it is passed the response is a page of items with the total count and offset is ignored."
+// @Param data.{path} query string false "Filter on a data field - data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu. Operators: eq (default), ne, in, nin, gt, gte, lt, lte, exists. The values of in and nin are coma separated."
+// @Param fields query string false "fields - Coma separated fields to give back, for example id,data.title. id and date_created are always given. All the fields are given by default."
 // @Accept json
 // @Success 200 {array} model.ContentItem
 // @Security AdminUserAuth
@@ -768,6 +881,21 @@ func (h AdminApisHandler) UpdateGiesPostTemplate(claims *tokenauth.Claims, w htt
 	h.updateContentItemByCategory(claims, w, r, "gies_post_templates")
 }
 
+// PatchGiesPostTemplate Changes some of the data fields of a gies post template with the specified id
+// @Description Changes some of the data fields of a gies post template with the specified id. The body is either a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) - it is told by the Content-Type or, for application/json, by whether the body is an object or a list.
+// @Tags Admin
+// @ID AdminPatchGiesPostTemplate
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Accept application/merge-patch+json,application/json-patch+json,json
+// @Produce json
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/gies_post_templates/{id} [patch]
+func (h AdminApisHandler) PatchGiesPostTemplate(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	h.patchContentItemByCategory(claims, w, r, "gies_post_templates")
+}
+
 // DeleteGiesPostTemplate Deletes a gies post template with the specified id
 // @Description Deletes a gies post template with the specified id
 // @Tags Admin
@@ -821,7 +949,47 @@ func (h AdminApisHandler) getContentItemsByCategory(claims *tokenauth.Claims, w
 
 	categories := []string{category}
 
-	resData, err := h.app.Services.GetContentItems(allApps, claims.AppID, claims.OrgID, IDs, categories, offset, limit, order)
+	paginate, cursor, err := getCursorQueryParam(r)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = checkCursorOrder(cursor, order)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dataQuery, err := getDataQueryParams(r)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if paginate {
+		page, err := h.app.Services.GetContentItemsPage(allApps, claims.AppID, claims.OrgID, IDs, categories, dataQuery, cursor, limit, order, false, nil, "", 0, nil)
+		if err != nil {
+			log.Printf("Error on getting content items page - %s\n", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		data, err := json.Marshal(page)
+		if err != nil {
+			log.Println("Error on marshal the content items page")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+		return
+	}
+
+	resData, err := h.app.Services.GetContentItems(allApps, claims.AppID, claims.OrgID, IDs, categories, dataQuery, offset, limit, order, false, nil, "", 0, nil)
 	if err != nil {
 		log.Printf("Error on cgetting content items - %s\n", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -846,8 +1014,14 @@ func (h AdminApisHandler) getContentItemsByCategory(claims *tokenauth.Claims, w
 
 // createContentItemByCategoryRequestBody Expected body while creating a new content item
 type createContentItemByCategoryRequestBody struct {
-	AllApps bool        `json:"all_apps"`
-	Data    interface{} `json:"data" bson:"data"`
+	AllApps   bool        `json:"all_apps"`
+	Data      interface{} `json:"data" bson:"data"`
+	Status    string      `json:"status"` // draft, published or archived. It is published by default
+	PublishAt *time.Time  `json:"publish_at"`
+	ExpireAt  *time.Time  `json:"expire_at"`
+	ExpiresAt *time.Time  `json:"expires_at"` // the item is removed at this time
+
+	Locales map[string]interface{} `json:"locales"` // the data for other locales, for example es or es-mx
 } // @name createContentItemByCategoryRequestBody
 
 func (h AdminApisHandler) createContentItemByCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request, category string) {
@@ -859,9 +1033,14 @@ func (h AdminApisHandler) createContentItemByCategory(claims *tokenauth.Claims,
 		return
 	}
 
-	createdItem, err := h.app.Services.CreateContentItem(item.AllApps, claims.AppID, claims.OrgID, category, item.Data)
+	contentItem := model.ContentItem{Category: category, Data: item.Data, Status: item.Status,
+		PublishAt: item.PublishAt, ExpireAt: item.ExpireAt, ExpiresAt: item.ExpiresAt, Locales: item.Locales}
+	createdItem, err := h.app.Services.CreateContentItem(claims, item.AllApps, contentItem)
 	if err != nil {
 		log.Printf("Error on creating content item: %s\n", err)
+		if handleSchemaValidationError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -902,9 +1081,22 @@ func (h AdminApisHandler) updateContentItemByCategory(claims *tokenauth.Claims,
 		return
 	}
 
-	resData, err := h.app.Services.UpdateContentItemData(item.AllApps, claims.AppID, claims.OrgID, id, category, item.Data)
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on updating content item with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	resData, err := h.app.Services.UpdateContentItemData(claims, item.AllApps, id, category, item.Data, version)
 	if err != nil {
 		log.Printf("Error on updating content item with id - %s\n %s", id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		if handleSchemaValidationError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -916,11 +1108,16 @@ func (h AdminApisHandler) updateContentItemByCategory(claims *tokenauth.Claims,
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(resData.Version))
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(jsonData)
 }
 
+func (h AdminApisHandler) patchContentItemByCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request, category string) {
+	h.patchContentItem(claims, w, r, category)
+}
+
 func (h AdminApisHandler) deleteContentItemByCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request, category string) {
 	//get all-apps param value
 	allApps := false //false by defautl
@@ -932,9 +1129,19 @@ func (h AdminApisHandler) deleteContentItemByCategory(claims *tokenauth.Claims,
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	err := h.app.Services.DeleteContentItemByCategory(allApps, claims.AppID, claims.OrgID, id, category)
+	version, err := getIfMatchVersion(r)
 	if err != nil {
 		log.Printf("Error on deleting content item with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	err = h.app.Services.DeleteContentItemByCategory(claims, allApps, id, category, version)
+	if err != nil {
+		log.Printf("Error on deleting content item with id - %s\n %s", id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -1013,7 +1220,7 @@ func (h AdminApisHandler) UploadImage(claims *tokenauth.Claims, w http.ResponseW
 	}
 
 	// pass the file to be processed by the use case handler
-	url, err := h.app.Services.UploadImage(fileBytes, path, imgSpec)
+	url, err := h.app.Services.UploadImage(claims, fileBytes, path, imgSpec)
 	if err != nil {
 		log.Printf("Error converting image: %s\n", err)
 		http.Error(w, "Error converting image", http.StatusInternalServerError)
@@ -1045,7 +1252,10 @@ type getContentItemsRequestBody struct {
 // @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
 // @Param offset query string false "offset"
 // @Param limit query string false "limit - limit the result"
-// @Param order query string false "order - Possible values: asc, desc. Default: desc"
+// @Param order query string false "order - Possible values: asc, desc, position. Default: desc. position is the manual order - the pinned items first, then the items by their position and at last the items without position."
+// @Param cursor query string false "cursor - Pass it empty for the first page and then the next_cursor from the previous page. When it is passed the response is a page of items with the total count and offset is ignored."
+// @Param data.{path} query string false "Filter on a data field - data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu. Operators: eq (default), ne, in, nin, gt, gte, lt, lte, exists. The values of in and nin are coma separated."
+// @Param fields query string false "fields - Coma separated fields to give back, for example id,data.title. id and date_created are always given. All the fields are given by default."
 // @Param data body getContentItemsRequestBody false "Optional - body json of the all items ids that need to be filtered. NOTE: Bad/broken json will be interpreted as an empty filter and the request will be proceeded further."
 // @Accept json
 // @Success 200 {array} model.ContentItem
@@ -1089,7 +1299,47 @@ func (h AdminApisHandler) GetContentItems(claims *tokenauth.Claims, w http.Respo
 		log.Printf("Warning: bad getContentItemsRequestBody request: %s", bodyErr)
 	}
 
-	resData, err := h.app.Services.GetContentItems(allApps, claims.AppID, claims.OrgID, item.IDs, item.Categories, offset, limit, order)
+	paginate, cursor, err := getCursorQueryParam(r)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = checkCursorOrder(cursor, order)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dataQuery, err := getDataQueryParams(r)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if paginate {
+		page, err := h.app.Services.GetContentItemsPage(allApps, claims.AppID, claims.OrgID, item.IDs, item.Categories, dataQuery, cursor, limit, order, false, nil, "", 0, nil)
+		if err != nil {
+			log.Printf("Error on getting content items page - %s\n", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		data, err := json.Marshal(page)
+		if err != nil {
+			log.Println("Error on marshal the content items page")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+		return
+	}
+
+	resData, err := h.app.Services.GetContentItems(allApps, claims.AppID, claims.OrgID, item.IDs, item.Categories, dataQuery, offset, limit, order, false, nil, "", 0, nil)
 	if err != nil {
 		log.Printf("Error on cgetting content items - %s\n", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -1112,6 +1362,65 @@ func (h AdminApisHandler) GetContentItems(claims *tokenauth.Claims, w http.Respo
 	w.Write(data)
 }
 
+// SearchContentItems Searches the content items by text
+// @Description Searches the content items by text. The most relevant items are given first, each with its relevance "score".
+// @Tags Admin
+// @ID AdminSearchContentItems
+// @Param text query string true "The text to search for"
+// @Param categories query string false "Coma separated categories of the desired records"
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param offset query string false "offset"
+// @Param limit query string false "limit - limit the result"
+// @Success 200 {array} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/content_items/search [get]
+func (h AdminApisHandler) SearchContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	text := getStringQueryParam(r, "text")
+	if text == nil {
+		log.Printf("Unable to search content items: Missing text")
+		http.Error(w, "Unable to search content items: Missing text", http.StatusBadRequest)
+		return
+	}
+
+	var categories []string
+	categoriesParam := getStringQueryParam(r, "categories")
+	if categoriesParam != nil {
+		categories = strings.Split(*categoriesParam, ",")
+	}
+
+	offset := getInt64QueryParam(r, "offset")
+	limit := getInt64QueryParam(r, "limit")
+
+	resData, err := h.app.Services.SearchContentItems(allApps, claims.AppID, claims.OrgID, *text, categories, offset, limit, false, nil, "", nil)
+	if err != nil {
+		log.Printf("Error on searching content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		resData = []model.ContentItemResponse{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the found content items")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
 // GetContentItem Retrieves a content item by id. <b> The data element could be either a primitive or nested json or array.</b>
 // @Description Retrieves a content item by id. <b> The data element could be either a primitive or nested json or array.</b>
 // @Tags Admin
@@ -1133,7 +1442,7 @@ func (h AdminApisHandler) GetContentItem(claims *tokenauth.Claims, w http.Respon
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	resData, err := h.app.Services.GetContentItem(allApps, claims.AppID, claims.OrgID, id)
+	resData, err := h.app.Services.GetContentItem(allApps, claims.AppID, claims.OrgID, id, false, nil, "", 0, nil)
 	if err != nil {
 		log.Printf("Error on getting content item id - %s\n %s", id, err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -1147,6 +1456,9 @@ func (h AdminApisHandler) GetContentItem(claims *tokenauth.Claims, w http.Respon
 		return
 	}
 
+	if resData != nil {
+		w.Header().Set("ETag", versionETag(contentItemVersion(*resData)))
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
@@ -1165,6 +1477,7 @@ type updateContentItemRequestBody struct {
 // @ID AdminUpdateContentItem
 // @Accept json
 // @Produce json
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
 // @Success 200 {object} model.ContentItem
 // @Security AdminUserAuth
 // @Router /admin/content_items/{id} [put]
@@ -1192,9 +1505,22 @@ func (h AdminApisHandler) UpdateContentItem(claims *tokenauth.Claims, w http.Res
 		return
 	}
 
-	resData, err := h.app.Services.UpdateContentItem(item.AllApps, claims.AppID, claims.OrgID, id, item.Category, item.Data)
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on updating content item with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	resData, err := h.app.Services.UpdateContentItem(claims, item.AllApps, id, item.Category, item.Data, version)
 	if err != nil {
 		log.Printf("Error on updating content item with id - %s\n %s", id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		if handleSchemaValidationError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -1206,6 +1532,7 @@ func (h AdminApisHandler) UpdateContentItem(claims *tokenauth.Claims, w http.Res
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(resData.Version))
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(jsonData)
@@ -1213,11 +1540,85 @@ func (h AdminApisHandler) UpdateContentItem(claims *tokenauth.Claims, w http.Res
 
 // createContentItemRequestBody Expected body while creating a new content item
 type createContentItemRequestBody struct {
-	AllApps  bool        `json:"all_apps"`
-	Category string      `json:"category" bson:"category"`
-	Data     interface{} `json:"data" bson:"data"`
+	AllApps   bool        `json:"all_apps"`
+	Category  string      `json:"category" bson:"category"`
+	Data      interface{} `json:"data" bson:"data"`
+	Status    string      `json:"status"` // draft, published or archived. It is published by default
+	PublishAt *time.Time  `json:"publish_at"`
+	ExpireAt  *time.Time  `json:"expire_at"`
+	ExpiresAt *time.Time  `json:"expires_at"` // the item is removed at this time
+
+	Locales map[string]interface{} `json:"locales"` // the data for other locales, for example es or es-mx
 } // @name createContentItemRequestBody
 
+// PatchContentItem Changes some of the data fields of a content item with the specified id
+// @Description Changes some of the data fields of a content item with the specified id. The body is either a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) - it is told by the Content-Type or, for application/json, by whether the body is an object or a list.
+// @Description Only the changed fields are written so the changes of other fields made in the meantime are kept. The result must match the schema of the category.
+// @Tags Admin
+// @ID AdminPatchContentItem
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Accept application/merge-patch+json,application/json-patch+json,json
+// @Produce json
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/content_items/{id} [patch]
+func (h AdminApisHandler) PatchContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	h.patchContentItem(claims, w, r, "")
+}
+
+// patchContentItem applies the patch from the body on the data of the item. The item could be in any category when category is empty.
+func (h AdminApisHandler) patchContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request, category string) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	patchType, patch, err := getDataPatch(r)
+	if err != nil {
+		log.Printf("Error on patching content item with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on patching content item with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	resData, err := h.app.Services.PatchContentItemData(claims, allApps, id, category, patchType, patch, version)
+	if err != nil {
+		log.Printf("Error on patching content item with id - %s\n %s", id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		if handleSchemaValidationError(w, err) {
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the patched content item")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(resData.Version))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
 // CreateContentItem creates a new content item. <b> The data element could be either a primitive or nested json or array.</b>
 // @Description Creates a new content item. <b> The data element could be either a primitive or nested json or array.</b>
 // @Tags Admin
@@ -1241,9 +1642,14 @@ func (h AdminApisHandler) CreateContentItem(claims *tokenauth.Claims, w http.Res
 		return
 	}
 
-	createdItem, err := h.app.Services.CreateContentItem(item.AllApps, claims.AppID, claims.OrgID, item.Category, item.Data)
+	contentItem := model.ContentItem{Category: item.Category, Data: item.Data, Status: item.Status,
+		PublishAt: item.PublishAt, ExpireAt: item.ExpireAt, ExpiresAt: item.ExpiresAt, Locales: item.Locales}
+	createdItem, err := h.app.Services.CreateContentItem(claims, item.AllApps, contentItem)
 	if err != nil {
 		log.Printf("Error on creating content item: %s\n", err)
+		if handleSchemaValidationError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -1260,221 +1666,291 @@ func (h AdminApisHandler) CreateContentItem(claims *tokenauth.Claims, w http.Res
 	w.Write(jsonData)
 }
 
-// DeleteContentItem Deletes a content item with the specified id
-// @Description Deletes a content item with the specified id
+// updateContentItemStatusRequestBody Expected body while updating the status of a content item
+type updateContentItemStatusRequestBody struct {
+	AllApps   bool       `json:"all_apps"`
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publish_at"`
+	ExpireAt  *time.Time `json:"expire_at"`
+} // @name updateContentItemStatusRequestBody
+
+// UpdateContentItemStatus Updates the status and the publishing window of a content item
+// @Description Updates the status and the publishing window of a content item. The clients get only the published items after their publish_at and before their expire_at time.
 // @Tags Admin
-// @ID AdminDeleteContentItem
-// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
-// @Success 200
+// @ID AdminUpdateContentItemStatus
+// @Accept json
+// @Produce json
+// @Param data body updateContentItemStatusRequestBody true "body json"
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200 {object} model.ContentItem
 // @Security AdminUserAuth
-// @Router /admin/content_items/{id} [delete]
-func (h AdminApisHandler) DeleteContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-	//get all-apps param value
-	allApps := false //false by defautl
-	allAppsParam := r.URL.Query().Get("all-apps")
-	if allAppsParam != "" {
-		allApps, _ = strconv.ParseBool(allAppsParam)
-	}
-
+// @Router /admin/content_items/{id}/status [put]
+func (h AdminApisHandler) UpdateContentItemStatus(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	guideID := vars["id"]
+	id := vars["id"]
 
-	err := h.app.Services.DeleteContentItem(allApps, claims.AppID, claims.OrgID, guideID)
+	var item updateContentItemStatusRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
 	if err != nil {
-		log.Printf("Error on deleting content item with id - %s\n %s", guideID, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error on unmarshal the update content item status request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-}
-
-// GetContentItemsCategories Retrieves  all content item categories that have in the database
-// @Description Retrieves  all content item categories that have in the database
-// @Tags Admin
-// @ID AdminGetContentItemsCategories
-// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
-// @Success 200
-// @Security AdminUserAuth
-// @Router /admin/content_item/categories [get]
-func (h AdminApisHandler) GetContentItemsCategories(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-	//get all-apps param value
-	allApps := false //false by defautl
-	allAppsParam := r.URL.Query().Get("all-apps")
-	if allAppsParam != "" {
-		allApps, _ = strconv.ParseBool(allAppsParam)
+	if len(item.Status) == 0 {
+		log.Printf("Unable to update content item status: Missing status")
+		http.Error(w, "Unable to update content item status: Missing status", http.StatusBadRequest)
+		return
 	}
 
-	resData, err := h.app.Services.GetContentItemsCategories(allApps, claims.AppID, claims.OrgID)
+	version, err := getIfMatchVersion(r)
 	if err != nil {
-		log.Printf("Error on cgetting content items - %s\n", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error on updating content item status with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
 		return
 	}
 
-	if resData == nil {
-		resData = []string{}
+	resData, err := h.app.Services.UpdateContentItemStatus(claims, item.AllApps, id, item.Status, item.PublishAt, item.ExpireAt, version)
+	if err != nil {
+		log.Printf("Error on updating content item status with id - %s\n %s", id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	data, err := json.Marshal(resData)
+	jsonData, err := json.Marshal(resData)
 	if err != nil {
-		log.Println("Error on marshal all content items")
+		log.Println("Error on marshal the updated content item")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(resData.Version))
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	w.Write(jsonData)
 }
 
-// CreateDataContentItem Creates a new data content type item
-// @Description Creates a new data content type item
+// updateContentItemAudienceRequestBody Expected body while updating the audience of a content item
+type updateContentItemAudienceRequestBody struct {
+	AllApps  bool                       `json:"all_apps"`
+	Audience *model.ContentItemAudience `json:"audience"`
+} // @name updateContentItemAudienceRequestBody
+
+// UpdateContentItemAudience Updates the audience of a content item
+// @Description Updates the audience of a content item. The clients get the item only when they match all the given rules. The roles and the groups are targeted by the permissions which they grant, as only the permissions are in the token. The audience is removed when it is null.
 // @Tags Admin
-// @ID AdminCreateDataContentItem
+// @ID AdminUpdateContentItemAudience
 // @Accept json
-// @Success 200
+// @Produce json
+// @Param data body updateContentItemAudienceRequestBody true "body json"
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200 {object} model.ContentItem
 // @Security AdminUserAuth
-// @Router /admin/data [post]
-func (h AdminApisHandler) CreateDataContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-	var item model.DataContentItem
+// @Router /admin/content_items/{id}/audience [put]
+func (h AdminApisHandler) UpdateContentItemAudience(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var item updateContentItemAudienceRequestBody
 	err := json.NewDecoder(r.Body).Decode(&item)
 	if err != nil {
-		log.Printf("Error on unmarshal the create data content item request data - %s\n", err.Error())
+		log.Printf("Error on unmarshal the update content item audience request data - %s\n", err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	createdItem, err := h.app.Services.CreateDataContentItem(claims, &item)
+	version, err := getIfMatchVersion(r)
 	if err != nil {
-		log.Printf("Error on creating data content item: %s\n", err)
+		log.Printf("Error on updating content item audience with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	resData, err := h.app.Services.UpdateContentItemAudience(claims, item.AllApps, id, item.Audience, version)
+	if err != nil {
+		log.Printf("Error on updating content item audience with id - %s\n %s", id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	jsonData, err := json.Marshal(createdItem)
+	jsonData, err := json.Marshal(resData)
 	if err != nil {
-		log.Println("Error on marshal the new item")
+		log.Println("Error on marshal the updated content item")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(resData.Version))
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(jsonData)
 }
 
-// GetDataContentItem Gets a data content type item
-// @Description Gets a data content type item
+// updateContentItemExpiryRequestBody Expected body while updating the expiry of a content item
+type updateContentItemExpiryRequestBody struct {
+	AllApps   bool       `json:"all_apps"`
+	ExpiresAt *time.Time `json:"expires_at"`
+} // @name updateContentItemExpiryRequestBody
+
+// UpdateContentItemExpiry Updates the expiry of a content item
+// @Description Updates the time when a content item is removed. The item is not given anymore once the time passes and it is deleted shortly after, its revisions are removed within an hour. The expiry is removed when it is null.
 // @Tags Admin
-// @ID AdminGetDataContentItem
+// @ID AdminUpdateContentItemExpiry
 // @Accept json
 // @Produce json
-// @Success 200
+// @Param data body updateContentItemExpiryRequestBody true "body json"
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200 {object} model.ContentItem
 // @Security AdminUserAuth
-// @Router /admin/data/{key} [get]
-func (h AdminApisHandler) GetDataContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+// @Router /admin/content_items/{id}/expiry [put]
+func (h AdminApisHandler) UpdateContentItemExpiry(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	key := vars["key"]
+	id := vars["id"]
 
-	resData, err := h.app.Services.GetDataContentItem(claims, key)
+	var item updateContentItemExpiryRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
 	if err != nil {
-		log.Printf("Error on getting data content type with key - %s\n %s", key, err)
+		log.Printf("Error on unmarshal the update content item expiry request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on updating content item expiry with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	resData, err := h.app.Services.UpdateContentItemExpiry(claims, item.AllApps, id, item.ExpiresAt, version)
+	if err != nil {
+		log.Printf("Error on updating content item expiry with id - %s\n %s", id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	data, err := json.Marshal(resData)
+	jsonData, err := json.Marshal(resData)
 	if err != nil {
-		log.Println("Error on marshal of data content type")
+		log.Println("Error on marshal the updated content item")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(resData.Version))
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	w.Write(jsonData)
 }
 
-// GetDataContentItems Gets data content items
-// @Descriptions Gets data content items
+// updateContentItemPinRequestBody Expected body while pinning or unpinning a content item
+type updateContentItemPinRequestBody struct {
+	AllApps     bool       `json:"all_apps"`
+	Pinned      bool       `json:"pinned"`
+	PinnedUntil *time.Time `json:"pinned_until"`
+} // @name updateContentItemPinRequestBody
+
+// UpdateContentItemPin Pins or unpins a content item
+// @Description Pins or unpins a content item. The pinned items come first in the manual order until their pinned_until time.
 // @Tags Admin
-// @ID AdminGetDataContentItems
-// @Param category body string false "category - get all data content items based on category"
+// @ID AdminUpdateContentItemPin
 // @Accept json
 // @Produce json
-// @Success 200
+// @Param data body updateContentItemPinRequestBody true "body json"
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200 {object} model.ContentItem
 // @Security AdminUserAuth
-// @Router /admin/data [get]
-func (h AdminApisHandler) GetDataContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-	category := r.URL.Query().Get("category")
-	if len(category) <= 0 {
-		log.Print("Missing category\n")
-		http.Error(w, "missing 'category' query param", http.StatusBadRequest)
+// @Router /admin/content_items/{id}/pin [put]
+func (h AdminApisHandler) UpdateContentItemPin(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var item updateContentItemPinRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the update content item pin request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	resData, err := h.app.Services.GetDataContentItems(claims, category)
+	version, err := getIfMatchVersion(r)
 	if err != nil {
-		log.Printf("Error on getting data content type with id - %s\n", err)
+		log.Printf("Error on updating content item pin with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	resData, err := h.app.Services.UpdateContentItemPin(claims, item.AllApps, id, item.Pinned, item.PinnedUntil, version)
+	if err != nil {
+		log.Printf("Error on updating content item pin with id - %s\n %s", id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	data, err := json.Marshal(resData)
+	jsonData, err := json.Marshal(resData)
 	if err != nil {
-		log.Println("Error on marshal of data content type")
+		log.Println("Error on marshal the updated content item")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(resData.Version))
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	w.Write(jsonData)
 }
 
-// UpdateDataContentItem Updates a content item.
-// @Description Updates a content item
+// reorderContentItemsRequestBody Expected body while reordering the content items of a category
+type reorderContentItemsRequestBody struct {
+	AllApps  bool     `json:"all_apps"`
+	Category string   `json:"category"`
+	IDs      []string `json:"ids"`
+} // @name reorderContentItemsRequestBody
+
+// ReorderContentItems Sets the manual order of the content items in a category
+// @Description Sets the manual order of the content items in a category at once. The given items get the positions from 1 in their order and the other items of the category lose their positions, so they come after them. The clients get the items in this order with order=position.
 // @Tags Admin
-// @ID AdminUpdateDataContentItem
+// @ID AdminReorderContentItems
 // @Accept json
 // @Produce json
-// @Success 200 {object} model.DataContentItem
+// @Param data body reorderContentItemsRequestBody true "body json"
+// @Success 200 {array} model.ContentItem
 // @Security AdminUserAuth
-// @Router /admin/data/ [put]
-func (h AdminApisHandler) UpdateDataContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-
-	var item model.DataContentItem
+// @Router /admin/content_items/reorder [post]
+func (h AdminApisHandler) ReorderContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var item reorderContentItemsRequestBody
 	err := json.NewDecoder(r.Body).Decode(&item)
 	if err != nil {
-		log.Printf("Error on unmarshal the update data content item request data - %s\n", err.Error())
+		log.Printf("Error on unmarshal the reorder content items request data - %s\n", err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	if len(item.Category) == 0 {
-		log.Printf("Unable to update content item: Missing category")
-		http.Error(w, "Unable to update content item: Missing category", http.StatusBadRequest)
-		return
-	}
-
-	if item.Data == nil {
-		log.Printf("Unable to update content item: Missing data")
-		http.Error(w, "Unable to update content item: Missing data", http.StatusBadRequest)
+	resData, err := h.app.Services.ReorderContentItems(claims, item.AllApps, item.Category, item.IDs)
+	if err != nil {
+		log.Printf("Error on reordering content items in category - %s\n %s", item.Category, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	resData, err := h.app.Services.UpdateDataContentItem(claims, &item)
-	if err != nil {
-		log.Printf("Error on updating content item- %s\n", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	if resData == nil {
+		resData = []model.ContentItem{}
 	}
 
 	jsonData, err := json.Marshal(resData)
 	if err != nil {
-		log.Println("Error on marshal the updated content item")
+		log.Println("Error on marshal the reordered content items")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
@@ -1484,87 +1960,164 @@ func (h AdminApisHandler) UpdateDataContentItem(claims *tokenauth.Claims, w http
 	w.Write(jsonData)
 }
 
-// DeleteDataContentItem Deletes a data content item with a specified key
-// @Description Deletes a data content item with the specified key
+type updateContentItemLocaleRequestBody struct {
+	AllApps bool        `json:"all_apps"`
+	Data    interface{} `json:"data"`
+} // @name updateContentItemLocaleRequestBody
+
+// UpdateContentItemLocale Sets the data of a content item for a locale
+// @Description Sets the data of a content item for a locale, for example es or es-MX. The clients get it when they prefer this locale. The data must match the schema of the category.
 // @Tags Admin
-// @ID AdminDeleteDataContentItem
-// @Success 200
+// @ID AdminUpdateContentItemLocale
+// @Accept json
+// @Produce json
+// @Param data body updateContentItemLocaleRequestBody true "body json"
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200 {object} model.ContentItem
 // @Security AdminUserAuth
-// @Router /admin/data/{key} [delete]
-func (h AdminApisHandler) DeleteDataContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+// @Router /admin/content_items/{id}/locales/{locale} [put]
+func (h AdminApisHandler) UpdateContentItemLocale(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	key := vars["key"]
+	id := vars["id"]
+	locale := vars["locale"]
 
-	err := h.app.Services.DeleteDataContentItem(claims, key)
+	var item updateContentItemLocaleRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
 	if err != nil {
-		log.Printf("Error on deleting data content item with key - %s\n %s", key, err)
+		log.Printf("Error on unmarshal the update content item locale request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on updating content item locale %s with id - %s\n %s", locale, id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	resData, err := h.app.Services.UpdateContentItemLocale(claims, item.AllApps, id, locale, item.Data, version)
+	if err != nil {
+		log.Printf("Error on updating content item locale %s with id - %s\n %s", locale, id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		if handleSchemaValidationError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the updated content item")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(resData.Version))
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
 }
 
-// CreateCategory Creates a category
-// @Description Creates a category
+// DeleteContentItemLocale Removes the data of a content item for a locale
+// @Description Removes the data of a content item for a locale. The clients which prefer this locale get the fallback one.
 // @Tags Admin
-// @ID AdminCreateCategory
-// @Accept json
-// @Success 200
+// @ID AdminDeleteContentItemLocale
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200 {object} model.ContentItem
 // @Security AdminUserAuth
-// @Router /admin/categories [post]
-func (h AdminApisHandler) CreateCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-	var item model.Category
-	err := json.NewDecoder(r.Body).Decode(&item)
+// @Router /admin/content_items/{id}/locales/{locale} [delete]
+func (h AdminApisHandler) DeleteContentItemLocale(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	locale := vars["locale"]
+
+	version, err := getIfMatchVersion(r)
 	if err != nil {
-		log.Printf("Error on unmarshal the create a category - %s\n", err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		log.Printf("Error on deleting content item locale %s with id - %s\n %s", locale, id, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
 		return
 	}
 
-	createdItem, err := h.app.Services.CreateCategory(claims, &item)
+	resData, err := h.app.Services.DeleteContentItemLocale(claims, allApps, id, locale, version)
 	if err != nil {
-		log.Printf("Error on creating category %s\n", err)
+		log.Printf("Error on deleting content item locale %s with id - %s\n %s", locale, id, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	jsonData, err := json.Marshal(createdItem)
+	jsonData, err := json.Marshal(resData)
 	if err != nil {
-		log.Println("Error on marshal the new category")
+		log.Println("Error on marshal the updated content item")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(resData.Version))
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(jsonData)
 }
 
-// GetCategory Gets a category
-// @Description Gets a category
+// GetContentItemsMissingTranslations Gives the content items which do not have data for some of the locales
+// @Description Gives the content items which do not have data for some of the locales, each with the missing ones.
 // @Tags Admin
-// @ID AdminGetCategory
-// @Accept json
-// @Produce json
-// @Success 200
+// @ID AdminGetContentItemsMissingTranslations
+// @Param locales query string true "Coma separated locales to check, for example es,fr"
+// @Param categories query string false "Coma separated categories of the desired records"
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {array} model.MissingTranslation
 // @Security AdminUserAuth
-// @Router /admin/categories/{name} [get]
-func (h AdminApisHandler) GetCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	name := vars["name"]
+// @Router /admin/content_items/missing_translations [get]
+func (h AdminApisHandler) GetContentItemsMissingTranslations(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
 
-	resData, err := h.app.Services.GetCategory(claims, name)
+	localesParam := getStringQueryParam(r, "locales")
+	if localesParam == nil {
+		log.Printf("Unable to get missing translations: Missing locales")
+		http.Error(w, "Unable to get missing translations: Missing locales", http.StatusBadRequest)
+		return
+	}
+	locales := strings.Split(*localesParam, ",")
+
+	var categories []string
+	categoriesParam := getStringQueryParam(r, "categories")
+	if categoriesParam != nil {
+		categories = strings.Split(*categoriesParam, ",")
+	}
+
+	resData, err := h.app.Services.GetContentItemsMissingTranslations(allApps, claims.AppID, claims.OrgID, categories, locales)
 	if err != nil {
-		log.Printf("Error on getting category with name - %s\n %s", name, err)
+		log.Printf("Error on getting content items missing translations - %s\n", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if resData == nil {
+		resData = []model.MissingTranslation{}
+	}
 
 	data, err := json.Marshal(resData)
 	if err != nil {
-		log.Println("Error on marshal of category")
+		log.Println("Error on marshal the missing translations")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
@@ -1574,179 +2127,2140 @@ func (h AdminApisHandler) GetCategory(claims *tokenauth.Claims, w http.ResponseW
 	w.Write(data)
 }
 
-// UpdateCategory Updates a category with a specificed id.
-// @Description  Updates a category with a specificed id.
+// batchContentItemsRequestBody Expected body while performing a batch of content items operations
+type batchContentItemsRequestBody struct {
+	AllApps    bool                               `json:"all_apps"`
+	Operations []model.ContentItemsBatchOperation `json:"operations"`
+} // @name batchContentItemsRequestBody
+
+// BatchContentItems Performs create, update and delete operations on content items in a single transaction
+// @Description Performs create, update and delete operations on content items in the given order in a single transaction. Either all of them are done or none of them.
+// @Description The result of every operation is given. The response is 400 when the batch is not committed.
 // @Tags Admin
-// @ID AdminUpdateCategory
+// @ID AdminBatchContentItems
 // @Accept json
 // @Produce json
-// @Success 200 {object} model.Category
+// @Param data body batchContentItemsRequestBody true "body json"
+// @Success 200 {object} model.ContentItemsBatchResult
 // @Security AdminUserAuth
-// @Router /admin/categories [put]
-func (h AdminApisHandler) UpdateCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-	var item model.Category
-	err := json.NewDecoder(r.Body).Decode(&item)
+// @Router /admin/content_items/batch [post]
+func (h AdminApisHandler) BatchContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var body batchContentItemsRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		log.Printf("Error on unmarshal the update category request data - %s\n", err.Error())
+		log.Printf("Error on unmarshal the content items batch request data - %s\n", err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	resData, err := h.app.Services.UpdateCategory(claims, &item)
+	if len(body.Operations) == 0 {
+		log.Printf("Unable to perform content items batch: Missing operations")
+		http.Error(w, "Unable to perform content items batch: Missing operations", http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.BatchContentItems(claims, body.AllApps, body.Operations)
 	if err != nil {
-		log.Printf("Error on updating category  - %s", err)
+		log.Printf("Error on performing content items batch - %s\n", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	jsonData, err := json.Marshal(resData)
 	if err != nil {
-		log.Println("Error on marshal the updated category")
+		log.Println("Error on marshal the content items batch result")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	status := http.StatusOK
+	if !resData.Committed {
+		status = http.StatusBadRequest
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 	w.Write(jsonData)
 }
 
-// DeleteCategory Deletes a category with specified key
-// @Description Deletes a category with specified key
+// maxImportLineSize is the max size of a content item within an import stream
+const maxImportLineSize int = 16 * 1024 * 1024
+
+// ExportContentItems Exports the content items as NDJSON
+// @Description Exports the content items of the categories, or all the items of the app or the organization, as NDJSON - one json content item per line. The stream could be imported with the import API.
 // @Tags Admin
-// @ID AdminDeleteCategory
-// @Success 200
+// @ID AdminExportContentItems
+// @Param categories query string false "Coma separated categories of the items to export. All the items are exported when it is not passed."
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Produce application/x-ndjson
+// @Success 200 {array} model.ContentItem
 // @Security AdminUserAuth
-// @Router /admin/categories/{name} [delete]
-func (h AdminApisHandler) DeleteCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	name := vars["name"]
+// @Router /admin/content_items/export [get]
+func (h AdminApisHandler) ExportContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	var categories []string
+	categoriesParam := getStringQueryParam(r, "categories")
+	if categoriesParam != nil {
+		categories = strings.Split(*categoriesParam, ",")
+	}
 
-	err := h.app.Services.DeleteCategory(claims, name)
+	items, err := h.app.Services.ExportContentItems(allApps, claims.AppID, claims.OrgID, categories)
 	if err != nil {
-		log.Printf("Error on deleting category with name - %s\n %s", name, err)
+		log.Printf("Error on exporting content items - %s\n", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		err = encoder.Encode(item)
+		if err != nil {
+			log.Printf("Error on writing the exported content item with id - %s\n %s", item.ID, err)
+			return
+		}
+	}
 }
 
-// UploadFileContentItem Uploads a file to AWS S3
-// @Description Uploads a file to AWS S3
+// ImportContentItems Imports content items from NDJSON
+// @Description Imports content items from NDJSON - one json content item per line, as they are exported. The items are stored within the current organization and app.
+// @Description The upsert mode replaces the items with the same ids and creates the others with their ids. The create mode creates all of them with new ids.
+// @Description Nothing is stored when any of the items is not fine - the report gives the errors.
 // @Tags Admin
-// @ID AdminUploadFileContentItem
-// @Param fileName body string false "fileName - the uploaded file name"
-// @Param category body string false "category - category of file content item"
-// @Success 200
+// @ID AdminImportContentItems
+// @Param mode query string false "upsert (default) or create"
+// @Param dry-run query boolean false "It says if only the report should be given without storing anything. It is 'false' by default."
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Accept application/x-ndjson
+// @Produce json
+// @Success 200 {object} model.ContentItemsImportReport
 // @Security AdminUserAuth
-// @Router /admin/files [post]
-func (h AdminApisHandler) UploadFileContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-
-	fileName := r.FormValue("fileName")
-	if len(fileName) <= 0 {
-		log.Print("Missing file name\n")
-		http.Error(w, "missing 'fileName' form param", http.StatusBadRequest)
+// @Router /admin/content_items/import [post]
+func (h AdminApisHandler) ImportContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	dryRun := false
+	dryRunParam := r.URL.Query().Get("dry-run")
+	if dryRunParam != "" {
+		dryRun, _ = strconv.ParseBool(dryRunParam)
+	}
+
+	mode := r.URL.Query().Get("mode")
+
+	items := []model.ContentItem{}
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		var item model.ContentItem
+		err := json.Unmarshal([]byte(line), &item)
+		if err != nil {
+			log.Printf("Error on unmarshal the imported content item on line %d - %s\n", len(items)+1, err.Error())
+			http.Error(w, fmt.Sprintf("invalid content item on line %d - %s", len(items)+1, err.Error()), http.StatusBadRequest)
+			return
+		}
+		items = append(items, item)
+	}
+	err := scanner.Err()
+	if err != nil {
+		log.Printf("Error on reading the imported content items - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.ImportContentItems(claims, allApps, items, mode, dryRun)
+	if err != nil {
+		log.Printf("Error on importing content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the import report")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// copyContentItemsRequestBody Expected body while copying content items to another app scope
+type copyContentItemsRequestBody struct {
+	AllApps     bool     `json:"all_apps"`
+	TargetAppID *string  `json:"target_app_id"`
+	IDs         []string `json:"ids"`
+	Categories  []string `json:"categories"`
+	OnConflict  string   `json:"on_conflict"`
+	DryRun      bool     `json:"dry_run"`
+} // @name copyContentItemsRequestBody
+
+// CopyContentItems Copies content items to another app scope
+// @Description Copies the content items with the ids and the items in the categories from the current app, or from all the apps with all_apps, to the target app within the organization. The items are copied for all the apps in the organization when target_app_id is null.
+// @Description The copies get new ids and keep the ids of their source items in copied_from. The references between the copied items are replaced by the ids of their copies, the references to the other items are kept as they are and reported as conflicts.
+// @Description An item which already has a copy in the target is skipped (default), replaces its copy or makes the whole copy fail, according to on_conflict. Nothing is stored when any of the items cannot be copied - the report gives the errors.
+// @Tags Admin
+// @ID AdminCopyContentItems
+// @Accept json
+// @Produce json
+// @Param data body copyContentItemsRequestBody true "body json"
+// @Success 200 {object} model.ContentItemsCopyReport
+// @Security AdminUserAuth
+// @Router /admin/content_items/copy [post]
+func (h AdminApisHandler) CopyContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var item copyContentItemsRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the copy content items request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.CopyContentItems(claims, item.AllApps, item.TargetAppID, item.IDs, item.Categories, item.OnConflict, item.DryRun)
+	if err != nil {
+		log.Printf("Error on copying content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the copy report")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// DeleteContentItem Deletes a content item with the specified id
+// @Description Deletes a content item with the specified id. It is moved to the trash and it could be restored until the trash retention period passes.
+// @Tags Admin
+// @ID AdminDeleteContentItem
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/content_items/{id} [delete]
+func (h AdminApisHandler) DeleteContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	guideID := vars["id"]
+
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on deleting content item with id - %s\n %s", guideID, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	err = h.app.Services.DeleteContentItem(claims, allApps, guideID, version)
+	if err != nil {
+		log.Printf("Error on deleting content item with id - %s\n %s", guideID, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetContentItemsCategories Retrieves  all content item categories that have in the database
+// @Description Retrieves  all content item categories that have in the database
+// @Tags Admin
+// @ID AdminGetContentItemsCategories
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/content_item/categories [get]
+func (h AdminApisHandler) GetContentItemsCategories(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	resData, err := h.app.Services.GetContentItemsCategories(allApps, claims.AppID, claims.OrgID, nil)
+	if err != nil {
+		log.Printf("Error on cgetting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		resData = []string{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal all content items")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetContentItemReferences Retrieves where a content item is referenced
+// @Description Retrieves the content items which reference a content item and the data fields which hold the references. The references are the data fields declared with "format": "content-item-ref" in the schemas of the categories. Check it before deleting an item which could be still in use.
+// @Tags Admin
+// @ID AdminGetContentItemReferences
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {array} model.ContentItemReference
+// @Security AdminUserAuth
+// @Router /admin/content_items/{id}/references [get]
+func (h AdminApisHandler) GetContentItemReferences(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetContentItemReferences(allApps, claims.AppID, claims.OrgID, id)
+	if err != nil {
+		log.Printf("Error on getting content item references for id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		resData = []model.ContentItemReference{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the content item references")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetContentItemRevisions Retrieves the revisions of a content item
+// @Description Retrieves the previous versions of a content item, the latest first. Every update, delete and restore of the item adds a revision.
+// @Tags Admin
+// @ID AdminGetContentItemRevisions
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {array} model.ContentItemRevision
+// @Security AdminUserAuth
+// @Router /admin/content_items/{id}/revisions [get]
+func (h AdminApisHandler) GetContentItemRevisions(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetContentItemRevisions(allApps, claims.AppID, claims.OrgID, id)
+	if err != nil {
+		log.Printf("Error on getting content item revisions for id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		resData = []model.ContentItemRevision{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the content item revisions")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetContentItemRevisionsDiff Compares two revisions of a content item
+// @Description Compares two revisions of a content item. The "from" and "to" params are revision numbers or "current" for the current version of the item.
+// @Tags Admin
+// @ID AdminGetContentItemRevisionsDiff
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param from query string true "Revision number"
+// @Param to query string false "Revision number. It is 'current' by default."
+// @Success 200 {object} model.ContentItemRevisionsDiff
+// @Security AdminUserAuth
+// @Router /admin/content_items/{id}/revisions/diff [get]
+func (h AdminApisHandler) GetContentItemRevisionsDiff(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	from := getStringQueryParam(r, "from")
+	if from == nil {
+		log.Printf("Unable to compare content item revisions: Missing from")
+		http.Error(w, "Unable to compare content item revisions: Missing from", http.StatusBadRequest)
+		return
+	}
+	to := model.RevisionCurrent
+	toParam := getStringQueryParam(r, "to")
+	if toParam != nil {
+		to = *toParam
+	}
+
+	resData, err := h.app.Services.GetContentItemRevisionsDiff(allApps, claims.AppID, claims.OrgID, id, *from, to)
+	if err != nil {
+		log.Printf("Error on comparing content item revisions for id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the content item revisions diff")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// restoreContentItemRevisionRequestBody Expected body while restoring a content item revision
+type restoreContentItemRevisionRequestBody struct {
+	AllApps bool `json:"all_apps"`
+} // @name restoreContentItemRevisionRequestBody
+
+// RestoreContentItemRevision Restores a revision as the current version of a content item
+// @Description Restores a revision as the current version of a content item. The replaced version is kept as a new revision. The items in the trash must be taken out of it first. An item which is not there anymore is brought back with the status, the publishing window, the audience and the expiry of the revision, or as a draft for the older revisions.
+// @Tags Admin
+// @ID AdminRestoreContentItemRevision
+// @Accept json
+// @Param data body restoreContentItemRevisionRequestBody false "body json"
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/content_items/{id}/revisions/{revision}/restore [post]
+func (h AdminApisHandler) RestoreContentItemRevision(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	revision, err := strconv.ParseInt(vars["revision"], 10, 64)
+	if err != nil {
+		log.Printf("Unable to restore content item revision: Invalid revision %s", vars["revision"])
+		http.Error(w, "Unable to restore content item revision: Invalid revision", http.StatusBadRequest)
+		return
+	}
+
+	var item restoreContentItemRevisionRequestBody
+	if r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(&item)
+		if err != nil {
+			log.Printf("Error on unmarshal the restore content item revision request data - %s\n", err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	resData, err := h.app.Services.RestoreContentItemRevision(claims, item.AllApps, id, revision)
+	if err != nil {
+		log.Printf("Error on restoring revision %d of content item with id - %s\n %s", revision, id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the restored content item")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// GetDeletedContentItems Gives the content items which are in the trash
+// @Description Gives the content items which are in the trash, the last deleted first. They are removed for good after the trash retention period.
+// @Tags Admin
+// @ID AdminGetDeletedContentItems
+// @Param categories query string false "Coma separated categories of the desired records"
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {array} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/content_items/trash [get]
+func (h AdminApisHandler) GetDeletedContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	var categories []string
+	categoriesParam := getStringQueryParam(r, "categories")
+	if categoriesParam != nil {
+		categories = strings.Split(*categoriesParam, ",")
+	}
+
+	resData, err := h.app.Services.GetDeletedContentItems(allApps, claims.AppID, claims.OrgID, categories)
+	if err != nil {
+		log.Printf("Error on getting deleted content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if resData == nil {
+		resData = []model.ContentItem{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the deleted content items")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// restoreContentItemRequestBody Expected body while taking a content item out of the trash
+type restoreContentItemRequestBody struct {
+	AllApps bool `json:"all_apps"`
+} // @name restoreContentItemRequestBody
+
+// RestoreContentItem Takes a content item out of the trash
+// @Description Takes a content item out of the trash, it is given to the clients again.
+// @Tags Admin
+// @ID AdminRestoreContentItem
+// @Accept json
+// @Param data body restoreContentItemRequestBody false "body json"
+// @Success 200 {object} model.ContentItem
+// @Security AdminUserAuth
+// @Router /admin/content_items/trash/{id}/restore [post]
+func (h AdminApisHandler) RestoreContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var item restoreContentItemRequestBody
+	if r.ContentLength != 0 {
+		err := json.NewDecoder(r.Body).Decode(&item)
+		if err != nil {
+			log.Printf("Error on unmarshal the restore content item request data - %s\n", err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	resData, err := h.app.Services.RestoreContentItem(claims, item.AllApps, id)
+	if err != nil {
+		log.Printf("Error on restoring deleted content item with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the restored content item")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(resData.Version))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// GetContentItemSchemas Retrieves all content item schemas
+// @Description Retrieves all content item schemas
+// @Tags Admin
+// @ID AdminGetContentItemSchemas
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {array} model.ContentItemSchema
+// @Security AdminUserAuth
+// @Router /admin/content_item/schemas [get]
+func (h AdminApisHandler) GetContentItemSchemas(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	resData, err := h.app.Services.GetContentItemSchemas(allApps, claims.AppID, claims.OrgID)
+	if err != nil {
+		log.Printf("Error on getting content item schemas - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		resData = []model.ContentItemSchema{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal all content item schemas")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetContentItemSchema Retrieves the schema for a content item category
+// @Description Retrieves the schema for a content item category
+// @Tags Admin
+// @ID AdminGetContentItemSchema
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {object} model.ContentItemSchema
+// @Security AdminUserAuth
+// @Router /admin/content_item/schemas/{category} [get]
+func (h AdminApisHandler) GetContentItemSchema(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	category := vars["category"]
+
+	resData, err := h.app.Services.GetContentItemSchema(allApps, claims.AppID, claims.OrgID, category)
+	if err != nil {
+		log.Printf("Error on getting content item schema for category - %s\n %s", category, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the content item schema")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// createContentItemSchemaRequestBody Expected body while creating a content item schema
+type createContentItemSchemaRequestBody struct {
+	AllApps  bool            `json:"all_apps"`
+	Category string          `json:"category"`
+	Schema   json.RawMessage `json:"schema"`
+} // @name createContentItemSchemaRequestBody
+
+// CreateContentItemSchema Creates the JSON Schema which the data of the content items in a category must match
+// @Description Creates the JSON Schema which the data of the content items in a category must match. Content items which do not match it are rejected on create and update.
+// @Tags Admin
+// @ID AdminCreateContentItemSchema
+// @Accept json
+// @Param data body createContentItemSchemaRequestBody true "body json"
+// @Success 200 {object} model.ContentItemSchema
+// @Security AdminUserAuth
+// @Router /admin/content_item/schemas [post]
+func (h AdminApisHandler) CreateContentItemSchema(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var item createContentItemSchemaRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the create content item schema request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(item.Category) == 0 {
+		log.Printf("Unable to create content item schema: Missing category")
+		http.Error(w, "Unable to create content item schema: Missing category", http.StatusBadRequest)
+		return
+	}
+
+	createdItem, err := h.app.Services.CreateContentItemSchema(item.AllApps, claims.AppID, claims.OrgID, item.Category, item.Schema)
+	if err != nil {
+		log.Printf("Error on creating content item schema: %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jsonData, err := json.Marshal(createdItem)
+	if err != nil {
+		log.Println("Error on marshal the new content item schema")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// updateContentItemSchemaRequestBody Expected body while updating a content item schema
+type updateContentItemSchemaRequestBody struct {
+	AllApps bool            `json:"all_apps"`
+	Schema  json.RawMessage `json:"schema"`
+} // @name updateContentItemSchemaRequestBody
+
+// UpdateContentItemSchema Updates the schema for a content item category
+// @Description Updates the schema for a content item category. The already existing content items are not validated again.
+// @Tags Admin
+// @ID AdminUpdateContentItemSchema
+// @Accept json
+// @Param data body updateContentItemSchemaRequestBody true "body json"
+// @Success 200 {object} model.ContentItemSchema
+// @Security AdminUserAuth
+// @Router /admin/content_item/schemas/{category} [put]
+func (h AdminApisHandler) UpdateContentItemSchema(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	category := vars["category"]
+
+	var item updateContentItemSchemaRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the update content item schema request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.UpdateContentItemSchema(item.AllApps, claims.AppID, claims.OrgID, category, item.Schema)
+	if err != nil {
+		log.Printf("Error on updating content item schema for category - %s\n %s", category, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the updated content item schema")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// DeleteContentItemSchema Deletes the schema for a content item category
+// @Description Deletes the schema for a content item category
+// @Tags Admin
+// @ID AdminDeleteContentItemSchema
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/content_item/schemas/{category} [delete]
+func (h AdminApisHandler) DeleteContentItemSchema(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	category := vars["category"]
+
+	err := h.app.Services.DeleteContentItemSchema(allApps, claims.AppID, claims.OrgID, category)
+	if err != nil {
+		log.Printf("Error on deleting content item schema for category - %s\n %s", category, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetContentItemCategories Retrieves the content item categories with their metadata and counts
+// @Description Retrieves the registered content item categories and the categories which have content items but are not registered. Every category has the count of its content items and the time of the latest change of its content items.
+// @Tags Admin
+// @ID AdminGetContentItemCategories
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {array} model.ContentItemCategory
+// @Security AdminUserAuth
+// @Router /admin/content_item/category_registry [get]
+func (h AdminApisHandler) GetContentItemCategories(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	resData, err := h.app.Services.GetContentItemCategories(allApps, claims.AppID, claims.OrgID)
+	if err != nil {
+		log.Printf("Error on getting content item categories - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		resData = []model.ContentItemCategory{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the content item categories")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetContentItemCategory Retrieves a registered content item category
+// @Description Retrieves a registered content item category with the count of its content items and the time of the latest change of its content items
+// @Tags Admin
+// @ID AdminGetContentItemCategory
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {object} model.ContentItemCategory
+// @Security AdminUserAuth
+// @Router /admin/content_item/category_registry/{name} [get]
+func (h AdminApisHandler) GetContentItemCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	resData, err := h.app.Services.GetContentItemCategory(allApps, claims.AppID, claims.OrgID, name)
+	if err != nil {
+		log.Printf("Error on getting content item category - %s\n %s", name, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the content item category")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// createContentItemCategoryRequestBody Expected body while registering a content item category
+type createContentItemCategoryRequestBody struct {
+	AllApps     bool              `json:"all_apps"`
+	Name        string            `json:"name"`
+	DisplayName string            `json:"display_name"`
+	Description string            `json:"description"`
+	Icon        string            `json:"icon"`
+	ReadAccess  *model.ReadAccess `json:"read_access"`
+} // @name createContentItemCategoryRequestBody
+
+// CreateContentItemCategory Registers a content item category
+// @Description Registers a content item category with its metadata. When the organization settings require registered categories, the content items could be created only in the registered categories.
+// @Tags Admin
+// @ID AdminCreateContentItemCategory
+// @Accept json
+// @Param data body createContentItemCategoryRequestBody true "body json"
+// @Success 200 {object} model.ContentItemCategory
+// @Security AdminUserAuth
+// @Router /admin/content_item/category_registry [post]
+func (h AdminApisHandler) CreateContentItemCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var item createContentItemCategoryRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the create content item category request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	category := model.ContentItemCategory{Name: item.Name, DisplayName: item.DisplayName, Description: item.Description, Icon: item.Icon, ReadAccess: item.ReadAccess}
+	createdItem, err := h.app.Services.CreateContentItemCategory(item.AllApps, claims.AppID, claims.OrgID, category)
+	if err != nil {
+		log.Printf("Error on creating content item category: %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jsonData, err := json.Marshal(createdItem)
+	if err != nil {
+		log.Println("Error on marshal the new content item category")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// updateContentItemCategoryRequestBody Expected body while updating a registered content item category
+type updateContentItemCategoryRequestBody struct {
+	AllApps     bool              `json:"all_apps"`
+	DisplayName string            `json:"display_name"`
+	Description string            `json:"description"`
+	Icon        string            `json:"icon"`
+	ReadAccess  *model.ReadAccess `json:"read_access"`
+} // @name updateContentItemCategoryRequestBody
+
+// UpdateContentItemCategory Updates the metadata of a registered content item category
+// @Description Updates the metadata of a registered content item category
+// @Tags Admin
+// @ID AdminUpdateContentItemCategory
+// @Accept json
+// @Param data body updateContentItemCategoryRequestBody true "body json"
+// @Success 200 {object} model.ContentItemCategory
+// @Security AdminUserAuth
+// @Router /admin/content_item/category_registry/{name} [put]
+func (h AdminApisHandler) UpdateContentItemCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	var item updateContentItemCategoryRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the update content item category request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	category := model.ContentItemCategory{Name: name, DisplayName: item.DisplayName, Description: item.Description, Icon: item.Icon, ReadAccess: item.ReadAccess}
+	resData, err := h.app.Services.UpdateContentItemCategory(item.AllApps, claims.AppID, claims.OrgID, category)
+	if err != nil {
+		log.Printf("Error on updating content item category - %s\n %s", name, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the updated content item category")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// DeleteContentItemCategory Removes a content item category from the registry
+// @Description Removes a content item category from the registry. Its content items are kept.
+// @Tags Admin
+// @ID AdminDeleteContentItemCategory
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/content_item/category_registry/{name} [delete]
+func (h AdminApisHandler) DeleteContentItemCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	err := h.app.Services.DeleteContentItemCategory(allApps, claims.AppID, claims.OrgID, name)
+	if err != nil {
+		log.Printf("Error on deleting content item category - %s\n %s", name, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// CreateDataContentItem Creates a new data content type item
+// @Description Creates a new data content type item
+// @Tags Admin
+// @ID AdminCreateDataContentItem
+// @Accept json
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/data [post]
+func (h AdminApisHandler) CreateDataContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var item model.DataContentItem
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the create data content item request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	createdItem, err := h.app.Services.CreateDataContentItem(claims, &item)
+	if err != nil {
+		log.Printf("Error on creating data content item: %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(createdItem)
+	if err != nil {
+		log.Println("Error on marshal the new item")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// GetDataContentItem Gets a data content type item
+// @Description Gets a data content type item
+// @Tags Admin
+// @ID AdminGetDataContentItem
+// @Accept json
+// @Produce json
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/data/{key} [get]
+func (h AdminApisHandler) GetDataContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	key := vars["key"]
+
+	resData, err := h.app.Services.GetDataContentItem(claims, key, nil, false)
+	if err != nil {
+		log.Printf("Error on getting data content type with key - %s\n %s", key, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal of data content type")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if resData != nil {
+		w.Header().Set("ETag", versionETag(resData.Version))
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetDataContentItems Gets data content items
+// @Descriptions Gets data content items
+// @Tags Admin
+// @ID AdminGetDataContentItems
+// @Param category body string false "category - get all data content items based on category"
+// @Accept json
+// @Produce json
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/data [get]
+func (h AdminApisHandler) GetDataContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	category := r.URL.Query().Get("category")
+	if len(category) <= 0 {
+		log.Print("Missing category\n")
+		http.Error(w, "missing 'category' query param", http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.GetDataContentItems(claims, category, nil, false)
+	if err != nil {
+		log.Printf("Error on getting data content type with id - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal of data content type")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetDataContentItemsMissingTranslations Gives the data content items which do not have data for some of the locales
+// @Description Gives the data content items which do not have data for some of the locales, each with the missing ones.
+// @Tags Admin
+// @ID AdminGetDataContentItemsMissingTranslations
+// @Param locales query string true "Coma separated locales to check, for example es,fr"
+// @Param category query string false "category - check only the data content items within this category"
+// @Success 200 {array} model.MissingTranslation
+// @Security AdminUserAuth
+// @Router /admin/data/missing_translations [get]
+func (h AdminApisHandler) GetDataContentItemsMissingTranslations(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	localesParam := getStringQueryParam(r, "locales")
+	if localesParam == nil {
+		log.Printf("Unable to get missing translations: Missing locales")
+		http.Error(w, "Unable to get missing translations: Missing locales", http.StatusBadRequest)
+		return
+	}
+	locales := strings.Split(*localesParam, ",")
+
+	category := r.URL.Query().Get("category")
+
+	resData, err := h.app.Services.GetDataContentItemsMissingTranslations(claims, category, locales)
+	if err != nil {
+		log.Printf("Error on getting data content items missing translations - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if resData == nil {
+		resData = []model.MissingTranslation{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the missing translations")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// UpdateDataContentItem Updates a content item.
+// @Description Updates a content item
+// @Tags Admin
+// @ID AdminUpdateDataContentItem
+// @Accept json
+// @Produce json
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200 {object} model.DataContentItem
+// @Security AdminUserAuth
+// @Router /admin/data/ [put]
+func (h AdminApisHandler) UpdateDataContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+
+	var item model.DataContentItem
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the update data content item request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(item.Category) == 0 {
+		log.Printf("Unable to update content item: Missing category")
+		http.Error(w, "Unable to update content item: Missing category", http.StatusBadRequest)
+		return
+	}
+
+	if item.Data == nil {
+		log.Printf("Unable to update content item: Missing data")
+		http.Error(w, "Unable to update content item: Missing data", http.StatusBadRequest)
+		return
+	}
+
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on updating content item- %s\n", err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	resData, err := h.app.Services.UpdateDataContentItem(claims, &item, version)
+	if err != nil {
+		log.Printf("Error on updating content item- %s\n", err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the updated content item")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(resData.Version))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// DeleteDataContentItem Deletes a data content item with a specified key
+// @Description Deletes a data content item with the specified key. It is moved to the trash and it could be restored until the trash retention period passes.
+// @Tags Admin
+// @ID AdminDeleteDataContentItem
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/data/{key} [delete]
+func (h AdminApisHandler) DeleteDataContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	key := vars["key"]
+
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on deleting data content item with key - %s\n %s", key, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	err = h.app.Services.DeleteDataContentItem(claims, key, version)
+	if err != nil {
+		log.Printf("Error on deleting data content item with key - %s\n %s", key, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetDeletedDataContentItems Gives the data content items which are in the trash
+// @Description Gives the data content items which are in the trash, the last deleted first. They are removed for good after the trash retention period.
+// @Tags Admin
+// @ID AdminGetDeletedDataContentItems
+// @Param category query string false "category - get only the deleted data content items within this category"
+// @Success 200 {array} model.DataContentItem
+// @Security AdminUserAuth
+// @Router /admin/data/trash [get]
+func (h AdminApisHandler) GetDeletedDataContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	category := r.URL.Query().Get("category")
+
+	resData, err := h.app.Services.GetDeletedDataContentItems(claims, category)
+	if err != nil {
+		log.Printf("Error on getting deleted data content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if resData == nil {
+		resData = []model.DataContentItem{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the deleted data content items")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// RestoreDataContentItem Takes a data content item out of the trash
+// @Description Takes a data content item with the specified id out of the trash. It fails if another item with the same key has been created in the meantime or the category is in the trash as well.
+// @Tags Admin
+// @ID AdminRestoreDataContentItem
+// @Success 200 {object} model.DataContentItem
+// @Security AdminUserAuth
+// @Router /admin/data/trash/{id}/restore [post]
+func (h AdminApisHandler) RestoreDataContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.RestoreDataContentItem(claims, id)
+	if err != nil {
+		log.Printf("Error on restoring deleted data content item with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the restored data content item")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(resData.Version))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// CreateCategory Creates a category
+// @Description Creates a category
+// @Tags Admin
+// @ID AdminCreateCategory
+// @Accept json
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/categories [post]
+func (h AdminApisHandler) CreateCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var item model.Category
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the create a category - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	createdItem, err := h.app.Services.CreateCategory(claims, &item)
+	if err != nil {
+		log.Printf("Error on creating category %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(createdItem)
+	if err != nil {
+		log.Println("Error on marshal the new category")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// GetCategory Gets a category
+// @Description Gets a category
+// @Tags Admin
+// @ID AdminGetCategory
+// @Accept json
+// @Produce json
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/categories/{name} [get]
+func (h AdminApisHandler) GetCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	resData, err := h.app.Services.GetCategory(claims, name)
+	if err != nil {
+		log.Printf("Error on getting category with name - %s\n %s", name, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal of category")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if resData != nil {
+		w.Header().Set("ETag", versionETag(resData.Version))
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// UpdateCategory Updates a category with a specificed id.
+// @Description  Updates a category with a specificed id.
+// @Tags Admin
+// @ID AdminUpdateCategory
+// @Accept json
+// @Produce json
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200 {object} model.Category
+// @Security AdminUserAuth
+// @Router /admin/categories [put]
+func (h AdminApisHandler) UpdateCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var item model.Category
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the update category request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on updating category  - %s", err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	resData, err := h.app.Services.UpdateCategory(claims, &item, version)
+	if err != nil {
+		log.Printf("Error on updating category  - %s", err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the updated category")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(resData.Version))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// DeleteCategory Deletes a category with specified key
+// @Description Deletes a category with specified key. It is moved to the trash and it could be restored until the trash retention period passes.
+// @Tags Admin
+// @ID AdminDeleteCategory
+// @Param If-Match header string false "The version of the item which is expected to be changed, for example \"3\". It is responded with 412 when the item has been changed in the meantime."
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/categories/{name} [delete]
+func (h AdminApisHandler) DeleteCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		log.Printf("Error on deleting category with name - %s\n %s", name, err)
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	err = h.app.Services.DeleteCategory(claims, name, version)
+	if err != nil {
+		log.Printf("Error on deleting category with name - %s\n %s", name, err)
+		if handleVersionMismatchError(w, err) {
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetDeletedCategories Gives the categories which are in the trash
+// @Description Gives the categories which are in the trash, the last deleted first. They are removed for good after the trash retention period.
+// @Tags Admin
+// @ID AdminGetDeletedCategories
+// @Success 200 {array} model.Category
+// @Security AdminUserAuth
+// @Router /admin/categories/trash [get]
+func (h AdminApisHandler) GetDeletedCategories(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	resData, err := h.app.Services.GetDeletedCategories(claims)
+	if err != nil {
+		log.Printf("Error on getting deleted categories - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if resData == nil {
+		resData = []model.Category{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the deleted categories")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// RestoreCategory Takes a category out of the trash
+// @Description Takes a category with the specified id out of the trash. It fails if another category with the same name has been created in the meantime.
+// @Tags Admin
+// @ID AdminRestoreCategory
+// @Success 200 {object} model.Category
+// @Security AdminUserAuth
+// @Router /admin/categories/trash/{id}/restore [post]
+func (h AdminApisHandler) RestoreCategory(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.RestoreCategory(claims, id)
+	if err != nil {
+		log.Printf("Error on restoring deleted category with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the restored category")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(resData.Version))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// UploadFileContentItem Uploads a file to AWS S3
+// @Description Uploads a file to AWS S3
+// @Tags Admin
+// @ID AdminUploadFileContentItem
+// @Param fileName body string false "fileName - the uploaded file name"
+// @Param category body string false "category - category of file content item"
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/files [post]
+func (h AdminApisHandler) UploadFileContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+
+	fileName := r.FormValue("fileName")
+	if len(fileName) <= 0 {
+		log.Print("Missing file name\n")
+		http.Error(w, "missing 'fileName' form param", http.StatusBadRequest)
+		return
+	}
+
+	category := r.FormValue("category")
+	if len(category) <= 0 {
+		log.Print("Missing category\n")
+		http.Error(w, "missing 'category' form param", http.StatusBadRequest)
+		return
+	}
+
+	// parse and validate file and post parameters
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		log.Print("Invalid file\n")
+		http.Error(w, "Invalid file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	// pass the file to be processed by the use case handler
+	err = h.app.Services.UploadFileContentItem(file, claims, fileName, category)
+	if err != nil {
+		log.Printf("Error converting file: %s\n", err)
+		http.Error(w, "Error converting file", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetFileContentItem Get a file to AWS S3
+// @Description Get a file to AWS S3
+// @Tags Admin
+// @ID AdminGetFileContentItem
+// @Param fileName body string false "fileName - the uploaded file name"
+// @Param category body string false "category - category of file content item"
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/files [get]
+func (h AdminApisHandler) GetFileContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+
+	fileName := r.URL.Query().Get("fileName")
+	if len(fileName) <= 0 {
+		log.Print("Missing file name\n")
+		http.Error(w, "missing 'fileName' query param", http.StatusBadRequest)
+		return
+	}
+
+	category := r.URL.Query().Get("category")
+	if len(category) <= 0 {
+		log.Print("Missing category\n")
+		http.Error(w, "missing 'category' query param", http.StatusBadRequest)
+		return
+	}
+
+	fileData, err := h.app.Services.GetFileContentItem(claims, fileName, category)
+	if err != nil {
+		log.Printf("Error getting file download stream: %s\n", err)
+		http.Error(w, "Error getting file download stream", http.StatusInternalServerError)
+		return
+	}
+
+	_, err = io.Copy(w, fileData)
+	if err != nil {
+		log.Printf("Error copying file into response: %s\n", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+// DeleteFileContentItem Deletes a file content item
+// @Description Deletes a file content item
+// @Tags Admin
+// @ID AdminDeleteFileContentItem
+// @Success 200
+// @Security AdminUserAuth
+// @Router /admin/files [delete]
+func (h AdminApisHandler) DeleteFileContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	fileName := r.URL.Query().Get("fileName")
+	if len(fileName) <= 0 {
+		log.Print("Missing file name\n")
+		http.Error(w, "missing 'fileName' query param", http.StatusBadRequest)
+		return
+	}
+
+	category := r.URL.Query().Get("category")
+	if len(category) <= 0 {
+		log.Print("Missing category\n")
+		http.Error(w, "missing 'category' query param", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.Services.DeleteFileContentItem(claims, fileName, category)
+	if err != nil {
+		log.Printf("error on delete AWS file: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetAuditLogEntries Retrieves the audit log of the content changes
+// @Description Retrieves the audit log of the changes of the content items, the data content items, the categories, the files and the images. The latest entries are given first.
+// @Tags Admin
+// @ID AdminGetAuditLogEntries
+// @Param actor query string false "The subject of the user who made the changes"
+// @Param resource-type query string false "The type of the changed resource - content_item, data_content_item, category, file or image"
+// @Param resource-id query string false "The id of the changed resource, the path for the files and the images"
+// @Param from query string false "Only the changes made at or after this time, RFC 3339 formatted"
+// @Param to query string false "Only the changes made before this time, RFC 3339 formatted"
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param offset query string false "offset"
+// @Param limit query string false "limit - limit the result"
+// @Success 200 {array} model.AuditLogEntry
+// @Security AdminUserAuth
+// @Router /admin/audit_logs [get]
+func (h AdminApisHandler) GetAuditLogEntries(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	from, err := getTimeQueryParam(r, "from")
+	if err != nil {
+		log.Printf("Error on getting audit log entries - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := getTimeQueryParam(r, "to")
+	if err != nil {
+		log.Printf("Error on getting audit log entries - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter := model.AuditLogFilter{ActorSubject: getStringQueryParam(r, "actor"), ResourceType: getStringQueryParam(r, "resource-type"),
+		ResourceID: getStringQueryParam(r, "resource-id"), From: from, To: to}
+
+	offset := getInt64QueryParam(r, "offset")
+	limit := getInt64QueryParam(r, "limit")
+
+	resData, err := h.app.Services.GetAuditLogEntries(allApps, claims.AppID, claims.OrgID, filter, offset, limit)
+	if err != nil {
+		log.Printf("Error on getting audit log entries - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		resData = []model.AuditLogEntry{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the audit log entries")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetWebhooks Retrieves the webhooks
+// @Description Retrieves the webhooks which are subscribed for the content changes
+// @Tags Admin
+// @ID AdminGetWebhooks
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {array} model.Webhook
+// @Security AdminUserAuth
+// @Router /admin/webhooks [get]
+func (h AdminApisHandler) GetWebhooks(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	resData, err := h.app.Services.GetWebhooks(allApps, claims.AppID, claims.OrgID)
+	if err != nil {
+		log.Printf("Error on getting webhooks - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		resData = []model.Webhook{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the webhooks")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetWebhook Retrieves a webhook
+// @Description Retrieves a webhook
+// @Tags Admin
+// @ID AdminGetWebhook
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {object} model.Webhook
+// @Security AdminUserAuth
+// @Router /admin/webhooks/{id} [get]
+func (h AdminApisHandler) GetWebhook(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetWebhook(allApps, claims.AppID, claims.OrgID, id)
+	if err != nil {
+		log.Printf("Error on getting webhook with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	category := r.FormValue("category")
-	if len(category) <= 0 {
-		log.Print("Missing category\n")
-		http.Error(w, "missing 'category' form param", http.StatusBadRequest)
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the webhook")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
-	// parse and validate file and post parameters
-	file, _, err := r.FormFile("file")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// webhookRequestBody Expected body while creating or updating a webhook
+type webhookRequestBody struct {
+	AllApps       bool     `json:"all_apps"`
+	URL           string   `json:"url"`
+	Secret        string   `json:"secret"`         // generated when it is not set on create, kept when it is not set on update
+	Categories    []string `json:"categories"`     // the changes in all the categories are delivered when it is empty
+	ResourceTypes []string `json:"resource_types"` // content_item, data_content_item or file, all of them when it is empty
+	Active        *bool    `json:"active"`         // it is true by default
+} // @name webhookRequestBody
+
+func (b webhookRequestBody) toWebhook(id string) model.Webhook {
+	active := true
+	if b.Active != nil {
+		active = *b.Active
+	}
+	return model.Webhook{ID: id, URL: b.URL, Secret: b.Secret, Categories: b.Categories, ResourceTypes: b.ResourceTypes, Active: active}
+}
+
+// CreateWebhook Creates a webhook
+// @Description Creates a webhook. The content changes it is subscribed for are posted to its url as WebhookEvent JSON. The X-Webhook-Signature header of the requests is "sha256=" followed by the hex encoded HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>" with the webhook secret. The failed deliveries are retried with an exponential backoff.
+// @Tags Admin
+// @ID AdminCreateWebhook
+// @Accept json
+// @Param data body webhookRequestBody true "body json"
+// @Success 200 {object} model.WebhookWithSecret
+// @Security AdminUserAuth
+// @Router /admin/webhooks [post]
+func (h AdminApisHandler) CreateWebhook(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var item webhookRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
 	if err != nil {
-		log.Print("Invalid file\n")
-		http.Error(w, "Invalid file", http.StatusBadRequest)
+		log.Printf("Error on unmarshal the create webhook request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	defer file.Close()
 
-	// pass the file to be processed by the use case handler
-	err = h.app.Services.UploadFileContentItem(file, claims, fileName, category)
+	createdItem, err := h.app.Services.CreateWebhook(item.AllApps, claims.AppID, claims.OrgID, item.toWebhook(""))
 	if err != nil {
-		log.Printf("Error converting file: %s\n", err)
-		http.Error(w, "Error converting file", http.StatusInternalServerError)
+		log.Printf("Error on creating webhook: %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	//the secret is given only now and when it is rotated
+	jsonData, err := json.Marshal(model.WebhookWithSecret{Webhook: *createdItem, Secret: createdItem.Secret})
+	if err != nil {
+		log.Println("Error on marshal the new webhook")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
 }
 
-// GetFileContentItem Get a file to AWS S3
-// @Description Get a file to AWS S3
+// UpdateWebhook Updates a webhook
+// @Description Updates a webhook
 // @Tags Admin
-// @ID AdminGetFileContentItem
-// @Param fileName body string false "fileName - the uploaded file name"
-// @Param category body string false "category - category of file content item"
-// @Success 200
+// @ID AdminUpdateWebhook
+// @Accept json
+// @Param data body webhookRequestBody true "body json"
+// @Success 200 {object} model.Webhook
 // @Security AdminUserAuth
-// @Router /admin/files [get]
-func (h AdminApisHandler) GetFileContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+// @Router /admin/webhooks/{id} [put]
+func (h AdminApisHandler) UpdateWebhook(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
 
-	fileName := r.URL.Query().Get("fileName")
-	if len(fileName) <= 0 {
-		log.Print("Missing file name\n")
-		http.Error(w, "missing 'fileName' query param", http.StatusBadRequest)
+	var item webhookRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the update webhook request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	category := r.URL.Query().Get("category")
-	if len(category) <= 0 {
-		log.Print("Missing category\n")
-		http.Error(w, "missing 'category' query param", http.StatusBadRequest)
+	resData, err := h.app.Services.UpdateWebhook(item.AllApps, claims.AppID, claims.OrgID, item.toWebhook(id))
+	if err != nil {
+		log.Printf("Error on updating webhook with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	fileData, err := h.app.Services.GetFileContentItem(claims, fileName, category)
+	jsonData, err := json.Marshal(resData)
 	if err != nil {
-		log.Printf("Error getting file download stream: %s\n", err)
-		http.Error(w, "Error getting file download stream", http.StatusInternalServerError)
+		log.Println("Error on marshal the updated webhook")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
-	_, err = io.Copy(w, fileData)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// RotateWebhookSecret Rotates the secret of a webhook
+// @Description Generates a new secret for a webhook. The deliveries are signed with it from now on. The secret is not given by the other webhook APIs.
+// @Tags Admin
+// @ID AdminRotateWebhookSecret
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {object} model.WebhookWithSecret
+// @Security AdminUserAuth
+// @Router /admin/webhooks/{id}/secret [post]
+func (h AdminApisHandler) RotateWebhookSecret(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.RotateWebhookSecret(allApps, claims.AppID, claims.OrgID, id)
 	if err != nil {
-		log.Printf("Error copying file into response: %s\n", err.Error())
+		log.Printf("Error on rotating the secret of webhook with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
-	w.Header().Set("Cache-Control", "no-store")
+	jsonData, err := json.Marshal(model.WebhookWithSecret{Webhook: *resData, Secret: resData.Secret})
+	if err != nil {
+		log.Println("Error on marshal the webhook")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
 }
 
-// DeleteFileContentItem Deletes a file content item
-// @Description Deletes a file content item
+// DeleteWebhook Deletes a webhook
+// @Description Deletes a webhook and its deliveries
 // @Tags Admin
-// @ID AdminDeleteFileContentItem
+// @ID AdminDeleteWebhook
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
 // @Success 200
 // @Security AdminUserAuth
-// @Router /admin/files [delete]
-func (h AdminApisHandler) DeleteFileContentItem(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
-	fileName := r.URL.Query().Get("fileName")
-	if len(fileName) <= 0 {
-		log.Print("Missing file name\n")
-		http.Error(w, "missing 'fileName' query param", http.StatusBadRequest)
+// @Router /admin/webhooks/{id} [delete]
+func (h AdminApisHandler) DeleteWebhook(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	err := h.app.Services.DeleteWebhook(allApps, claims.AppID, claims.OrgID, id)
+	if err != nil {
+		log.Printf("Error on deleting webhook with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	category := r.URL.Query().Get("category")
-	if len(category) <= 0 {
-		log.Print("Missing category\n")
-		http.Error(w, "missing 'category' query param", http.StatusBadRequest)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetWebhookDeliveries Retrieves the deliveries of a webhook
+// @Description Retrieves the deliveries of a webhook with all their attempts. The latest deliveries are given first.
+// @Tags Admin
+// @ID AdminGetWebhookDeliveries
+// @Param status query string false "pending, succeeded or failed"
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param offset query string false "offset"
+// @Param limit query string false "limit - limit the result"
+// @Success 200 {array} model.WebhookDelivery
+// @Security AdminUserAuth
+// @Router /admin/webhooks/{id}/deliveries [get]
+func (h AdminApisHandler) GetWebhookDeliveries(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	status := getStringQueryParam(r, "status")
+	offset := getInt64QueryParam(r, "offset")
+	limit := getInt64QueryParam(r, "limit")
+
+	resData, err := h.app.Services.GetWebhookDeliveries(allApps, claims.AppID, claims.OrgID, id, status, offset, limit)
+	if err != nil {
+		log.Printf("Error on getting deliveries of webhook with id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	err := h.app.Services.DeleteFileContentItem(claims, fileName, category)
+	if resData == nil {
+		resData = []model.WebhookDelivery{}
+	}
+
+	data, err := json.Marshal(resData)
 	if err != nil {
-		log.Printf("error on delete AWS file: %s", err)
+		log.Println("Error on marshal the webhook deliveries")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetWebhookDelivery Retrieves a delivery of a webhook
+// @Description Retrieves a delivery of a webhook with all its attempts
+// @Tags Admin
+// @ID AdminGetWebhookDelivery
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {object} model.WebhookDelivery
+// @Security AdminUserAuth
+// @Router /admin/webhooks/{id}/deliveries/{delivery-id} [get]
+func (h AdminApisHandler) GetWebhookDelivery(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	deliveryID := vars["delivery-id"]
+
+	resData, err := h.app.Services.GetWebhookDelivery(allApps, claims.AppID, claims.OrgID, id, deliveryID)
+	if err != nil {
+		log.Printf("Error on getting delivery with id - %s\n %s", deliveryID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the webhook delivery")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// ReplayWebhookDelivery Sends again a delivery of a webhook
+// @Description Sends again the payload of a delivery which is not pending. It gives the new delivery, it has the id of the replayed one in replay_of.
+// @Tags Admin
+// @ID AdminReplayWebhookDelivery
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Success 200 {object} model.WebhookDelivery
+// @Security AdminUserAuth
+// @Router /admin/webhooks/{id}/deliveries/{delivery-id}/replay [post]
+func (h AdminApisHandler) ReplayWebhookDelivery(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	deliveryID := vars["delivery-id"]
+
+	resData, err := h.app.Services.ReplayWebhookDelivery(allApps, claims.AppID, claims.OrgID, id, deliveryID)
+	if err != nil {
+		log.Printf("Error on replaying delivery with id - %s\n %s", deliveryID, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the replayed webhook delivery")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetOrgSettings Retrieves the content settings of the organization
+// @Description Retrieves the content settings of the organization. The defaults are given until they are set.
+// @Tags Admin
+// @ID AdminGetOrgSettings
+// @Success 200 {object} model.OrgSettings
+// @Security AdminUserAuth
+// @Router /admin/settings [get]
+func (h AdminApisHandler) GetOrgSettings(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	resData, err := h.app.Services.GetOrgSettings(claims.OrgID)
+	if err != nil {
+		log.Printf("Error on getting org settings - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the org settings")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// updateOrgSettingsRequestBody Expected body while updating the content settings of the organization
+type updateOrgSettingsRequestBody struct {
+	RequireRegisteredCategories bool                 `json:"require_registered_categories"`
+	HTMLSanitizer               *model.HTMLSanitizer `json:"html_sanitizer"`
+} // @name updateOrgSettingsRequestBody
+
+// UpdateOrgSettings Updates the content settings of the organization
+// @Description Updates the content settings of the organization. When require_registered_categories is true, the content items could be created only in the registered categories. The html_sanitizer defines what the rich text fields of the content items could have, the defaults are used when it is not given.
+// @Tags Admin
+// @ID AdminUpdateOrgSettings
+// @Accept json
+// @Param data body updateOrgSettingsRequestBody true "body json"
+// @Success 200 {object} model.OrgSettings
+// @Security AdminUserAuth
+// @Router /admin/settings [put]
+func (h AdminApisHandler) UpdateOrgSettings(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	var item updateOrgSettingsRequestBody
+	err := json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("Error on unmarshal the update org settings request data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	settings := model.OrgSettings{RequireRegisteredCategories: item.RequireRegisteredCategories, HTMLSanitizer: item.HTMLSanitizer}
+	resData, err := h.app.Services.UpdateOrgSettings(claims.OrgID, settings)
+	if err != nil {
+		log.Printf("Error on updating org settings - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the org settings")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
+	w.Write(data)
 }
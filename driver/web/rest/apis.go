@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
@@ -35,6 +36,9 @@ import (
 
 const maxUploadSize = 15 * 1024 * 1024 // 15 mb
 
+// contentItemsStreamKeepAlive is how often a comment is sent to the idle content items streams
+const contentItemsStreamKeepAlive = 30 * time.Second
+
 // ApisHandler handles the rest APIs implementation
 type ApisHandler struct {
 	app *core.Application
@@ -464,15 +468,24 @@ func (h ApisHandler) GetHealthLocation(claims *tokenauth.Claims, w http.Response
 }
 
 // GetContentItems Retrieves  all content items. <b> The data element could be either a primitive or nested json or array.</b>
-// @Description Retrieves  all content items. <b> The data element could be either a primitive or nested json or array.</b>
+// @Description Retrieves  all content items. <b> The data element could be either a primitive or nested json or array.</b> Only the published items within their publishing window are given.
 // @Tags Client
 // @ID GetContentItems
 // @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
 // @Param offset query string false "offset"
 // @Param limit query string false "limit - limit the result"
-// @Param order query string false "order - Possible values: asc, desc. Default: desc"
+// @Param order query string false "order - Possible values: asc, desc, position. Default: desc. position is the manual order - the pinned items first, then the items by their position and at last the items without position."
+// @Param cursor query string false "cursor - Pass it empty for the first page and then the next_cursor from the previous page. When it is passed the response is a page of items with the total count and offset is ignored."
+// @Param data.{path} query string false "Filter on a data field - data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu. Operators: eq (default), ne, in, nin, gt, gte, lt, lte, exists. The values of in and nin are coma separated."
+// @Param fields query string false "fields - Coma separated fields to give back, for example id,data.title. id and date_created are always given. All the fields are given by default."
+// @Param expand query integer false "How many levels of the referenced content items are put in place of their ids in the data, none by default and at most 3. The references are the data fields declared with \"format\": \"content-item-ref\" in the schema of the category."
 // @Param data body getContentItemsRequestBody false "Optional - body json of the all items ids that need to be filtered. NOTE: Bad/broken json will be interpreted as an empty filter and the request will be proceeded further."
 // @Accept json
+// @Param format query string false "The format of the markdown fields of the data - markdown (default) as they have been written, html rendered and sanitized or text. The markdown fields are the data fields declared with \"format\": \"markdown\" in the schema of the category."
+// @Param locale query string false "locale - The preferred locale, for example es-MX. It comes before the Accept-Language locales. The data is given in the first locale which the item has, then in the fallback locales and at last as it is."
+// @Param Accept-Language header string false "The preferred locales"
+// @Param X-App-Version header string false "The version of the app, for example 3.2.1. The items for specific app versions are not given without it."
+// @Param If-None-Match header string false "The ETag of the data which the client already has. It is responded with 304 and no body when the data has not been changed."
 // @Success 200 {array} model.ContentItem
 // @Security UserAuth
 // @Router /content_items [get]
@@ -518,7 +531,60 @@ func (h ApisHandler) GetContentItems(claims *tokenauth.Claims, w http.ResponseWr
 		}
 	}
 
-	resData, err := h.app.Services.GetContentItems(allApps, claims.AppID, claims.OrgID, body.IDs, body.Categories, offset, limit, order)
+	paginate, cursor, err := getCursorQueryParam(r)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = checkCursorOrder(cursor, order)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dataQuery, err := getDataQueryParams(r)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	expand, err := getExpandQueryParam(r)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format, err := getFormatQueryParam(r)
+	if err != nil {
+		log.Printf("Error on getting content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if paginate {
+		page, err := h.app.Services.GetContentItemsPage(allApps, claims.AppID, claims.OrgID, body.IDs, body.Categories, dataQuery, cursor, limit, order, true, getLocalesParam(r), format, expand, getContentItemsViewer(claims, r))
+		if err != nil {
+			log.Printf("Error on getting content items page - %s\n", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		data, err := json.Marshal(page)
+		if err != nil {
+			log.Println("Error on marshal the content items page")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		writeConditionalJSON(w, r, data)
+		return
+	}
+
+	resData, err := h.app.Services.GetContentItems(allApps, claims.AppID, claims.OrgID, body.IDs, body.Categories, dataQuery, offset, limit, order, true, getLocalesParam(r), format, expand, getContentItemsViewer(claims, r))
 	if err != nil {
 		log.Printf("Error on cgetting content items - %s\n", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -536,18 +602,180 @@ func (h ApisHandler) GetContentItems(claims *tokenauth.Claims, w http.ResponseWr
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	writeConditionalJSON(w, r, data)
+}
+
+// GetContentItemsStream Pushes the changes of the content items as Server-Sent Events
+// @Description Pushes the changes of the published content items as Server-Sent Events until the client disconnects. Every event has the position of the change as id, its type (created, updated, deleted or reset) as event and the change as data. The deleted event is given also when an item is not visible to the clients anymore. The items are given as updated or deleted also at the times when they are published or expire. The reset event says that the changes after the passed Last-Event-ID are not available anymore, so the client has to load the items again. Every instance keeps the latest changes on its own, so the client which reconnects to another instance gets the reset event.
+// @Tags Client
+// @ID GetContentItemsStream
+// @Param categories query string false "Coma separated categories of the desired records, all of them by default"
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param format query string false "The format of the markdown fields of the data - markdown (default) as they have been written, html rendered and sanitized or text. The markdown fields are the data fields declared with \"format\": \"markdown\" in the schema of the category."
+// @Param locale query string false "locale - The preferred locale, for example es-MX. It comes before the Accept-Language locales. The data is given in the first locale which the item has, then in the fallback locales and at last as it is."
+// @Param Accept-Language header string false "The preferred locales"
+// @Param X-App-Version header string false "The version of the app, for example 3.2.1. The items for specific app versions are not given without it."
+// @Param Last-Event-ID header string false "The id of the last event which the client has got, the changes after it are given first"
+// @Produce text/event-stream
+// @Success 200 {object} model.ContentItemEvent
+// @Security UserAuth
+// @Router /content_items/stream [get]
+func (h ApisHandler) GetContentItemsStream(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Println("Error on streaming content items - streaming is not supported")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	var categories []string
+	categoriesParam := getStringQueryParam(r, "categories")
+	if categoriesParam != nil {
+		categories = strings.Split(*categoriesParam, ",")
+	}
+
+	format, err := getFormatQueryParam(r)
+	if err != nil {
+		log.Printf("Error on subscribing to content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	events, cancel, err := h.app.Services.SubscribeContentItems(allApps, claims.AppID, claims.OrgID, categories, r.Header.Get("Last-Event-ID"), getLocalesParam(r), format, getContentItemsViewer(claims, r))
+	if err != nil {
+		log.Printf("Error on subscribing to content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
 	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(contentItemsStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			//keep the proxies from closing the idle connection
+			_, err := fmt.Fprint(w, ": keep-alive\n\n")
+			if err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				//the client is too slow, it reconnects with the last event it has got
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Printf("Error on marshal content item event - %s\n", err)
+				continue
+			}
+			_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+			if err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// SearchContentItems Searches the content items by text
+// @Description Searches the content items by text. The most relevant items are given first, each with its relevance "score". Only the published items within their publishing window are given.
+// @Tags Client
+// @ID SearchContentItems
+// @Param text query string true "The text to search for"
+// @Param categories query string false "Coma separated categories of the desired records"
+// @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param offset query string false "offset"
+// @Param limit query string false "limit - limit the result"
+// @Param format query string false "The format of the markdown fields of the data - markdown (default) as they have been written, html rendered and sanitized or text. The markdown fields are the data fields declared with \"format\": \"markdown\" in the schema of the category."
+// @Param locale query string false "locale - The preferred locale, for example es-MX. It comes before the Accept-Language locales. The data is given in the first locale which the item has, then in the fallback locales and at last as it is."
+// @Param Accept-Language header string false "The preferred locales"
+// @Param X-App-Version header string false "The version of the app, for example 3.2.1. The items for specific app versions are not given without it."
+// @Param If-None-Match header string false "The ETag of the data which the client already has. It is responded with 304 and no body when the data has not been changed."
+// @Success 200 {array} model.ContentItem
+// @Security UserAuth
+// @Router /content_items/search [get]
+func (h ApisHandler) SearchContentItems(claims *tokenauth.Claims, w http.ResponseWriter, r *http.Request) {
+	//get all-apps param value
+	allApps := false //false by defautl
+	allAppsParam := r.URL.Query().Get("all-apps")
+	if allAppsParam != "" {
+		allApps, _ = strconv.ParseBool(allAppsParam)
+	}
+
+	text := getStringQueryParam(r, "text")
+	if text == nil {
+		log.Printf("Unable to search content items: Missing text")
+		http.Error(w, "Unable to search content items: Missing text", http.StatusBadRequest)
+		return
+	}
+
+	var categories []string
+	categoriesParam := getStringQueryParam(r, "categories")
+	if categoriesParam != nil {
+		categories = strings.Split(*categoriesParam, ",")
+	}
+
+	offset := getInt64QueryParam(r, "offset")
+	limit := getInt64QueryParam(r, "limit")
+
+	format, err := getFormatQueryParam(r)
+	if err != nil {
+		log.Printf("Error on searching content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.SearchContentItems(allApps, claims.AppID, claims.OrgID, *text, categories, offset, limit, true, getLocalesParam(r), format, getContentItemsViewer(claims, r))
+	if err != nil {
+		log.Printf("Error on searching content items - %s\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		resData = []model.ContentItemResponse{}
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Println("Error on marshal the found content items")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	writeConditionalJSON(w, r, data)
 }
 
 // GetContentItem Retrieves a content item by id. <b> The data element could be either a primitive or nested json or array.</b>
-// @Description Retrieves a content item by id. <b> The data element could be either a primitive or nested json or array.</b>
+// @Description Retrieves a content item by id. <b> The data element could be either a primitive or nested json or array.</b> Only the published items within their publishing window are given.
 // @Tags Client
 // @ID GetContentItem
 // @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param expand query integer false "How many levels of the referenced content items are put in place of their ids in the data, none by default and at most 3. The references are the data fields declared with \"format\": \"content-item-ref\" in the schema of the category."
 // @Accept json
 // @Produce json
+// @Param format query string false "The format of the markdown fields of the data - markdown (default) as they have been written, html rendered and sanitized or text. The markdown fields are the data fields declared with \"format\": \"markdown\" in the schema of the category."
+// @Param locale query string false "locale - The preferred locale, for example es-MX. It comes before the Accept-Language locales. The data is given in the first locale which the item has, then in the fallback locales and at last as it is."
+// @Param Accept-Language header string false "The preferred locales"
+// @Param X-App-Version header string false "The version of the app, for example 3.2.1. The items for specific app versions are not given without it."
+// @Param If-None-Match header string false "The ETag of the data which the client already has. It is responded with 304 and no body when the data has not been changed."
 // @Success 200 {object} model.ContentItem
 // @Security UserAuth
 // @Router /content_items/{id} [get]
@@ -562,7 +790,21 @@ func (h ApisHandler) GetContentItem(claims *tokenauth.Claims, w http.ResponseWri
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	resData, err := h.app.Services.GetContentItem(allApps, claims.AppID, claims.OrgID, id)
+	expand, err := getExpandQueryParam(r)
+	if err != nil {
+		log.Printf("Error on getting content item id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format, err := getFormatQueryParam(r)
+	if err != nil {
+		log.Printf("Error on getting content item id - %s\n %s", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.GetContentItem(allApps, claims.AppID, claims.OrgID, id, true, getLocalesParam(r), format, expand, getContentItemsViewer(claims, r))
 	if err != nil {
 		log.Printf("Error on getting content item id - %s\n %s", id, err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -576,16 +818,15 @@ func (h ApisHandler) GetContentItem(claims *tokenauth.Claims, w http.ResponseWri
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	writeConditionalJSON(w, r, data)
 }
 
 // GetContentItemsCategories Retrieves  all content item categories that have in the database
-// @Description Retrieves  all content item categories that have in the database
+// @Description Retrieves  all content item categories that have in the database. The categories which the user cannot read are not given.
 // @Tags Client
 // @ID GetContentItemsCategories
 // @Param all-apps query boolean false "It says if the data is associated with the current app or it is for all the apps within the organization. It is 'false' by default."
+// @Param If-None-Match header string false "The ETag of the data which the client already has. It is responded with 304 and no body when the data has not been changed."
 // @Success 200
 // @Security UserAuth
 // @Router /content_item/categories [get]
@@ -597,7 +838,7 @@ func (h ApisHandler) GetContentItemsCategories(claims *tokenauth.Claims, w http.
 		allApps, _ = strconv.ParseBool(allAppsParam)
 	}
 
-	resData, err := h.app.Services.GetContentItemsCategories(allApps, claims.AppID, claims.OrgID)
+	resData, err := h.app.Services.GetContentItemsCategories(allApps, claims.AppID, claims.OrgID, getContentItemsViewer(claims, r))
 	if err != nil {
 		log.Printf("Error on getting content items - %s\n", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -615,9 +856,7 @@ func (h ApisHandler) GetContentItemsCategories(claims *tokenauth.Claims, w http.
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	writeConditionalJSON(w, r, data)
 }
 
 // UploadImage Uploads an image to AWS S3
@@ -685,7 +924,7 @@ func (h ApisHandler) UploadImage(claims *tokenauth.Claims, w http.ResponseWriter
 	}
 
 	// pass the file to be processed by the use case handler
-	objectLocation, err := h.app.Services.UploadImage(fileBytes, path, imgSpec)
+	objectLocation, err := h.app.Services.UploadImage(claims, fileBytes, path, imgSpec)
 	if err != nil {
 		log.Printf("Error converting image: %s\n", err)
 		http.Error(w, "Error converting image", http.StatusInternalServerError)
@@ -760,6 +999,9 @@ func (h ApisHandler) GetTweeterPosts(claims *tokenauth.Claims, w http.ResponseWr
 // @ID GetDataContentItem
 // @Accept json
 // @Produce json
+// @Param locale query string false "locale - The preferred locale, for example es-MX. It comes before the Accept-Language locales. The data is given in the first locale which the item has, then in the fallback locales and at last as it is."
+// @Param Accept-Language header string false "The preferred locales"
+// @Param If-None-Match header string false "The ETag of the data which the client already has. It is responded with 304 and no body when the data has not been changed."
 // @Success 200
 // @Security UserAuth
 // @Router /data/{key} [get]
@@ -767,7 +1009,7 @@ func (h ApisHandler) GetDataContentItem(claims *tokenauth.Claims, w http.Respons
 	vars := mux.Vars(r)
 	key := vars["key"]
 
-	resData, err := h.app.Services.GetDataContentItem(claims, key)
+	resData, err := h.app.Services.GetDataContentItem(claims, key, getLocalesParam(r), true)
 	if err != nil {
 		log.Printf("Error on getting data content type with key - %s\n %s", key, err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -781,9 +1023,7 @@ func (h ApisHandler) GetDataContentItem(claims *tokenauth.Claims, w http.Respons
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	writeConditionalJSON(w, r, data)
 }
 
 // GetFileContentItem Get a file to AWS S3
@@ -939,6 +1179,9 @@ func (h ApisHandler) GetFileContentDownloadURLs(claims *tokenauth.Claims, w http
 // @Param category body string false "category - get all data content items based on category"
 // @Accept json
 // @Produce json
+// @Param locale query string false "locale - The preferred locale, for example es-MX. It comes before the Accept-Language locales. The data is given in the first locale which the item has, then in the fallback locales and at last as it is."
+// @Param Accept-Language header string false "The preferred locales"
+// @Param If-None-Match header string false "The ETag of the data which the client already has. It is responded with 304 and no body when the data has not been changed."
 // @Success 200
 // @Security UserAuth
 // @Router /data [get]
@@ -950,7 +1193,7 @@ func (h ApisHandler) GetDataContentItems(claims *tokenauth.Claims, w http.Respon
 		return
 	}
 
-	resData, err := h.app.Services.GetDataContentItems(claims, category)
+	resData, err := h.app.Services.GetDataContentItems(claims, category, getLocalesParam(r), true)
 	if err != nil {
 		log.Printf("Error on getting data content items with category - %s\n %s", category, err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -964,9 +1207,7 @@ func (h ApisHandler) GetDataContentItems(claims *tokenauth.Claims, w http.Respon
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	writeConditionalJSON(w, r, data)
 }
 
 func intPostValueFromString(stringValue string) int {
@@ -95,7 +95,7 @@ func (h BBsApisHandler) UploadImage(claims *tokenauth.Claims, w http.ResponseWri
 	}
 
 	// pass the file to be processed by the use case handler
-	url, err := h.app.Services.UploadImage(fileBytes, path, imgSpec)
+	url, err := h.app.Services.UploadImage(claims, fileBytes, path, imgSpec)
 	if err != nil {
 		log.Printf("Error converting image: %s\n", err)
 		http.Error(w, "Error converting image", http.StatusInternalServerError)
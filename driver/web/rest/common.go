@@ -15,8 +15,25 @@
 package rest
 
 import (
+	"bytes"
+	"content/core/model"
+	"content/utils"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
 	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
 )
 
 func getStringQueryParam(r *http.Request, paramName string) *string {
@@ -39,6 +56,45 @@ func getInt64QueryParam(r *http.Request, paramName string) *int64 {
 	return nil
 }
 
+// getTimeQueryParam gives the RFC 3339 time passed in the query param, nil if it is not passed
+func getTimeQueryParam(r *http.Request, paramName string) (*time.Time, error) {
+	param := getStringQueryParam(r, paramName)
+	if param == nil {
+		return nil, nil
+	}
+	value, err := time.Parse(time.RFC3339, *param)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s - RFC 3339 time is expected", paramName)
+	}
+	return &value, nil
+}
+
+// getExpandQueryParam gives how many levels of the referenced content items should be expanded, none by default
+func getExpandQueryParam(r *http.Request) (int, error) {
+	param := getStringQueryParam(r, "expand")
+	if param == nil {
+		return 0, nil
+	}
+	expand, err := strconv.Atoi(*param)
+	if err != nil || expand < 0 {
+		return 0, fmt.Errorf("invalid expand %s - a non-negative number is expected", *param)
+	}
+	return expand, nil
+}
+
+// getFormatQueryParam gives the format of the markdown fields of the content items, they are given as they have been written by default
+func getFormatQueryParam(r *http.Request) (string, error) {
+	param := getStringQueryParam(r, "format")
+	if param == nil {
+		return model.ContentItemFormatMarkdown, nil
+	}
+	switch *param {
+	case model.ContentItemFormatMarkdown, model.ContentItemFormatHTML, model.ContentItemFormatText:
+		return *param, nil
+	}
+	return "", fmt.Errorf("invalid format %s - markdown, html or text is expected", *param)
+}
+
 func getIntQueryParam(r *http.Request, paramName string, defaultValue int) int {
 	params, ok := r.URL.Query()[paramName]
 	if ok && len(params[0]) > 0 {
@@ -62,3 +118,306 @@ func getBoolQueryParam(r *http.Request, paramName string, defaultValue bool) boo
 	}
 	return defaultValue
 }
+
+// getCursorQueryParam says if cursor pagination is requested and gives the decoded cursor.
+// The cursor param is passed empty for the first page.
+func getCursorQueryParam(r *http.Request) (bool, *model.ContentItemsCursor, error) {
+	params, ok := r.URL.Query()["cursor"]
+	if !ok {
+		return false, nil, nil
+	}
+	if len(params[0]) == 0 {
+		return true, nil, nil
+	}
+	cursor, err := model.DecodeContentItemsCursor(params[0])
+	if err != nil {
+		return true, nil, err
+	}
+	return true, cursor, nil
+}
+
+const maxDataFilterPathDepth int = 10
+
+var (
+	dataFilterKeyPattern       = regexp.MustCompile(`^(data(?:\.[A-Za-z0-9_-]+)+)(?:\[([a-z]+)\])?$`)
+	fieldPathPattern           = regexp.MustCompile(`^[A-Za-z0-9_-]+(?:\.[A-Za-z0-9_-]+)*$`)
+	allowedDataFilterOperators = map[string]bool{
+		model.DataFilterOperatorEq: true, model.DataFilterOperatorNe: true,
+		model.DataFilterOperatorIn: true, model.DataFilterOperatorNin: true,
+		model.DataFilterOperatorGt: true, model.DataFilterOperatorGte: true,
+		model.DataFilterOperatorLt: true, model.DataFilterOperatorLte: true,
+		model.DataFilterOperatorExists: true,
+	}
+)
+
+// getDataQueryParams gives the filters on the content items data and the fields projection from the query params.
+// The filters are passed as data.<path>=<value> or data.<path>[<operator>]=<value>, for example data.building=ECEB&data.tags[in]=covid,flu.
+// The fields are passed as coma separated list, for example fields=id,data.title
+func getDataQueryParams(r *http.Request) (*model.ContentItemsDataQuery, error) {
+	var query model.ContentItemsDataQuery
+
+	filter := utils.ConstructFilter(r)
+	if filter != nil {
+		for _, item := range filter.Items {
+			if !strings.HasPrefix(item.Field, "data.") {
+				continue
+			}
+			dataFilter, err := constructDataFilter(item)
+			if err != nil {
+				return nil, err
+			}
+			query.Filters = append(query.Filters, *dataFilter)
+		}
+	}
+
+	//the query params come in random order
+	sort.Slice(query.Filters, func(i, j int) bool {
+		if query.Filters[i].Path != query.Filters[j].Path {
+			return query.Filters[i].Path < query.Filters[j].Path
+		}
+		return query.Filters[i].Operator < query.Filters[j].Operator
+	})
+
+	fieldsParam := getStringQueryParam(r, "fields")
+	if fieldsParam != nil {
+		for _, field := range strings.Split(*fieldsParam, ",") {
+			field = strings.TrimSpace(field)
+			if len(field) == 0 {
+				continue
+			}
+			if !fieldPathPattern.MatchString(field) || strings.Count(field, ".") >= maxDataFilterPathDepth {
+				return nil, fmt.Errorf("invalid field %s", field)
+			}
+			query.Fields = append(query.Fields, field)
+		}
+	}
+
+	if len(query.Filters) == 0 && len(query.Fields) == 0 {
+		return nil, nil
+	}
+	return &query, nil
+}
+
+func constructDataFilter(item utils.FilterItem) (*model.DataFilter, error) {
+	matches := dataFilterKeyPattern.FindStringSubmatch(item.Field)
+	if matches == nil || strings.Count(matches[1], ".") > maxDataFilterPathDepth {
+		return nil, fmt.Errorf("invalid filter %s", item.Field)
+	}
+	path := matches[1]
+	operator := matches[2]
+	if len(operator) == 0 {
+		operator = model.DataFilterOperatorEq
+	}
+	if !allowedDataFilterOperators[operator] {
+		return nil, fmt.Errorf("not supported filter operator %s for %s", operator, path)
+	}
+
+	values := []string{}
+	switch operator {
+	case model.DataFilterOperatorIn, model.DataFilterOperatorNin:
+		for _, value := range item.Value {
+			values = append(values, strings.Split(value, ",")...)
+		}
+	case model.DataFilterOperatorEq, model.DataFilterOperatorNe:
+		//the same param could be passed more than once
+		values = item.Value
+	default:
+		if len(item.Value) != 1 {
+			return nil, fmt.Errorf("filter %s must have a single value", item.Field)
+		}
+		values = item.Value
+	}
+	if operator == model.DataFilterOperatorExists {
+		if _, err := strconv.ParseBool(values[0]); err != nil {
+			return nil, fmt.Errorf("filter %s must be true or false", item.Field)
+		}
+	}
+
+	return &model.DataFilter{Path: path, Operator: operator, Values: values}, nil
+}
+
+// checkCursorOrder makes sure that the cursor has been given for the requested order
+func checkCursorOrder(cursor *model.ContentItemsCursor, order *string) error {
+	if cursor != nil && (order != nil && *order == model.ContentItemsOrderPosition) != (cursor.Position != nil) {
+		return errors.New("invalid cursor - it is for another order")
+	}
+	return nil
+}
+
+// getContentItemsViewer gives the client which reads the content items. The user is known only from the token, the app version is given by the app in the header.
+// The app version is ignored when it is not in the x.x.x or x.x format.
+func getContentItemsViewer(claims *tokenauth.Claims, r *http.Request) *model.ContentItemsViewer {
+	viewer := model.ContentItemsViewer{Anonymous: claims.Anonymous, Permissions: utils.GetListValue(claims.Permissions)}
+
+	appVersion := strings.TrimSpace(r.Header.Get("X-App-Version"))
+	if _, err := utils.VersionNumber(appVersion); err == nil {
+		viewer.AppVersion = &appVersion
+	}
+	return &viewer
+}
+
+// getLocalesParam gives the locales preferred by the client, the most preferred first.
+// The locale query param comes first and then the languages from the Accept-Language header by their weight.
+func getLocalesParam(r *http.Request) []string {
+	locales := []string{}
+	locale := getStringQueryParam(r, "locale")
+	if locale != nil {
+		locales = append(locales, *locale)
+	}
+
+	type weightedLanguage struct {
+		language string
+		weight   float64
+	}
+	languages := []weightedLanguage{}
+	for _, header := range r.Header.Values("Accept-Language") {
+		for _, part := range strings.Split(header, ",") {
+			elements := strings.Split(strings.TrimSpace(part), ";")
+			language := strings.TrimSpace(elements[0])
+			if len(language) == 0 || language == "*" {
+				continue
+			}
+			weight := 1.0
+			for _, element := range elements[1:] {
+				element = strings.TrimSpace(element)
+				if strings.HasPrefix(element, "q=") {
+					value, err := strconv.ParseFloat(element[2:], 64)
+					if err == nil {
+						weight = value
+					}
+				}
+			}
+			if weight > 0 {
+				languages = append(languages, weightedLanguage{language: language, weight: weight})
+			}
+		}
+	}
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].weight > languages[j].weight
+	})
+	for _, language := range languages {
+		locales = append(locales, language.language)
+	}
+	return locales
+}
+
+// schemaValidationErrorResponse is the body of the response when the content data does not match the schema of its category
+type schemaValidationErrorResponse struct {
+	Message string                   `json:"message"`
+	Errors  []model.SchemaFieldError `json:"errors"`
+} // @name schemaValidationErrorResponse
+
+// handleSchemaValidationError responds with 400 and the field errors if err is a schema validation error.
+// It returns false without writing anything for any other error.
+func handleSchemaValidationError(w http.ResponseWriter, err error) bool {
+	var validationErr *model.SchemaValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+
+	data, err := json.Marshal(schemaValidationErrorResponse{Message: validationErr.Error(), Errors: validationErr.Errors})
+	if err != nil {
+		log.Println("Error on marshal the schema validation errors")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return true
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusBadRequest)
+	w.Write(data)
+	return true
+}
+
+// getDataPatch gives the type and the body of a data patch. The type is told by the Content-Type header -
+// application/merge-patch+json or application/json-patch+json. For any other, a list is a JSON patch and anything else is a merge patch.
+func getDataPatch(r *http.Request) (string, json.RawMessage, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", nil, err
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return "", nil, errors.New("missing patch")
+	}
+	if !json.Valid(body) {
+		return "", nil, errors.New("invalid patch json")
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/merge-patch+json":
+		return model.DataPatchTypeMerge, body, nil
+	case mediaType == "application/json-patch+json" || body[0] == '[':
+		return model.DataPatchTypeJSON, body, nil
+	default:
+		return model.DataPatchTypeMerge, body, nil
+	}
+}
+
+// getIfMatchVersion gives the version which the client expects to replace from the If-Match header.
+// It gives nil when the header is missing or it is "*". The entity tags are the versions, for example "3".
+func getIfMatchVersion(r *http.Request) (*int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if len(header) == 0 || header == "*" {
+		return nil, nil
+	}
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), "\"")
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid If-Match header %s", header)
+	}
+	return &version, nil
+}
+
+// handleVersionMismatchError responds with 412 if err is a version mismatch error.
+// It returns false without writing anything for any other error.
+func handleVersionMismatchError(w http.ResponseWriter, err error) bool {
+	var mismatchErr *model.VersionMismatchError
+	if !errors.As(err, &mismatchErr) {
+		return false
+	}
+
+	w.Header().Set("ETag", versionETag(mismatchErr.Current))
+	http.Error(w, mismatchErr.Error(), http.StatusPreconditionFailed)
+	return true
+}
+
+func versionETag(version int64) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// contentItemVersion gives the version of a content item as it comes from the storage
+func contentItemVersion(item model.ContentItemResponse) int64 {
+	switch version := item["version"].(type) {
+	case int32:
+		return int64(version)
+	case int64:
+		return version
+	case float64:
+		return int64(version)
+	}
+	return 0
+}
+
+// writeConditionalJSON writes the json data with an ETag of it. It responds with 304 and no body
+// when the client already has the same data, i.e. the If-None-Match header matches the ETag.
+func writeConditionalJSON(w http.ResponseWriter, r *http.Request, data []byte) {
+	hash := sha256.Sum256(data)
+	etag := "\"" + hex.EncodeToString(hash[:16]) + "\""
+	w.Header().Set("ETag", etag)
+
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if len(ifNoneMatch) > 0 {
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"content/core/model"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetCursorQueryParam(t *testing.T) {
+	cursor := model.ContentItemsCursor{DateCreated: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), ID: "id1"}
+	tests := []struct {
+		name       string
+		query      string
+		wantCursor bool
+		wantValue  *model.ContentItemsCursor
+		wantErr    bool
+	}{
+		{name: "not requested", query: ""},
+		{name: "first page", query: "?cursor=", wantCursor: true},
+		{name: "next page", query: "?cursor=" + cursor.Encode(), wantCursor: true, wantValue: &cursor},
+		{name: "invalid", query: "?cursor=abc", wantCursor: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/content/content_items"+tt.query, nil)
+			gotCursor, gotValue, err := getCursorQueryParam(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getCursorQueryParam() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotCursor != tt.wantCursor {
+				t.Errorf("getCursorQueryParam() cursor = %v, want %v", gotCursor, tt.wantCursor)
+			}
+			if (gotValue == nil) != (tt.wantValue == nil) || (gotValue != nil && gotValue.ID != tt.wantValue.ID) {
+				t.Errorf("getCursorQueryParam() value = %+v, want %+v", gotValue, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestCheckCursorOrder(t *testing.T) {
+	position := int64(1)
+	positionOrder := model.ContentItemsOrderPosition
+	dateOrder := "asc"
+	tests := []struct {
+		name    string
+		cursor  *model.ContentItemsCursor
+		order   *string
+		wantErr bool
+	}{
+		{name: "no cursor", order: &positionOrder},
+		{name: "date cursor", cursor: &model.ContentItemsCursor{ID: "id1"}, order: &dateOrder},
+		{name: "date cursor with the default order", cursor: &model.ContentItemsCursor{ID: "id1"}},
+		{name: "position cursor", cursor: &model.ContentItemsCursor{ID: "id1", Position: &position}, order: &positionOrder},
+		{name: "date cursor for the position order", cursor: &model.ContentItemsCursor{ID: "id1"}, order: &positionOrder, wantErr: true},
+		{name: "position cursor for the date order", cursor: &model.ContentItemsCursor{ID: "id1", Position: &position}, order: &dateOrder, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkCursorOrder(tt.cursor, tt.order); (err != nil) != tt.wantErr {
+				t.Errorf("checkCursorOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetIfMatchVersion(t *testing.T) {
+	version := int64(3)
+	tests := []struct {
+		name    string
+		header  string
+		want    *int64
+		wantErr bool
+	}{
+		{name: "missing"},
+		{name: "any", header: "*"},
+		{name: "quoted", header: `"3"`, want: &version},
+		{name: "weak", header: `W/"3"`, want: &version},
+		{name: "spaces", header: ` "3" `, want: &version},
+		{name: "not a version", header: `"abc"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("PUT", "/content/admin/content_items/id1", nil)
+			if len(tt.header) > 0 {
+				r.Header.Set("If-Match", tt.header)
+			}
+			got, err := getIfMatchVersion(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getIfMatchVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("getIfMatchVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleVersionMismatchError(t *testing.T) {
+	mismatchErr := &model.VersionMismatchError{Resource: "content item", ID: "id1", Expected: 2, Current: 3}
+	tests := []struct {
+		name       string
+		err        error
+		wantHandle bool
+		wantStatus int
+		wantETag   string
+	}{
+		{name: "mismatch", err: mismatchErr, wantHandle: true, wantStatus: http.StatusPreconditionFailed, wantETag: `"3"`},
+		{name: "wrapped mismatch", err: fmt.Errorf("update failed: %w", mismatchErr), wantHandle: true, wantStatus: http.StatusPreconditionFailed, wantETag: `"3"`},
+		{name: "other error", err: errors.New("not found"), wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if got := handleVersionMismatchError(w, tt.err); got != tt.wantHandle {
+				t.Errorf("handleVersionMismatchError() = %v, want %v", got, tt.wantHandle)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+		})
+	}
+}
+
+func TestWriteConditionalJSON(t *testing.T) {
+	data := []byte(`{"id":"id1"}`)
+	first := httptest.NewRecorder()
+	writeConditionalJSON(first, httptest.NewRequest("GET", "/content/content_items/id1", nil), data)
+	etag := first.Header().Get("ETag")
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+		wantBody    string
+	}{
+		{name: "no header", wantStatus: http.StatusOK, wantBody: string(data)},
+		{name: "same data", ifNoneMatch: etag, wantStatus: http.StatusNotModified},
+		{name: "weak tag in a list", ifNoneMatch: `"other", W/` + etag, wantStatus: http.StatusNotModified},
+		{name: "any", ifNoneMatch: "*", wantStatus: http.StatusNotModified},
+		{name: "changed data", ifNoneMatch: `"other"`, wantStatus: http.StatusOK, wantBody: string(data)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/content/content_items/id1", nil)
+			if len(tt.ifNoneMatch) > 0 {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			writeConditionalJSON(w, r, data)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+			if w.Header().Get("ETag") != etag {
+				t.Errorf("ETag = %q, want %q", w.Header().Get("ETag"), etag)
+			}
+		})
+	}
+}
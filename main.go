@@ -22,10 +22,12 @@ import (
 	corebb "content/driven/core"
 	storage "content/driven/storage"
 	"content/driven/twitter"
+	"content/driven/webhooks"
 	driver "content/driver/web"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rokwire/core-auth-library-go/v3/authservice"
 	"github.com/rokwire/core-auth-library-go/v3/envloader"
@@ -83,7 +85,12 @@ func main() {
 	mongoDBAuth := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_AUTH", true, true)
 	mongoDBName := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_DATABASE", true, false)
 	mongoTimeout := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_TIMEOUT", false, false)
-	storageAdapter := storage.NewStorageAdapter(mongoDBAuth, mongoDBName, mongoTimeout, logger)
+	searchDataPathsStr := envLoader.GetAndLogEnvVar(envPrefix+"SEARCH_DATA_PATHS", false, false)
+	searchDataPaths := []string{"title", "description"}
+	if searchDataPathsStr != "" {
+		searchDataPaths = strings.Split(searchDataPathsStr, ",")
+	}
+	storageAdapter := storage.NewStorageAdapter(mongoDBAuth, mongoDBName, mongoTimeout, searchDataPaths, logger)
 	err = storageAdapter.Start()
 	if err != nil {
 		log.Fatal("Cannot start the mongoDB adapter - " + err.Error())
@@ -130,6 +137,17 @@ func main() {
 	twitterAccessToken := envLoader.GetAndLogEnvVar(envPrefix+"TWITTER_ACCESS_TOKEN", true, true)
 	twitterAdapter := twitter.NewTwitterAdapter(twitterFeedURL, twitterAccessToken)
 
+	webhooksTimeoutSecondsStr := envLoader.GetAndLogEnvVar(envPrefix+"WEBHOOKS_TIMEOUT_SECONDS", false, false)
+	webhooksTimeoutSeconds := 10
+	if webhooksTimeoutSecondsStr != "" {
+		webhooksTimeoutSeconds, err = strconv.Atoi(webhooksTimeoutSecondsStr)
+		if err != nil || webhooksTimeoutSeconds <= 0 {
+			logger.Warnf("error parsing webhooks timeout seconds: %s - applying default", webhooksTimeoutSecondsStr)
+			webhooksTimeoutSeconds = 10
+		}
+	}
+	webhooksAdapter := webhooks.NewWebhooksAdapter(time.Second * time.Duration(webhooksTimeoutSeconds))
+
 	mtAppID := envLoader.GetAndLogEnvVar(envPrefix+"MULTI_TENANCY_APP_ID", true, true)
 	mtOrgID := envLoader.GetAndLogEnvVar(envPrefix+"MULTI_TENANCY_ORG_ID", true, true)
 
@@ -162,8 +180,25 @@ func main() {
 	}
 	coreAdapter := corebb.NewCoreAdapter(coreBBHost, serviceAccountManager)
 
+	localeFallbackStr := envLoader.GetAndLogEnvVar(envPrefix+"LOCALE_FALLBACK", false, false)
+	localeFallback := []string{"en"}
+	if localeFallbackStr != "" {
+		localeFallback = strings.Split(localeFallbackStr, ",")
+	}
+
+	trashRetentionDaysStr := envLoader.GetAndLogEnvVar(envPrefix+"TRASH_RETENTION_DAYS", false, false)
+	trashRetentionDays := 30
+	if trashRetentionDaysStr != "" {
+		trashRetentionDays, err = strconv.Atoi(trashRetentionDaysStr)
+		if err != nil || trashRetentionDays < 0 {
+			logger.Warnf("error parsing trash retention days: %s - applying default", trashRetentionDaysStr)
+			trashRetentionDays = 30
+		}
+	}
+	trashRetention := time.Hour * 24 * time.Duration(trashRetentionDays)
+
 	// application
-	application := core.NewApplication(Version, Build, storageAdapter, awsAdapter, twitterAdapter, cacheAdapter, mtAppID, mtOrgID, serviceID, coreAdapter, logger)
+	application := core.NewApplication(Version, Build, storageAdapter, awsAdapter, twitterAdapter, cacheAdapter, webhooksAdapter, mtAppID, mtOrgID, serviceID, coreAdapter, localeFallback, trashRetention, logger)
 	application.Start()
 
 	// web adapter
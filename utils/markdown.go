@@ -0,0 +1,587 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	markdownHeading     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	markdownRule        = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	markdownFence       = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*?)[ \t]*$")
+	markdownQuote       = regexp.MustCompile(`^ {0,3}> ?`)
+	markdownListItem    = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])([ \t]+|$)`)
+	markdownSetextLine  = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	markdownAutolink    = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^<>\s]*)>`)
+	markdownTextNewline = regexp.MustCompile(`\n{3,}`)
+	htmlTextSpaces      = regexp.MustCompile(`\s+`)
+)
+
+// RenderMarkdown gives the html of the markdown. It supports the headings, the paragraphs, the emphasis, the code, the links, the images,
+// the lists, the block quotes and the horizontal rules. The html within the markdown is escaped, so the result should be sanitized only for the urls.
+// For example:
+// # Title\n\nSome **bold** text with a [link](https://illinois.edu) -> <h1>Title</h1>\n<p>Some <strong>bold</strong> text with a <a href="https://illinois.edu">link</a></p>
+func RenderMarkdown(input string) string {
+	input = strings.ReplaceAll(strings.ReplaceAll(input, "\r\n", "\n"), "\r", "\n")
+	lines := strings.Split(strings.ReplaceAll(input, "\t", "    "), "\n")
+	return strings.TrimSuffix(renderMarkdownBlocks(lines, false), "\n")
+}
+
+// renderMarkdownBlocks renders the block elements of the lines. The paragraphs are not wrapped when the lines are the content of a tight list item.
+func renderMarkdownBlocks(lines []string, tight bool) string {
+	var output strings.Builder
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case len(strings.TrimSpace(line)) == 0:
+			i++
+		case markdownFence.MatchString(line):
+			i = renderMarkdownFence(lines, i, &output)
+		case markdownHeading.MatchString(line):
+			match := markdownHeading.FindStringSubmatch(line)
+			level := strconv.Itoa(len(match[1]))
+			output.WriteString("<h" + level + ">" + renderMarkdownInline(match[2]) + "</h" + level + ">\n")
+			i++
+		case markdownRule.MatchString(line):
+			output.WriteString("<hr/>\n")
+			i++
+		case markdownQuote.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && markdownQuote.MatchString(lines[i]); i++ {
+				quoted = append(quoted, markdownQuote.ReplaceAllString(lines[i], ""))
+			}
+			output.WriteString("<blockquote>\n" + renderMarkdownBlocks(quoted, false) + "</blockquote>\n")
+		case markdownListItem.MatchString(line):
+			i = renderMarkdownList(lines, i, &output)
+		case strings.HasPrefix(line, "    "):
+			var code []string
+			for ; i < len(lines) && (strings.HasPrefix(lines[i], "    ") || len(strings.TrimSpace(lines[i])) == 0); i++ {
+				code = append(code, strings.TrimPrefix(lines[i], "    "))
+			}
+			for len(code) > 0 && len(strings.TrimSpace(code[len(code)-1])) == 0 {
+				code = code[:len(code)-1]
+			}
+			output.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "\n</code></pre>\n")
+		default:
+			i = renderMarkdownParagraph(lines, i, tight, &output)
+		}
+	}
+	return output.String()
+}
+
+// renderMarkdownFence renders the fenced code block which starts on the line. It gives the line after the block.
+func renderMarkdownFence(lines []string, start int, output *strings.Builder) int {
+	match := markdownFence.FindStringSubmatch(lines[start])
+	indent, fence, info := len(match[1]), match[2], strings.Fields(match[3])
+
+	var code []string
+	i := start + 1
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && len(strings.Trim(trimmed, fence[:1])) == 0 {
+			i++
+			break
+		}
+		line := lines[i]
+		for j := 0; j < indent && strings.HasPrefix(line, " "); j++ {
+			line = line[1:]
+		}
+		code = append(code, line)
+	}
+
+	output.WriteString("<pre><code")
+	if len(info) > 0 {
+		output.WriteString(` class="language-` + html.EscapeString(info[0]) + `"`)
+	}
+	output.WriteString(">")
+	if len(code) > 0 {
+		output.WriteString(html.EscapeString(strings.Join(code, "\n")) + "\n")
+	}
+	output.WriteString("</code></pre>\n")
+	return i
+}
+
+// renderMarkdownList renders the list which starts on the line. It gives the line after the list.
+func renderMarkdownList(lines []string, start int, output *strings.Builder) int {
+	first := markdownListItem.FindStringSubmatch(lines[start])
+	marker := first[2]
+	ordered := marker[len(marker)-1] == '.' || marker[len(marker)-1] == ')'
+	delimiter := marker[len(marker)-1:]
+
+	var items [][]string
+	tight := true
+	i := start
+	for i < len(lines) {
+		match := markdownListItem.FindStringSubmatch(lines[i])
+		if match == nil || markdownRule.MatchString(lines[i]) {
+			break
+		}
+		itemMarker := match[2]
+		if ordered != (itemMarker[len(itemMarker)-1] == '.' || itemMarker[len(itemMarker)-1] == ')') ||
+			(ordered && itemMarker[len(itemMarker)-1:] != delimiter) || (!ordered && itemMarker != marker) {
+			break
+		}
+
+		//the item continues with the lines which are indented as its content
+		contentIndent := len(match[0])
+		if len(match[3]) == 0 {
+			contentIndent = len(match[1]) + len(itemMarker) + 1
+		}
+		item := []string{lines[i][len(match[0]):]}
+		i++
+		for i < len(lines) {
+			line := lines[i]
+			if len(strings.TrimSpace(line)) == 0 {
+				//a blank line is within the item only when the item continues after it
+				next := i + 1
+				for next < len(lines) && len(strings.TrimSpace(lines[next])) == 0 {
+					next++
+				}
+				if next < len(lines) && markdownIndent(lines[next]) >= contentIndent {
+					tight = false
+					for ; i < next; i++ {
+						item = append(item, "")
+					}
+					continue
+				}
+				if next < len(lines) && markdownListItem.MatchString(lines[next]) {
+					tight = false
+				}
+				i = next
+				break
+			}
+			if markdownIndent(line) >= contentIndent {
+				item = append(item, line[contentIndent:])
+				i++
+				continue
+			}
+			if markdownListItem.MatchString(line) || markdownHeading.MatchString(line) || markdownFence.MatchString(line) ||
+				markdownQuote.MatchString(line) || markdownRule.MatchString(line) {
+				break
+			}
+			//lazy continuation of the paragraph
+			item = append(item, strings.TrimSpace(line))
+			i++
+		}
+		items = append(items, item)
+		if i < len(lines) && !markdownListItem.MatchString(lines[i]) {
+			break
+		}
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		number, _ := strconv.Atoi(strings.TrimRight(marker, ".)"))
+		if number != 1 {
+			output.WriteString(`<ol start="` + strconv.Itoa(number) + `">` + "\n")
+		} else {
+			output.WriteString("<ol>\n")
+		}
+	} else {
+		output.WriteString("<ul>\n")
+	}
+	for _, item := range items {
+		content := renderMarkdownBlocks(item, tight)
+		if tight && !strings.Contains(strings.TrimSuffix(content, "\n"), "\n") {
+			output.WriteString("<li>" + strings.TrimSuffix(content, "\n") + "</li>\n")
+		} else {
+			output.WriteString("<li>\n" + content + "</li>\n")
+		}
+	}
+	output.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// renderMarkdownParagraph renders the paragraph or the setext heading which starts on the line. It gives the line after it.
+func renderMarkdownParagraph(lines []string, start int, tight bool, output *strings.Builder) int {
+	paragraph := []string{strings.TrimSpace(lines[start])}
+	i := start + 1
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if len(strings.TrimSpace(line)) == 0 {
+			break
+		}
+		if match := markdownSetextLine.FindStringSubmatch(line); match != nil {
+			level := "1"
+			if match[1][0] == '-' {
+				level = "2"
+			}
+			output.WriteString("<h" + level + ">" + renderMarkdownInline(strings.Join(paragraph, "\n")) + "</h" + level + ">\n")
+			return i + 1
+		}
+		if markdownHeading.MatchString(line) || markdownFence.MatchString(line) || markdownQuote.MatchString(line) ||
+			markdownRule.MatchString(line) || markdownListItem.MatchString(line) {
+			break
+		}
+		paragraph = append(paragraph, strings.TrimLeft(line, " "))
+	}
+
+	content := renderMarkdownInline(strings.Join(paragraph, "\n"))
+	if tight {
+		output.WriteString(content + "\n")
+	} else {
+		output.WriteString("<p>" + content + "</p>\n")
+	}
+	return i
+}
+
+// markdownIndent gives the count of the spaces at the beginning of the line
+func markdownIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// renderMarkdownInline renders the inline elements of the text - the emphasis, the code, the links, the images and the line breaks
+func renderMarkdownInline(text string) string {
+	var output strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			output.WriteString("<br/>\n")
+			i += 2
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", text[i+1]) >= 0:
+			output.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+		case c == '`':
+			run := markdownRun(text, i)
+			end := strings.Index(text[i+run:], text[i:i+run])
+			for end >= 0 && i+run+end+run < len(text) && text[i+run+end+run] == '`' {
+				//the closing run must have the same length
+				next := strings.Index(text[i+run+end+run:], text[i:i+run])
+				if next < 0 {
+					end = -1
+					break
+				}
+				end += run + next
+			}
+			if end < 0 {
+				output.WriteString(text[i : i+run])
+				i += run
+				continue
+			}
+			code := strings.ReplaceAll(text[i+run:i+run+end], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+				code = code[1 : len(code)-1]
+			}
+			output.WriteString("<code>" + html.EscapeString(code) + "</code>")
+			i += run + end + run
+		case c == '*' || c == '_':
+			rendered, next := renderMarkdownEmphasis(text, i)
+			output.WriteString(rendered)
+			i = next
+		case c == '!' && i+1 < len(text) && text[i+1] == '[':
+			label, url, title, next, ok := parseMarkdownLink(text, i+1)
+			if !ok {
+				output.WriteString("!")
+				i++
+				continue
+			}
+			output.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(markdownPlainText(label)) + `"`)
+			if len(title) > 0 {
+				output.WriteString(` title="` + html.EscapeString(title) + `"`)
+			}
+			output.WriteString("/>")
+			i = next
+		case c == '[':
+			label, url, title, next, ok := parseMarkdownLink(text, i)
+			if !ok {
+				output.WriteString("[")
+				i++
+				continue
+			}
+			output.WriteString(`<a href="` + html.EscapeString(url) + `"`)
+			if len(title) > 0 {
+				output.WriteString(` title="` + html.EscapeString(title) + `"`)
+			}
+			output.WriteString(">" + renderMarkdownInline(label) + "</a>")
+			i = next
+		case c == '<' && markdownAutolink.MatchString(text[i:]):
+			match := markdownAutolink.FindStringSubmatch(text[i:])
+			output.WriteString(`<a href="` + html.EscapeString(match[1]) + `">` + html.EscapeString(match[1]) + "</a>")
+			i += len(match[0])
+		case c == '\n':
+			//two spaces at the end of the line are a line break
+			current := output.String()
+			trimmed := strings.TrimRight(current, " ")
+			if len(current)-len(trimmed) >= 2 {
+				output.Reset()
+				output.WriteString(trimmed + "<br/>")
+			} else if len(current) != len(trimmed) {
+				output.Reset()
+				output.WriteString(trimmed)
+			}
+			output.WriteString("\n")
+			i++
+		default:
+			output.WriteString(html.EscapeString(text[i : i+1]))
+			i++
+		}
+	}
+	return output.String()
+}
+
+// renderMarkdownEmphasis renders the emphasis which is opened at the index. It gives the delimiters as text when they are not closed.
+// It gives the index after what has been rendered.
+func renderMarkdownEmphasis(text string, start int) (string, int) {
+	c := text[start]
+	run := markdownRun(text, start)
+	after := start + run
+	//the opening delimiters must be followed by text and the underscores must not be within a word
+	if after >= len(text) || isMarkdownSpace(text[after]) || (c == '_' && start > 0 && isMarkdownWordChar(text[start-1])) {
+		return text[start:after], after
+	}
+
+	size := run
+	if size > 3 {
+		size = 3
+	}
+	for ; size > 0; size-- {
+		end := findMarkdownEmphasisEnd(text, after-(run-size), c, size)
+		if end < 0 {
+			continue
+		}
+		opening := start + run - size
+		inner := renderMarkdownInline(text[opening+size : end])
+		switch size {
+		case 1:
+			inner = "<em>" + inner + "</em>"
+		case 2:
+			inner = "<strong>" + inner + "</strong>"
+		default:
+			inner = "<em><strong>" + inner + "</strong></em>"
+		}
+		return strings.Repeat(string(c), run-size) + inner, end + size
+	}
+	return text[start:after], after
+}
+
+// findMarkdownEmphasisEnd gives the index of the closing delimiters of the size, or -1 when there are not such
+func findMarkdownEmphasisEnd(text string, from int, c byte, size int) int {
+	for i := from; i < len(text); {
+		switch text[i] {
+		case '\\':
+			i += 2
+		case '`':
+			run := markdownRun(text, i)
+			end := strings.Index(text[i+run:], text[i:i+run])
+			if end < 0 {
+				i += run
+			} else {
+				i += run + end + run
+			}
+		case c:
+			run := markdownRun(text, i)
+			closing := i > from && !isMarkdownSpace(text[i-1]) &&
+				(c != '_' || i+run >= len(text) || !isMarkdownWordChar(text[i+run]))
+			if closing && (run == size || (run > size && size == 3)) {
+				return i
+			}
+			//the other sizes are for the inner emphasis
+			i += run
+		default:
+			i++
+		}
+	}
+	return -1
+}
+
+// parseMarkdownLink parses the [label](url "title") which starts at the index. It gives the index after it.
+func parseMarkdownLink(text string, start int) (string, string, string, int, bool) {
+	depth := 0
+	labelEnd := -1
+	for i := start; i < len(text) && labelEnd < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				labelEnd = i
+			}
+		}
+	}
+	if labelEnd < 0 || labelEnd+1 >= len(text) || text[labelEnd+1] != '(' {
+		return "", "", "", 0, false
+	}
+
+	i := labelEnd + 2
+	for i < len(text) && isMarkdownSpace(text[i]) {
+		i++
+	}
+	var url string
+	if i < len(text) && text[i] == '<' {
+		end := strings.IndexAny(text[i+1:], ">\n")
+		if end < 0 || text[i+1+end] != '>' {
+			return "", "", "", 0, false
+		}
+		url = text[i+1 : i+1+end]
+		i += end + 2
+	} else {
+		urlStart := i
+		parentheses := 0
+		for ; i < len(text) && !isMarkdownSpace(text[i]); i++ {
+			if text[i] == '(' {
+				parentheses++
+			} else if text[i] == ')' {
+				if parentheses == 0 {
+					break
+				}
+				parentheses--
+			}
+		}
+		url = text[urlStart:i]
+	}
+
+	for i < len(text) && isMarkdownSpace(text[i]) {
+		i++
+	}
+	var title string
+	if i < len(text) && (text[i] == '"' || text[i] == '\'') {
+		end := strings.IndexByte(text[i+1:], text[i])
+		if end < 0 {
+			return "", "", "", 0, false
+		}
+		title = text[i+1 : i+1+end]
+		i += end + 2
+		for i < len(text) && isMarkdownSpace(text[i]) {
+			i++
+		}
+	}
+	if i >= len(text) || text[i] != ')' {
+		return "", "", "", 0, false
+	}
+	return text[start+1 : labelEnd], url, title, i + 1, true
+}
+
+// markdownRun gives the count of the same characters starting at the index
+func markdownRun(text string, start int) int {
+	run := 1
+	for start+run < len(text) && text[start+run] == text[start] {
+		run++
+	}
+	return run
+}
+
+// markdownPlainText gives the text of the inline markdown, it is used for the alternative text of the images
+func markdownPlainText(text string) string {
+	return HTMLText(renderMarkdownInline(text))
+}
+
+func isMarkdownSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isMarkdownWordChar(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+// HTMLText gives the text of the html. The block elements are on separate lines and the images are replaced by their alternative text.
+// For example:
+// <h1>Title</h1><p>Some <b>bold</b> text</p><ul><li>one</li><li>two</li></ul> -> Title\n\nSome bold text\n\none\ntwo
+func HTMLText(input string) string {
+	var output strings.Builder
+	skipped := "" //the raw text tag whose text is left out
+	pre := 0      //the whitespaces are kept within the pre tags
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			//the end of the input
+			return strings.TrimSpace(markdownTextNewline.ReplaceAllString(output.String(), "\n\n"))
+		case html.TextToken:
+			if len(skipped) > 0 {
+				continue
+			}
+			text := string(tokenizer.Text())
+			if pre == 0 {
+				//the whitespaces are shown as one space as in the browsers
+				text = htmlTextSpaces.ReplaceAllString(text, " ")
+				current := output.String()
+				if len(current) == 0 || strings.HasSuffix(current, " ") || strings.HasSuffix(current, "\n") {
+					text = strings.TrimLeft(text, " ")
+				}
+			}
+			output.WriteString(text)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Data == "pre" && tokenType == html.StartTagToken {
+				pre++
+			}
+			switch {
+			case tokenType == html.StartTagToken && isRawTextTag(token.Data):
+				skipped = token.Data
+			case token.Data == "br":
+				output.WriteString("\n")
+			case token.Data == "img":
+				for _, attribute := range token.Attr {
+					if attribute.Key == "alt" {
+						output.WriteString(attribute.Val)
+					}
+				}
+			case token.Data == "li" || token.Data == "tr" || token.Data == "ul" || token.Data == "ol":
+				writeTextLineBreak(&output, "\n")
+			case isHTMLTextBlock(token.Data):
+				writeTextLineBreak(&output, "\n\n")
+			case token.Data == "td" || token.Data == "th":
+				output.WriteString(" ")
+			}
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if token.Data == skipped {
+				skipped = ""
+				continue
+			}
+			if token.Data == "pre" && pre > 0 {
+				pre--
+			}
+			if token.Data == "li" || token.Data == "tr" || token.Data == "ul" || token.Data == "ol" {
+				writeTextLineBreak(&output, "\n")
+			} else if isHTMLTextBlock(token.Data) {
+				writeTextLineBreak(&output, "\n\n")
+			}
+		}
+	}
+}
+
+// writeTextLineBreak ends the current line of the text, it does nothing at the beginning of the text
+func writeTextLineBreak(output *strings.Builder, lineBreak string) {
+	text := strings.TrimRight(output.String(), " ")
+	if len(text) == 0 {
+		return
+	}
+	if strings.HasSuffix(text, lineBreak) {
+		lineBreak = ""
+	}
+	output.Reset()
+	output.WriteString(text + lineBreak)
+}
+
+// isHTMLTextBlock checks if the tag is a block whose text is separated by an empty line
+func isHTMLTextBlock(tag string) bool {
+	switch tag {
+	case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "pre", "table", "hr", "section", "article", "header", "footer":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import "testing"
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "heading and paragraph", input: "# Title\n\nSome **bold** text with a [link](https://illinois.edu)",
+			want: "<h1>Title</h1>\n<p>Some <strong>bold</strong> text with a <a href=\"https://illinois.edu\">link</a></p>"},
+		{name: "setext heading", input: "Title\n=====", want: "<h1>Title</h1>"},
+		{name: "emphasis and code", input: "*em* and _em_ and `code <b>`",
+			want: "<p><em>em</em> and <em>em</em> and <code>code &lt;b&gt;</code></p>"},
+		{name: "bullet list", input: "- one\n- two", want: "<ul>\n<li>one</li>\n<li>two</li>\n</ul>"},
+		{name: "ordered list", input: "1. one\n2. two", want: "<ol>\n<li>one</li>\n<li>two</li>\n</ol>"},
+		{name: "block quote", input: "> quoted\n> text", want: "<blockquote>\n<p>quoted\ntext</p>\n</blockquote>"},
+		{name: "fenced code", input: "```go\nx := 1 < 2\n```", want: "<pre><code class=\"language-go\">x := 1 &lt; 2\n</code></pre>"},
+		{name: "indented code", input: "    indented", want: "<pre><code>indented\n</code></pre>"},
+		{name: "rule", input: "---", want: "<hr/>"},
+		{name: "image", input: "![alt](/a.png \"t\")", want: "<p><img src=\"/a.png\" alt=\"alt\" title=\"t\"/></p>"},
+		{name: "autolink", input: "<https://illinois.edu>", want: "<p><a href=\"https://illinois.edu\">https://illinois.edu</a></p>"},
+		{name: "html is escaped", input: "<script>alert(1)</script>", want: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{name: "urls are left to the sanitizer", input: "[x](javascript:alert(1))", want: "<p><a href=\"javascript:alert(1)\">x</a></p>"},
+		{name: "windows line endings", input: "line one\r\nline two", want: "<p>line one\nline two</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.input); got != tt.want {
+				t.Errorf("RenderMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "blocks", input: "<h1>Title</h1><p>Some <b>bold</b> text</p><ul><li>one</li><li>two</li></ul>",
+			want: "Title\n\nSome bold text\n\none\ntwo"},
+		{name: "image", input: "<p>a<img alt=\"pic\"/>b</p>", want: "apicb"},
+		{name: "entities and scripts", input: "<p>a &amp; b</p><script>x</script>", want: "a & b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLText(tt.input); got != tt.want {
+				t.Errorf("HTMLText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package utils
 import (
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"sort"
 	"strconv"
@@ -24,6 +25,7 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
 )
 
 // Filter represents find filter for finding entities by the their fields
@@ -114,6 +116,129 @@ func ModifyHTMLContent(input string) string {
 	return final
 }
 
+// urlAttributes are the attributes which have urls, their schemes are checked
+var urlAttributes = map[string]bool{"href": true, "src": true, "srcset": true, "cite": true, "action": true, "formaction": true,
+	"poster": true, "background": true, "longdesc": true, "data": true, "xlink:href": true}
+
+// SanitizeHTML keeps only the allowed tags and attributes. The text of the tags which are not allowed is kept, except for the raw text ones like script and style.
+// allowedAttributes are by tag, the ones for "*" are allowed for all the tags. The urls in the attributes must have one of the allowed schemes or be relative.
+// For example with the tags p and a, the attribute href for a and the scheme https:
+// <p onclick="f()">Some <b>text</b><script>f()</script></p><a href="javascript:f()">link</a> -> <p>Some text</p><a>link</a>
+func SanitizeHTML(input string, allowedTags []string, allowedAttributes map[string][]string, allowedURLSchemes []string) string {
+	tags := map[string]bool{}
+	for _, tag := range allowedTags {
+		tags[strings.ToLower(tag)] = true
+	}
+	attributes := map[string]bool{}
+	for tag, names := range allowedAttributes {
+		for _, name := range names {
+			attributes[strings.ToLower(tag)+" "+strings.ToLower(name)] = true
+		}
+	}
+	schemes := map[string]bool{}
+	for _, scheme := range allowedURLSchemes {
+		schemes[strings.ToLower(scheme)] = true
+	}
+
+	var output strings.Builder
+	skipped := "" //the raw text tag whose text is left out
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			//the end of the input
+			return output.String()
+		case html.TextToken:
+			if len(skipped) == 0 {
+				output.WriteString(html.EscapeString(string(tokenizer.Text())))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if !tags[token.Data] {
+				if tokenType == html.StartTagToken && isRawTextTag(token.Data) {
+					skipped = token.Data
+				}
+				continue
+			}
+			output.WriteString("<" + token.Data)
+			for _, attribute := range token.Attr {
+				name := strings.ToLower(attribute.Key)
+				if len(attribute.Namespace) > 0 {
+					name = strings.ToLower(attribute.Namespace) + ":" + name
+				}
+				if !attributes[token.Data+" "+name] && !attributes["* "+name] {
+					continue
+				}
+				if urlAttributes[name] && !allowedURLs(name, attribute.Val, schemes) {
+					continue
+				}
+				output.WriteString(" " + name + `="` + html.EscapeString(attribute.Val) + `"`)
+			}
+			if tokenType == html.SelfClosingTagToken {
+				output.WriteString("/>")
+			} else {
+				output.WriteString(">")
+			}
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if token.Data == skipped {
+				skipped = ""
+				continue
+			}
+			if tags[token.Data] {
+				output.WriteString("</" + token.Data + ">")
+			}
+		}
+		//the comments and the doctypes are left out
+	}
+}
+
+// isRawTextTag checks if the text of the tag is not html, so it is not kept when the tag is not allowed
+func isRawTextTag(tag string) bool {
+	switch tag {
+	case "script", "style", "iframe", "noembed", "noframes", "noscript", "plaintext", "textarea", "title", "xmp", "template":
+		return true
+	}
+	return false
+}
+
+// allowedURLs checks if the urls in the attribute value have allowed schemes, srcset has a list of urls
+func allowedURLs(attribute string, value string, schemes map[string]bool) bool {
+	urls := []string{value}
+	if attribute == "srcset" {
+		urls = nil
+		for _, candidate := range strings.Split(value, ",") {
+			fields := strings.Fields(candidate)
+			if len(fields) > 0 {
+				urls = append(urls, fields[0])
+			}
+		}
+	}
+	for _, url := range urls {
+		if !allowedURL(url, schemes) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowedURL checks if the url has an allowed scheme or it is relative. The browsers ignore the whitespaces and the control characters in the schemes, so they are removed first.
+func allowedURL(url string, schemes map[string]bool) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, url)
+	end := strings.IndexAny(cleaned, ":/?#")
+	if end < 0 || cleaned[end] != ':' {
+		//relative url
+		return true
+	}
+	return schemes[strings.ToLower(cleaned[:end])]
+}
+
 // LogRequest logs the request as hide some header fields because of security reasons
 func LogRequest(req *http.Request) {
 	if req == nil {
@@ -216,6 +341,18 @@ func GetTime(time *time.Time) string {
 	return fmt.Sprintf("%s", time)
 }
 
+// GetListValue gives the values of a comma separated list without the empty ones
+func GetListValue(value string) []string {
+	list := []string{}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if len(element) > 0 {
+			list = append(list, element)
+		}
+	}
+	return list
+}
+
 // SortVersions sorts the versions list. The format is x.x.x or x.x which is the short for x.x.0
 func SortVersions(versions []string) {
 	//sort
@@ -277,3 +414,34 @@ func IsVersionLess(v1 string, v2 string) bool {
 	// they are equals
 	return false
 }
+
+// VersionNumber checks the version and gives it as a number which keeps the order of the versions. The format is x.x.x or x.x which is the short for x.x.0
+func VersionNumber(version string) (int64, error) {
+	elements := strings.Split(version, ".")
+	if len(elements) != 2 && len(elements) != 3 {
+		return 0, fmt.Errorf("invalid version %s - the format is x.x.x or x.x", version)
+	}
+
+	var number int64
+	for i := 0; i < 3; i++ {
+		var element int64
+		if i < len(elements) {
+			value, err := strconv.ParseInt(elements[i], 10, 64)
+			if err != nil || value < 0 || value > 999999 {
+				return 0, fmt.Errorf("invalid version %s - the format is x.x.x or x.x", version)
+			}
+			element = value
+		}
+		number = number*1000000 + element
+	}
+	return number, nil
+}
+
+// IsPublicIP says if the ip address is reachable from the internet - it is not a loopback, private, link-local, multicast or unspecified address
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"net"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tags := []string{"p", "a", "b", "img"}
+	attributes := map[string][]string{"a": {"href"}, "img": {"src", "srcset", "alt"}, "*": {"title"}}
+	schemes := []string{"https", "mailto"}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "allowed", input: `<p title="t">Some <b>text</b></p>`, want: `<p title="t">Some <b>text</b></p>`},
+		{name: "not allowed tag keeps the text", input: `<p><i>text</i></p>`, want: `<p>text</p>`},
+		{name: "raw text tag is left out", input: `<p>a<script>alert(1)</script>b</p>`, want: `<p>ab</p>`},
+		{name: "not allowed attribute", input: `<p onclick="f()">text</p>`, want: `<p>text</p>`},
+		{name: "comment", input: `<p><!-- comment -->text</p>`, want: `<p>text</p>`},
+		{name: "text is escaped", input: `a < b & c`, want: `a &lt; b &amp; c`},
+		{name: "attribute is escaped", input: `<p title='"x"'>text</p>`, want: `<p title="&#34;x&#34;">text</p>`},
+		{name: "allowed scheme", input: `<a href="https://example.com">link</a>`, want: `<a href="https://example.com">link</a>`},
+		{name: "relative url", input: `<a href="/path?a=b:c">link</a>`, want: `<a href="/path?a=b:c">link</a>`},
+		{name: "javascript scheme", input: `<a href="javascript:alert(1)">link</a>`, want: `<a>link</a>`},
+		{name: "upper case scheme", input: `<a href="JavaScript:alert(1)">link</a>`, want: `<a>link</a>`},
+		{name: "entity in the scheme", input: `<a href="javascript&#58;alert(1)">link</a>`, want: `<a>link</a>`},
+		{name: "tab in the scheme", input: "<a href=\"java\tscript:alert(1)\">link</a>", want: `<a>link</a>`},
+		{name: "newline in the scheme", input: "<a href=\"java\nscript:alert(1)\">link</a>", want: `<a>link</a>`},
+		{name: "control character in the scheme", input: "<a href=\"\x01javascript:alert(1)\">link</a>", want: `<a>link</a>`},
+		{name: "leading spaces", input: `<a href="  javascript:alert(1)">link</a>`, want: `<a>link</a>`},
+		{name: "data scheme", input: `<img src="data:text/html;base64,PHNjcmlwdD4="/>`, want: `<img/>`},
+		{name: "srcset allowed", input: `<img srcset="https://example.com/a.png 1x, /b.png 2x"/>`,
+			want: `<img srcset="https://example.com/a.png 1x, /b.png 2x"/>`},
+		{name: "srcset with javascript", input: `<img srcset="https://example.com/a.png 1x, javascript:alert(1) 2x" alt="a"/>`,
+			want: `<img alt="a"/>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input, tags, attributes, schemes); got != tt.want {
+				t.Errorf("SanitizeHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    int64
+		wantErr bool
+	}{
+		{name: "full", version: "1.2.3", want: 1000002000003},
+		{name: "short", version: "1.2", want: 1000002000000},
+		{name: "zero", version: "0.0.0", want: 0},
+		{name: "largest elements", version: "999999.999999.999999", want: 999999999999999999},
+		{name: "one element", version: "1", wantErr: true},
+		{name: "four elements", version: "1.2.3.4", wantErr: true},
+		{name: "empty", version: "", wantErr: true},
+		{name: "empty element", version: "1..3", wantErr: true},
+		{name: "not a number", version: "1.2.beta", wantErr: true},
+		{name: "negative", version: "1.-2.3", wantErr: true},
+		{name: "element too large", version: "1.1000000.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VersionNumber(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VersionNumber() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("VersionNumber() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionNumberOrder(t *testing.T) {
+	versions := []string{"0.9.9", "1.0", "1.0.1", "1.9.0", "1.10.0", "2.0.0"}
+	for i := 1; i < len(versions); i++ {
+		previous, _ := VersionNumber(versions[i-1])
+		current, _ := VersionNumber(versions[i])
+		if previous >= current {
+			t.Errorf("VersionNumber(%s) = %d is not less than VersionNumber(%s) = %d", versions[i-1], previous, versions[i], current)
+		}
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.215.14", want: true},
+		{ip: "2606:4700:4700::1111", want: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.0.0.1"},
+		{ip: "172.16.5.4"},
+		{ip: "192.168.1.1"},
+		{ip: "fd00::1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "224.0.0.1"},
+		{ip: "ff02::1"},
+		{ip: "0.0.0.0"},
+		{ip: "::"},
+		{ip: "::ffff:127.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := IsPublicIP(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("IsPublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+	if IsPublicIP(nil) {
+		t.Error("IsPublicIP(nil) = true, want false")
+	}
+}